@@ -26,6 +26,13 @@ type MultiCacheGroup struct {
 	// resourceId:os:arch:channel / cache empty
 	MultiVersionInfoCache *Cache[string, *model.MultiVersionInfo]
 
+	// key: resourceId:targetVersionId:currentVersionId:os:arch:format / cache empty
+	// short lived so patches generated on other instances join the chains
+	PatchChainCache *Cache[string, *model.PatchChain]
+
+	// key: resourceId:channel, published versions of the channel chain in release order
+	ChangelogCache *Cache[string, *model.ChangelogIndex]
+
 	ResourceInfoCache *Cache[string, *ent.Resource]
 }
 
@@ -38,6 +45,8 @@ func (g *MultiCacheGroup) EvictAll() {
 	g.VersionNameIdCache.EvictAll()
 	g.IncrementalUpdateInfoCache.EvictAll()
 	g.MultiVersionInfoCache.EvictAll()
+	g.PatchChainCache.EvictAll()
+	g.ChangelogCache.EvictAll()
 	g.ResourceInfoCache.EvictAll()
 }
 
@@ -47,17 +56,27 @@ func NewVersionCacheGroup(rdb *redis.Client) *MultiCacheGroup {
 		VersionNameIdCache:         NewCache[string, int](-1),
 		IncrementalUpdateInfoCache: NewCache[string, *model.IncrementalUpdateInfo](168 * time.Hour),
 		MultiVersionInfoCache:      NewCache[string, *model.MultiVersionInfo](168 * time.Hour),
+		PatchChainCache:            NewCache[string, *model.PatchChain](10 * time.Minute),
+		ChangelogCache:             NewCache[string, *model.ChangelogIndex](24 * time.Hour),
 		ResourceInfoCache:          NewCache[string, *ent.Resource](-1),
 	}
 	subscribeCacheEvict(rdb, group)
 	return group
 }
 
+// EvictChannel every instance clears its local caches on a message of this channel
+const EvictChannel = "evict"
+
+// PublishEvict local caches don't support iteration, evict all of them on every instance
+func PublishEvict(ctx context.Context, rdb *redis.Client, key string) error {
+	return rdb.Publish(ctx, EvictChannel, key).Err()
+}
+
 func subscribeCacheEvict(rdb *redis.Client, group *MultiCacheGroup) {
 	var (
 		logger  = zap.L()
 		cxt     = context.Background()
-		channel = "evict"
+		channel = EvictChannel
 	)
 
 	subscribe := rdb.Subscribe(cxt, channel)
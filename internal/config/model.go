@@ -11,6 +11,7 @@ type (
 		Auth     AuthConfig     `mapstructure:"auth"`
 		Redis    RedisConfig    `mapstructure:"redis"`
 		OSS      OSSConfig      `mapstructure:"oss"`
+		Blob     BlobConfig     `mapstructure:"blob"`
 		Extra    ExtraConfig    `mapstructure:"extra"`
 	}
 	InstanceConfig struct {
@@ -57,6 +58,36 @@ type (
 		DistributeCdnRatio        int                      `mapstructure:"distribute_cdn_ratio"`
 		DistributeCdnRegion       []string                 `mapstructure:"distribute_cdn_region"`
 		Concurrency               int32                    `mapstructure:"concurrency"`
+		MirrorProbe               MirrorProbeConfig        `mapstructure:"mirror_probe"`
+		// OrphanGracePeriod files nothing points to are deleted by the reconciler after it, defaults to 72h
+		OrphanGracePeriod time.Duration `mapstructure:"orphan_grace_period"`
+		Scrub             ScrubConfig   `mapstructure:"scrub"`
+		// DefaultLocale language of release notes uploaded without a locale, defaults to zh-CN
+		DefaultLocale string `mapstructure:"default_locale"`
+	}
+
+	ScrubConfig struct {
+		// Cron of the scrub task, defaults to 0 3 * * ?
+		Cron string `mapstructure:"cron"`
+		// BytesPerRun packages re-hashed by one run, defaults to 10GiB
+		BytesPerRun int64 `mapstructure:"bytes_per_run"`
+		// BytesPerSecond read rate while hashing, 0 is unlimited
+		BytesPerSecond int64 `mapstructure:"bytes_per_second"`
+		// Webhook receives every hash mismatch
+		Webhook string `mapstructure:"webhook"`
+		// Quarantine stops serving a mismatching storage until it is released
+		Quarantine bool `mapstructure:"quarantine"`
+	}
+
+	MirrorProbeConfig struct {
+		// Path probe object relative to the mirror url, empty disables probing
+		Path     string        `mapstructure:"path"`
+		Interval time.Duration `mapstructure:"interval"`
+		Timeout  time.Duration `mapstructure:"timeout"`
+		// Fall consecutive failures before a mirror is evicted
+		Fall int `mapstructure:"fall"`
+		// Rise consecutive successes before an evicted mirror is added back
+		Rise int `mapstructure:"rise"`
 	}
 
 	RobinServer struct {
@@ -65,7 +96,11 @@ type (
 	}
 
 	AuthConfig struct {
-		SignSecret            string `mapstructure:"sign_secret"`
+		SignSecret string `mapstructure:"sign_secret"`
+		// SignKeys kid -> secret of download tokens, keep retired keys until their tokens expire
+		SignKeys map[string]string `mapstructure:"sign_keys"`
+		// SignKeyId signs new download tokens, empty means sign_secret
+		SignKeyId             string `mapstructure:"sign_key_id"`
 		PrivateKey            string `mapstructure:"private_key"`
 		UploaderValidationURL string `mapstructure:"uploader_validation_url"`
 		CDKValidationURL      string `mapstructure:"cdk_validation_url"`
@@ -79,4 +114,28 @@ type (
 		SecretKey    string `mapstructure:"secret_key"`
 		Bucket       string `mapstructure:"bucket"`
 	}
+
+	BlobConfig struct {
+		// Driver local (default) or s3
+		Driver string `mapstructure:"driver"`
+		// WorkDir local directory for extracting and diffing packages, defaults to $PWD/storage
+		WorkDir string `mapstructure:"work_dir"`
+		// Root directory of the local driver, defaults to $PWD/oss
+		Root string `mapstructure:"root"`
+		// BaseURL the local root is served under, enables the blob distributor
+		BaseURL string       `mapstructure:"base_url"`
+		S3      BlobS3Config `mapstructure:"s3"`
+		// PresignTTL of the urls handed out by the blob distributor
+		PresignTTL time.Duration `mapstructure:"presign_ttl"`
+	}
+	BlobS3Config struct {
+		Endpoint  string `mapstructure:"endpoint"`
+		Region    string `mapstructure:"region"`
+		Bucket    string `mapstructure:"bucket"`
+		AccessKey string `mapstructure:"access_key"`
+		SecretKey string `mapstructure:"secret_key"`
+		PathStyle bool   `mapstructure:"path_style"`
+		// PartSize objects larger than it are uploaded in parts, defaults to 64 MiB
+		PartSize int64 `mapstructure:"part_size"`
+	}
 )
@@ -0,0 +1,172 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
+	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+)
+
+// Channel is the model entity for the Channel schema.
+type Channel struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// channels whose versions subscribers also see
+	Includes []string `json:"includes,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ChannelQuery when eager-loading is set.
+	Edges             ChannelEdges `json:"edges"`
+	resource_channels *string
+	selectValues      sql.SelectValues
+}
+
+// ChannelEdges holds the relations/edges for other nodes in the graph.
+type ChannelEdges struct {
+	// Resource holds the value of the resource edge.
+	Resource *Resource `json:"resource,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ResourceOrErr returns the Resource value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ChannelEdges) ResourceOrErr() (*Resource, error) {
+	if e.Resource != nil {
+		return e.Resource, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: resource.Label}
+	}
+	return nil, &NotLoadedError{edge: "resource"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Channel) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case channel.FieldIncludes:
+			values[i] = new([]byte)
+		case channel.FieldID:
+			values[i] = new(sql.NullInt64)
+		case channel.FieldName:
+			values[i] = new(sql.NullString)
+		case channel.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case channel.ForeignKeys[0]: // resource_channels
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Channel fields.
+func (c *Channel) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case channel.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			c.ID = int(value.Int64)
+		case channel.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				c.Name = value.String
+			}
+		case channel.FieldIncludes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field includes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &c.Includes); err != nil {
+					return fmt.Errorf("unmarshal field includes: %w", err)
+				}
+			}
+		case channel.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				c.CreatedAt = value.Time
+			}
+		case channel.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resource_channels", values[i])
+			} else if value.Valid {
+				c.resource_channels = new(string)
+				*c.resource_channels = value.String
+			}
+		default:
+			c.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Channel.
+// This includes values selected through modifiers, order, etc.
+func (c *Channel) Value(name string) (ent.Value, error) {
+	return c.selectValues.Get(name)
+}
+
+// QueryResource queries the "resource" edge of the Channel entity.
+func (c *Channel) QueryResource() *ResourceQuery {
+	return NewChannelClient(c.config).QueryResource(c)
+}
+
+// Update returns a builder for updating this Channel.
+// Note that you need to call Channel.Unwrap() before calling this method if this Channel
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (c *Channel) Update() *ChannelUpdateOne {
+	return NewChannelClient(c.config).UpdateOne(c)
+}
+
+// Unwrap unwraps the Channel entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (c *Channel) Unwrap() *Channel {
+	_tx, ok := c.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Channel is not a transactional entity")
+	}
+	c.config.driver = _tx.drv
+	return c
+}
+
+// String implements the fmt.Stringer.
+func (c *Channel) String() string {
+	var builder strings.Builder
+	builder.WriteString("Channel(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", c.ID))
+	builder.WriteString("name=")
+	builder.WriteString(c.Name)
+	builder.WriteString(", ")
+	builder.WriteString("includes=")
+	builder.WriteString(fmt.Sprintf("%v", c.Includes))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(c.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Channels is a parsable slice of Channel.
+type Channels []*Channel
@@ -0,0 +1,262 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
+	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+)
+
+// ChannelCreate is the builder for creating a Channel entity.
+type ChannelCreate struct {
+	config
+	mutation *ChannelMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (cc *ChannelCreate) SetName(s string) *ChannelCreate {
+	cc.mutation.SetName(s)
+	return cc
+}
+
+// SetIncludes sets the "includes" field.
+func (cc *ChannelCreate) SetIncludes(s []string) *ChannelCreate {
+	cc.mutation.SetIncludes(s)
+	return cc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cc *ChannelCreate) SetCreatedAt(t time.Time) *ChannelCreate {
+	cc.mutation.SetCreatedAt(t)
+	return cc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cc *ChannelCreate) SetNillableCreatedAt(t *time.Time) *ChannelCreate {
+	if t != nil {
+		cc.SetCreatedAt(*t)
+	}
+	return cc
+}
+
+// SetResourceID sets the "resource" edge to the Resource entity by ID.
+func (cc *ChannelCreate) SetResourceID(id string) *ChannelCreate {
+	cc.mutation.SetResourceID(id)
+	return cc
+}
+
+// SetResource sets the "resource" edge to the Resource entity.
+func (cc *ChannelCreate) SetResource(r *Resource) *ChannelCreate {
+	return cc.SetResourceID(r.ID)
+}
+
+// Mutation returns the ChannelMutation object of the builder.
+func (cc *ChannelCreate) Mutation() *ChannelMutation {
+	return cc.mutation
+}
+
+// Save creates the Channel in the database.
+func (cc *ChannelCreate) Save(ctx context.Context) (*Channel, error) {
+	cc.defaults()
+	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cc *ChannelCreate) SaveX(ctx context.Context) *Channel {
+	v, err := cc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cc *ChannelCreate) Exec(ctx context.Context) error {
+	_, err := cc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cc *ChannelCreate) ExecX(ctx context.Context) {
+	if err := cc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cc *ChannelCreate) defaults() {
+	if _, ok := cc.mutation.CreatedAt(); !ok {
+		v := channel.DefaultCreatedAt()
+		cc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cc *ChannelCreate) check() error {
+	if _, ok := cc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Channel.name"`)}
+	}
+	if v, ok := cc.mutation.Name(); ok {
+		if err := channel.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Channel.name": %w`, err)}
+		}
+	}
+	if _, ok := cc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Channel.created_at"`)}
+	}
+	if len(cc.mutation.ResourceIDs()) == 0 {
+		return &ValidationError{Name: "resource", err: errors.New(`ent: missing required edge "Channel.resource"`)}
+	}
+	return nil
+}
+
+func (cc *ChannelCreate) sqlSave(ctx context.Context) (*Channel, error) {
+	if err := cc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cc.mutation.id = &_node.ID
+	cc.mutation.done = true
+	return _node, nil
+}
+
+func (cc *ChannelCreate) createSpec() (*Channel, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Channel{config: cc.config}
+		_spec = sqlgraph.NewCreateSpec(channel.Table, sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt))
+	)
+	if value, ok := cc.mutation.Name(); ok {
+		_spec.SetField(channel.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := cc.mutation.Includes(); ok {
+		_spec.SetField(channel.FieldIncludes, field.TypeJSON, value)
+		_node.Includes = value
+	}
+	if value, ok := cc.mutation.CreatedAt(); ok {
+		_spec.SetField(channel.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := cc.mutation.ResourceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   channel.ResourceTable,
+			Columns: []string{channel.ResourceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(resource.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.resource_channels = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ChannelCreateBulk is the builder for creating many Channel entities in bulk.
+type ChannelCreateBulk struct {
+	config
+	err      error
+	builders []*ChannelCreate
+}
+
+// Save creates the Channel entities in the database.
+func (ccb *ChannelCreateBulk) Save(ctx context.Context) ([]*Channel, error) {
+	if ccb.err != nil {
+		return nil, ccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ccb.builders))
+	nodes := make([]*Channel, len(ccb.builders))
+	mutators := make([]Mutator, len(ccb.builders))
+	for i := range ccb.builders {
+		func(i int, root context.Context) {
+			builder := ccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ChannelMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccb *ChannelCreateBulk) SaveX(ctx context.Context) []*Channel {
+	v, err := ccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccb *ChannelCreateBulk) Exec(ctx context.Context) error {
+	_, err := ccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccb *ChannelCreateBulk) ExecX(ctx context.Context) {
+	if err := ccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,428 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
+	"github.com/MirrorChyan/resource-backend/internal/ent/predicate"
+	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+)
+
+// ChannelUpdate is the builder for updating Channel entities.
+type ChannelUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ChannelMutation
+}
+
+// Where appends a list predicates to the ChannelUpdate builder.
+func (cu *ChannelUpdate) Where(ps ...predicate.Channel) *ChannelUpdate {
+	cu.mutation.Where(ps...)
+	return cu
+}
+
+// SetName sets the "name" field.
+func (cu *ChannelUpdate) SetName(s string) *ChannelUpdate {
+	cu.mutation.SetName(s)
+	return cu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cu *ChannelUpdate) SetNillableName(s *string) *ChannelUpdate {
+	if s != nil {
+		cu.SetName(*s)
+	}
+	return cu
+}
+
+// SetIncludes sets the "includes" field.
+func (cu *ChannelUpdate) SetIncludes(s []string) *ChannelUpdate {
+	cu.mutation.SetIncludes(s)
+	return cu
+}
+
+// AppendIncludes appends s to the "includes" field.
+func (cu *ChannelUpdate) AppendIncludes(s []string) *ChannelUpdate {
+	cu.mutation.AppendIncludes(s)
+	return cu
+}
+
+// ClearIncludes clears the value of the "includes" field.
+func (cu *ChannelUpdate) ClearIncludes() *ChannelUpdate {
+	cu.mutation.ClearIncludes()
+	return cu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cu *ChannelUpdate) SetCreatedAt(t time.Time) *ChannelUpdate {
+	cu.mutation.SetCreatedAt(t)
+	return cu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cu *ChannelUpdate) SetNillableCreatedAt(t *time.Time) *ChannelUpdate {
+	if t != nil {
+		cu.SetCreatedAt(*t)
+	}
+	return cu
+}
+
+// SetResourceID sets the "resource" edge to the Resource entity by ID.
+func (cu *ChannelUpdate) SetResourceID(id string) *ChannelUpdate {
+	cu.mutation.SetResourceID(id)
+	return cu
+}
+
+// SetResource sets the "resource" edge to the Resource entity.
+func (cu *ChannelUpdate) SetResource(r *Resource) *ChannelUpdate {
+	return cu.SetResourceID(r.ID)
+}
+
+// Mutation returns the ChannelMutation object of the builder.
+func (cu *ChannelUpdate) Mutation() *ChannelMutation {
+	return cu.mutation
+}
+
+// ClearResource clears the "resource" edge to the Resource entity.
+func (cu *ChannelUpdate) ClearResource() *ChannelUpdate {
+	cu.mutation.ClearResource()
+	return cu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cu *ChannelUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, cu.sqlSave, cu.mutation, cu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cu *ChannelUpdate) SaveX(ctx context.Context) int {
+	affected, err := cu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cu *ChannelUpdate) Exec(ctx context.Context) error {
+	_, err := cu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cu *ChannelUpdate) ExecX(ctx context.Context) {
+	if err := cu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cu *ChannelUpdate) check() error {
+	if v, ok := cu.mutation.Name(); ok {
+		if err := channel.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Channel.name": %w`, err)}
+		}
+	}
+	if cu.mutation.ResourceCleared() && len(cu.mutation.ResourceIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Channel.resource"`)
+	}
+	return nil
+}
+
+func (cu *ChannelUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := cu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(channel.Table, channel.Columns, sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt))
+	if ps := cu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cu.mutation.Name(); ok {
+		_spec.SetField(channel.FieldName, field.TypeString, value)
+	}
+	if value, ok := cu.mutation.Includes(); ok {
+		_spec.SetField(channel.FieldIncludes, field.TypeJSON, value)
+	}
+	if value, ok := cu.mutation.AppendedIncludes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, channel.FieldIncludes, value)
+		})
+	}
+	if cu.mutation.IncludesCleared() {
+		_spec.ClearField(channel.FieldIncludes, field.TypeJSON)
+	}
+	if value, ok := cu.mutation.CreatedAt(); ok {
+		_spec.SetField(channel.FieldCreatedAt, field.TypeTime, value)
+	}
+	if cu.mutation.ResourceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   channel.ResourceTable,
+			Columns: []string{channel.ResourceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(resource.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cu.mutation.ResourceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   channel.ResourceTable,
+			Columns: []string{channel.ResourceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(resource.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{channel.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cu.mutation.done = true
+	return n, nil
+}
+
+// ChannelUpdateOne is the builder for updating a single Channel entity.
+type ChannelUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ChannelMutation
+}
+
+// SetName sets the "name" field.
+func (cuo *ChannelUpdateOne) SetName(s string) *ChannelUpdateOne {
+	cuo.mutation.SetName(s)
+	return cuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (cuo *ChannelUpdateOne) SetNillableName(s *string) *ChannelUpdateOne {
+	if s != nil {
+		cuo.SetName(*s)
+	}
+	return cuo
+}
+
+// SetIncludes sets the "includes" field.
+func (cuo *ChannelUpdateOne) SetIncludes(s []string) *ChannelUpdateOne {
+	cuo.mutation.SetIncludes(s)
+	return cuo
+}
+
+// AppendIncludes appends s to the "includes" field.
+func (cuo *ChannelUpdateOne) AppendIncludes(s []string) *ChannelUpdateOne {
+	cuo.mutation.AppendIncludes(s)
+	return cuo
+}
+
+// ClearIncludes clears the value of the "includes" field.
+func (cuo *ChannelUpdateOne) ClearIncludes() *ChannelUpdateOne {
+	cuo.mutation.ClearIncludes()
+	return cuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cuo *ChannelUpdateOne) SetCreatedAt(t time.Time) *ChannelUpdateOne {
+	cuo.mutation.SetCreatedAt(t)
+	return cuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cuo *ChannelUpdateOne) SetNillableCreatedAt(t *time.Time) *ChannelUpdateOne {
+	if t != nil {
+		cuo.SetCreatedAt(*t)
+	}
+	return cuo
+}
+
+// SetResourceID sets the "resource" edge to the Resource entity by ID.
+func (cuo *ChannelUpdateOne) SetResourceID(id string) *ChannelUpdateOne {
+	cuo.mutation.SetResourceID(id)
+	return cuo
+}
+
+// SetResource sets the "resource" edge to the Resource entity.
+func (cuo *ChannelUpdateOne) SetResource(r *Resource) *ChannelUpdateOne {
+	return cuo.SetResourceID(r.ID)
+}
+
+// Mutation returns the ChannelMutation object of the builder.
+func (cuo *ChannelUpdateOne) Mutation() *ChannelMutation {
+	return cuo.mutation
+}
+
+// ClearResource clears the "resource" edge to the Resource entity.
+func (cuo *ChannelUpdateOne) ClearResource() *ChannelUpdateOne {
+	cuo.mutation.ClearResource()
+	return cuo
+}
+
+// Where appends a list predicates to the ChannelUpdate builder.
+func (cuo *ChannelUpdateOne) Where(ps ...predicate.Channel) *ChannelUpdateOne {
+	cuo.mutation.Where(ps...)
+	return cuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cuo *ChannelUpdateOne) Select(field string, fields ...string) *ChannelUpdateOne {
+	cuo.fields = append([]string{field}, fields...)
+	return cuo
+}
+
+// Save executes the query and returns the updated Channel entity.
+func (cuo *ChannelUpdateOne) Save(ctx context.Context) (*Channel, error) {
+	return withHooks(ctx, cuo.sqlSave, cuo.mutation, cuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cuo *ChannelUpdateOne) SaveX(ctx context.Context) *Channel {
+	node, err := cuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cuo *ChannelUpdateOne) Exec(ctx context.Context) error {
+	_, err := cuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cuo *ChannelUpdateOne) ExecX(ctx context.Context) {
+	if err := cuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cuo *ChannelUpdateOne) check() error {
+	if v, ok := cuo.mutation.Name(); ok {
+		if err := channel.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Channel.name": %w`, err)}
+		}
+	}
+	if cuo.mutation.ResourceCleared() && len(cuo.mutation.ResourceIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Channel.resource"`)
+	}
+	return nil
+}
+
+func (cuo *ChannelUpdateOne) sqlSave(ctx context.Context) (_node *Channel, err error) {
+	if err := cuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(channel.Table, channel.Columns, sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt))
+	id, ok := cuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Channel.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, channel.FieldID)
+		for _, f := range fields {
+			if !channel.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != channel.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cuo.mutation.Name(); ok {
+		_spec.SetField(channel.FieldName, field.TypeString, value)
+	}
+	if value, ok := cuo.mutation.Includes(); ok {
+		_spec.SetField(channel.FieldIncludes, field.TypeJSON, value)
+	}
+	if value, ok := cuo.mutation.AppendedIncludes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, channel.FieldIncludes, value)
+		})
+	}
+	if cuo.mutation.IncludesCleared() {
+		_spec.ClearField(channel.FieldIncludes, field.TypeJSON)
+	}
+	if value, ok := cuo.mutation.CreatedAt(); ok {
+		_spec.SetField(channel.FieldCreatedAt, field.TypeTime, value)
+	}
+	if cuo.mutation.ResourceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   channel.ResourceTable,
+			Columns: []string{channel.ResourceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(resource.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cuo.mutation.ResourceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   channel.ResourceTable,
+			Columns: []string{channel.ResourceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(resource.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Channel{config: cuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{channel.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cuo.mutation.done = true
+	return _node, nil
+}
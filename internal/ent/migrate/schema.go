@@ -8,6 +8,35 @@ import (
 )
 
 var (
+	// ChannelsColumns holds the columns for the "channels" table.
+	ChannelsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "includes", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "resource_channels", Type: field.TypeString},
+	}
+	// ChannelsTable holds the schema information for the "channels" table.
+	ChannelsTable = &schema.Table{
+		Name:       "channels",
+		Columns:    ChannelsColumns,
+		PrimaryKey: []*schema.Column{ChannelsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "channels_resources_channels",
+				Columns:    []*schema.Column{ChannelsColumns[4]},
+				RefColumns: []*schema.Column{ResourcesColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "channel_name_resource_channels",
+				Unique:  true,
+				Columns: []*schema.Column{ChannelsColumns[1], ChannelsColumns[4]},
+			},
+		},
+	}
 	// ResourcesColumns holds the columns for the "resources" table.
 	ResourcesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeString, Unique: true},
@@ -15,6 +44,11 @@ var (
 		{Name: "description", Type: field.TypeString},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "update_type", Type: field.TypeString, Default: "incremental"},
+		{Name: "version_scheme", Type: field.TypeString, Default: ""},
+		{Name: "distribution_policy", Type: field.TypeJSON, Nullable: true},
+		{Name: "patch_policy", Type: field.TypeJSON, Nullable: true},
+		{Name: "retention_policy", Type: field.TypeJSON, Nullable: true},
+		{Name: "support_policy", Type: field.TypeJSON, Nullable: true},
 	}
 	// ResourcesTable holds the schema information for the "resources" table.
 	ResourcesTable = &schema.Table{
@@ -33,7 +67,15 @@ var (
 		{Name: "file_type", Type: field.TypeString, Nullable: true},
 		{Name: "file_size", Type: field.TypeInt64, Default: 0},
 		{Name: "file_hashes", Type: field.TypeJSON, Nullable: true},
+		{Name: "patch_format", Type: field.TypeInt, Default: 1},
 		{Name: "created_at", Type: field.TypeTime},
+		{Name: "broken_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_verified_at", Type: field.TypeTime, Nullable: true},
+		{Name: "quarantined_at", Type: field.TypeTime, Nullable: true},
+		{Name: "rollout_percent", Type: field.TypeInt, Default: 100},
+		{Name: "yanked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "yank_reason", Type: field.TypeString, Nullable: true},
+		{Name: "yank_downgrade", Type: field.TypeBool, Default: false},
 		{Name: "storage_old_version", Type: field.TypeInt, Nullable: true},
 		{Name: "version_storages", Type: field.TypeInt},
 	}
@@ -45,13 +87,13 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "storages_versions_old_version",
-				Columns:    []*schema.Column{StoragesColumns[10]},
+				Columns:    []*schema.Column{StoragesColumns[18]},
 				RefColumns: []*schema.Column{VersionsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 			{
 				Symbol:     "storages_versions_storages",
-				Columns:    []*schema.Column{StoragesColumns[11]},
+				Columns:    []*schema.Column{StoragesColumns[19]},
 				RefColumns: []*schema.Column{VersionsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -60,12 +102,16 @@ var (
 	// VersionsColumns holds the columns for the "versions" table.
 	VersionsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
-		{Name: "channel", Type: field.TypeEnum, Enums: []string{"stable", "beta", "alpha"}, Default: "stable"},
+		{Name: "channel", Type: field.TypeString, Default: "stable"},
 		{Name: "name", Type: field.TypeString},
 		{Name: "number", Type: field.TypeUint64},
 		{Name: "release_note", Type: field.TypeString, Default: "", SchemaType: map[string]string{"mysql": "longtext"}},
+		{Name: "release_notes", Type: field.TypeJSON, Nullable: true},
 		{Name: "custom_data", Type: field.TypeString, Default: "", SchemaType: map[string]string{"mysql": "longtext"}},
 		{Name: "created_at", Type: field.TypeTime},
+		{Name: "publish_at", Type: field.TypeTime, Nullable: true},
+		{Name: "critical", Type: field.TypeBool, Default: false},
+		{Name: "critical_reason", Type: field.TypeString, Nullable: true},
 		{Name: "resource_versions", Type: field.TypeString, Nullable: true},
 	}
 	// VersionsTable holds the schema information for the "versions" table.
@@ -76,7 +122,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "versions_resources_versions",
-				Columns:    []*schema.Column{VersionsColumns[7]},
+				Columns:    []*schema.Column{VersionsColumns[11]},
 				RefColumns: []*schema.Column{ResourcesColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -84,6 +130,7 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		ChannelsTable,
 		ResourcesTable,
 		StoragesTable,
 		VersionsTable,
@@ -91,6 +138,7 @@ var (
 )
 
 func init() {
+	ChannelsTable.ForeignKeys[0].RefTable = ResourcesTable
 	StoragesTable.ForeignKeys[0].RefTable = VersionsTable
 	StoragesTable.ForeignKeys[1].RefTable = VersionsTable
 	VersionsTable.ForeignKeys[0].RefTable = ResourcesTable
@@ -11,10 +11,12 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
 	"github.com/MirrorChyan/resource-backend/internal/ent/predicate"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 const (
@@ -26,28 +28,577 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
+	TypeChannel  = "Channel"
 	TypeResource = "Resource"
 	TypeStorage  = "Storage"
 	TypeVersion  = "Version"
 )
 
+// ChannelMutation represents an operation that mutates the Channel nodes in the graph.
+type ChannelMutation struct {
+	config
+	op              Op
+	typ             string
+	id              *int
+	name            *string
+	includes        *[]string
+	appendincludes  []string
+	created_at      *time.Time
+	clearedFields   map[string]struct{}
+	resource        *string
+	clearedresource bool
+	done            bool
+	oldValue        func(context.Context) (*Channel, error)
+	predicates      []predicate.Channel
+}
+
+var _ ent.Mutation = (*ChannelMutation)(nil)
+
+// channelOption allows management of the mutation configuration using functional options.
+type channelOption func(*ChannelMutation)
+
+// newChannelMutation creates new mutation for the Channel entity.
+func newChannelMutation(c config, op Op, opts ...channelOption) *ChannelMutation {
+	m := &ChannelMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeChannel,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withChannelID sets the ID field of the mutation.
+func withChannelID(id int) channelOption {
+	return func(m *ChannelMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Channel
+		)
+		m.oldValue = func(ctx context.Context) (*Channel, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Channel.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withChannel sets the old Channel of the mutation.
+func withChannel(node *Channel) channelOption {
+	return func(m *ChannelMutation) {
+		m.oldValue = func(context.Context) (*Channel, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ChannelMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ChannelMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ChannelMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ChannelMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Channel.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetName sets the "name" field.
+func (m *ChannelMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ChannelMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the Channel entity.
+// If the Channel object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ChannelMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *ChannelMutation) ResetName() {
+	m.name = nil
+}
+
+// SetIncludes sets the "includes" field.
+func (m *ChannelMutation) SetIncludes(s []string) {
+	m.includes = &s
+	m.appendincludes = nil
+}
+
+// Includes returns the value of the "includes" field in the mutation.
+func (m *ChannelMutation) Includes() (r []string, exists bool) {
+	v := m.includes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIncludes returns the old "includes" field's value of the Channel entity.
+// If the Channel object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ChannelMutation) OldIncludes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIncludes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIncludes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIncludes: %w", err)
+	}
+	return oldValue.Includes, nil
+}
+
+// AppendIncludes adds s to the "includes" field.
+func (m *ChannelMutation) AppendIncludes(s []string) {
+	m.appendincludes = append(m.appendincludes, s...)
+}
+
+// AppendedIncludes returns the list of values that were appended to the "includes" field in this mutation.
+func (m *ChannelMutation) AppendedIncludes() ([]string, bool) {
+	if len(m.appendincludes) == 0 {
+		return nil, false
+	}
+	return m.appendincludes, true
+}
+
+// ClearIncludes clears the value of the "includes" field.
+func (m *ChannelMutation) ClearIncludes() {
+	m.includes = nil
+	m.appendincludes = nil
+	m.clearedFields[channel.FieldIncludes] = struct{}{}
+}
+
+// IncludesCleared returns if the "includes" field was cleared in this mutation.
+func (m *ChannelMutation) IncludesCleared() bool {
+	_, ok := m.clearedFields[channel.FieldIncludes]
+	return ok
+}
+
+// ResetIncludes resets all changes to the "includes" field.
+func (m *ChannelMutation) ResetIncludes() {
+	m.includes = nil
+	m.appendincludes = nil
+	delete(m.clearedFields, channel.FieldIncludes)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ChannelMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ChannelMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Channel entity.
+// If the Channel object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ChannelMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ChannelMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetResourceID sets the "resource" edge to the Resource entity by id.
+func (m *ChannelMutation) SetResourceID(id string) {
+	m.resource = &id
+}
+
+// ClearResource clears the "resource" edge to the Resource entity.
+func (m *ChannelMutation) ClearResource() {
+	m.clearedresource = true
+}
+
+// ResourceCleared reports if the "resource" edge to the Resource entity was cleared.
+func (m *ChannelMutation) ResourceCleared() bool {
+	return m.clearedresource
+}
+
+// ResourceID returns the "resource" edge ID in the mutation.
+func (m *ChannelMutation) ResourceID() (id string, exists bool) {
+	if m.resource != nil {
+		return *m.resource, true
+	}
+	return
+}
+
+// ResourceIDs returns the "resource" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ResourceID instead. It exists only for internal usage by the builders.
+func (m *ChannelMutation) ResourceIDs() (ids []string) {
+	if id := m.resource; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetResource resets all changes to the "resource" edge.
+func (m *ChannelMutation) ResetResource() {
+	m.resource = nil
+	m.clearedresource = false
+}
+
+// Where appends a list predicates to the ChannelMutation builder.
+func (m *ChannelMutation) Where(ps ...predicate.Channel) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ChannelMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ChannelMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Channel, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ChannelMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ChannelMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Channel).
+func (m *ChannelMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ChannelMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.name != nil {
+		fields = append(fields, channel.FieldName)
+	}
+	if m.includes != nil {
+		fields = append(fields, channel.FieldIncludes)
+	}
+	if m.created_at != nil {
+		fields = append(fields, channel.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ChannelMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case channel.FieldName:
+		return m.Name()
+	case channel.FieldIncludes:
+		return m.Includes()
+	case channel.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ChannelMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case channel.FieldName:
+		return m.OldName(ctx)
+	case channel.FieldIncludes:
+		return m.OldIncludes(ctx)
+	case channel.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Channel field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ChannelMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case channel.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case channel.FieldIncludes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIncludes(v)
+		return nil
+	case channel.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Channel field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ChannelMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ChannelMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ChannelMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Channel numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ChannelMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(channel.FieldIncludes) {
+		fields = append(fields, channel.FieldIncludes)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ChannelMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ChannelMutation) ClearField(name string) error {
+	switch name {
+	case channel.FieldIncludes:
+		m.ClearIncludes()
+		return nil
+	}
+	return fmt.Errorf("unknown Channel nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ChannelMutation) ResetField(name string) error {
+	switch name {
+	case channel.FieldName:
+		m.ResetName()
+		return nil
+	case channel.FieldIncludes:
+		m.ResetIncludes()
+		return nil
+	case channel.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Channel field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ChannelMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.resource != nil {
+		edges = append(edges, channel.EdgeResource)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ChannelMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case channel.EdgeResource:
+		if id := m.resource; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ChannelMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ChannelMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ChannelMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedresource {
+		edges = append(edges, channel.EdgeResource)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ChannelMutation) EdgeCleared(name string) bool {
+	switch name {
+	case channel.EdgeResource:
+		return m.clearedresource
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ChannelMutation) ClearEdge(name string) error {
+	switch name {
+	case channel.EdgeResource:
+		m.ClearResource()
+		return nil
+	}
+	return fmt.Errorf("unknown Channel unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ChannelMutation) ResetEdge(name string) error {
+	switch name {
+	case channel.EdgeResource:
+		m.ResetResource()
+		return nil
+	}
+	return fmt.Errorf("unknown Channel edge %s", name)
+}
+
 // ResourceMutation represents an operation that mutates the Resource nodes in the graph.
 type ResourceMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *string
-	name            *string
-	description     *string
-	created_at      *time.Time
-	update_type     *string
-	clearedFields   map[string]struct{}
-	versions        map[int]struct{}
-	removedversions map[int]struct{}
-	clearedversions bool
-	done            bool
-	oldValue        func(context.Context) (*Resource, error)
-	predicates      []predicate.Resource
+	op                  Op
+	typ                 string
+	id                  *string
+	name                *string
+	description         *string
+	created_at          *time.Time
+	update_type         *string
+	version_scheme      *string
+	distribution_policy *types.DistributionPolicy
+	patch_policy        *types.PatchPolicy
+	retention_policy    *types.RetentionPolicy
+	support_policy      *types.SupportPolicy
+	clearedFields       map[string]struct{}
+	versions            map[int]struct{}
+	removedversions     map[int]struct{}
+	clearedversions     bool
+	channels            map[int]struct{}
+	removedchannels     map[int]struct{}
+	clearedchannels     bool
+	done                bool
+	oldValue            func(context.Context) (*Resource, error)
+	predicates          []predicate.Resource
 }
 
 var _ ent.Mutation = (*ResourceMutation)(nil)
@@ -298,6 +849,238 @@ func (m *ResourceMutation) ResetUpdateType() {
 	m.update_type = nil
 }
 
+// SetVersionScheme sets the "version_scheme" field.
+func (m *ResourceMutation) SetVersionScheme(s string) {
+	m.version_scheme = &s
+}
+
+// VersionScheme returns the value of the "version_scheme" field in the mutation.
+func (m *ResourceMutation) VersionScheme() (r string, exists bool) {
+	v := m.version_scheme
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVersionScheme returns the old "version_scheme" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldVersionScheme(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVersionScheme is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVersionScheme requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVersionScheme: %w", err)
+	}
+	return oldValue.VersionScheme, nil
+}
+
+// ResetVersionScheme resets all changes to the "version_scheme" field.
+func (m *ResourceMutation) ResetVersionScheme() {
+	m.version_scheme = nil
+}
+
+// SetDistributionPolicy sets the "distribution_policy" field.
+func (m *ResourceMutation) SetDistributionPolicy(tp types.DistributionPolicy) {
+	m.distribution_policy = &tp
+}
+
+// DistributionPolicy returns the value of the "distribution_policy" field in the mutation.
+func (m *ResourceMutation) DistributionPolicy() (r types.DistributionPolicy, exists bool) {
+	v := m.distribution_policy
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDistributionPolicy returns the old "distribution_policy" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldDistributionPolicy(ctx context.Context) (v types.DistributionPolicy, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDistributionPolicy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDistributionPolicy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDistributionPolicy: %w", err)
+	}
+	return oldValue.DistributionPolicy, nil
+}
+
+// ClearDistributionPolicy clears the value of the "distribution_policy" field.
+func (m *ResourceMutation) ClearDistributionPolicy() {
+	m.distribution_policy = nil
+	m.clearedFields[resource.FieldDistributionPolicy] = struct{}{}
+}
+
+// DistributionPolicyCleared returns if the "distribution_policy" field was cleared in this mutation.
+func (m *ResourceMutation) DistributionPolicyCleared() bool {
+	_, ok := m.clearedFields[resource.FieldDistributionPolicy]
+	return ok
+}
+
+// ResetDistributionPolicy resets all changes to the "distribution_policy" field.
+func (m *ResourceMutation) ResetDistributionPolicy() {
+	m.distribution_policy = nil
+	delete(m.clearedFields, resource.FieldDistributionPolicy)
+}
+
+// SetPatchPolicy sets the "patch_policy" field.
+func (m *ResourceMutation) SetPatchPolicy(tp types.PatchPolicy) {
+	m.patch_policy = &tp
+}
+
+// PatchPolicy returns the value of the "patch_policy" field in the mutation.
+func (m *ResourceMutation) PatchPolicy() (r types.PatchPolicy, exists bool) {
+	v := m.patch_policy
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPatchPolicy returns the old "patch_policy" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldPatchPolicy(ctx context.Context) (v types.PatchPolicy, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPatchPolicy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPatchPolicy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPatchPolicy: %w", err)
+	}
+	return oldValue.PatchPolicy, nil
+}
+
+// ClearPatchPolicy clears the value of the "patch_policy" field.
+func (m *ResourceMutation) ClearPatchPolicy() {
+	m.patch_policy = nil
+	m.clearedFields[resource.FieldPatchPolicy] = struct{}{}
+}
+
+// PatchPolicyCleared returns if the "patch_policy" field was cleared in this mutation.
+func (m *ResourceMutation) PatchPolicyCleared() bool {
+	_, ok := m.clearedFields[resource.FieldPatchPolicy]
+	return ok
+}
+
+// ResetPatchPolicy resets all changes to the "patch_policy" field.
+func (m *ResourceMutation) ResetPatchPolicy() {
+	m.patch_policy = nil
+	delete(m.clearedFields, resource.FieldPatchPolicy)
+}
+
+// SetRetentionPolicy sets the "retention_policy" field.
+func (m *ResourceMutation) SetRetentionPolicy(tp types.RetentionPolicy) {
+	m.retention_policy = &tp
+}
+
+// RetentionPolicy returns the value of the "retention_policy" field in the mutation.
+func (m *ResourceMutation) RetentionPolicy() (r types.RetentionPolicy, exists bool) {
+	v := m.retention_policy
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRetentionPolicy returns the old "retention_policy" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldRetentionPolicy(ctx context.Context) (v types.RetentionPolicy, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRetentionPolicy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRetentionPolicy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRetentionPolicy: %w", err)
+	}
+	return oldValue.RetentionPolicy, nil
+}
+
+// ClearRetentionPolicy clears the value of the "retention_policy" field.
+func (m *ResourceMutation) ClearRetentionPolicy() {
+	m.retention_policy = nil
+	m.clearedFields[resource.FieldRetentionPolicy] = struct{}{}
+}
+
+// RetentionPolicyCleared returns if the "retention_policy" field was cleared in this mutation.
+func (m *ResourceMutation) RetentionPolicyCleared() bool {
+	_, ok := m.clearedFields[resource.FieldRetentionPolicy]
+	return ok
+}
+
+// ResetRetentionPolicy resets all changes to the "retention_policy" field.
+func (m *ResourceMutation) ResetRetentionPolicy() {
+	m.retention_policy = nil
+	delete(m.clearedFields, resource.FieldRetentionPolicy)
+}
+
+// SetSupportPolicy sets the "support_policy" field.
+func (m *ResourceMutation) SetSupportPolicy(tp types.SupportPolicy) {
+	m.support_policy = &tp
+}
+
+// SupportPolicy returns the value of the "support_policy" field in the mutation.
+func (m *ResourceMutation) SupportPolicy() (r types.SupportPolicy, exists bool) {
+	v := m.support_policy
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSupportPolicy returns the old "support_policy" field's value of the Resource entity.
+// If the Resource object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceMutation) OldSupportPolicy(ctx context.Context) (v types.SupportPolicy, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSupportPolicy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSupportPolicy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSupportPolicy: %w", err)
+	}
+	return oldValue.SupportPolicy, nil
+}
+
+// ClearSupportPolicy clears the value of the "support_policy" field.
+func (m *ResourceMutation) ClearSupportPolicy() {
+	m.support_policy = nil
+	m.clearedFields[resource.FieldSupportPolicy] = struct{}{}
+}
+
+// SupportPolicyCleared returns if the "support_policy" field was cleared in this mutation.
+func (m *ResourceMutation) SupportPolicyCleared() bool {
+	_, ok := m.clearedFields[resource.FieldSupportPolicy]
+	return ok
+}
+
+// ResetSupportPolicy resets all changes to the "support_policy" field.
+func (m *ResourceMutation) ResetSupportPolicy() {
+	m.support_policy = nil
+	delete(m.clearedFields, resource.FieldSupportPolicy)
+}
+
 // AddVersionIDs adds the "versions" edge to the Version entity by ids.
 func (m *ResourceMutation) AddVersionIDs(ids ...int) {
 	if m.versions == nil {
@@ -352,6 +1135,60 @@ func (m *ResourceMutation) ResetVersions() {
 	m.removedversions = nil
 }
 
+// AddChannelIDs adds the "channels" edge to the Channel entity by ids.
+func (m *ResourceMutation) AddChannelIDs(ids ...int) {
+	if m.channels == nil {
+		m.channels = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.channels[ids[i]] = struct{}{}
+	}
+}
+
+// ClearChannels clears the "channels" edge to the Channel entity.
+func (m *ResourceMutation) ClearChannels() {
+	m.clearedchannels = true
+}
+
+// ChannelsCleared reports if the "channels" edge to the Channel entity was cleared.
+func (m *ResourceMutation) ChannelsCleared() bool {
+	return m.clearedchannels
+}
+
+// RemoveChannelIDs removes the "channels" edge to the Channel entity by IDs.
+func (m *ResourceMutation) RemoveChannelIDs(ids ...int) {
+	if m.removedchannels == nil {
+		m.removedchannels = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.channels, ids[i])
+		m.removedchannels[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedChannels returns the removed IDs of the "channels" edge to the Channel entity.
+func (m *ResourceMutation) RemovedChannelsIDs() (ids []int) {
+	for id := range m.removedchannels {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ChannelsIDs returns the "channels" edge IDs in the mutation.
+func (m *ResourceMutation) ChannelsIDs() (ids []int) {
+	for id := range m.channels {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetChannels resets all changes to the "channels" edge.
+func (m *ResourceMutation) ResetChannels() {
+	m.channels = nil
+	m.clearedchannels = false
+	m.removedchannels = nil
+}
+
 // Where appends a list predicates to the ResourceMutation builder.
 func (m *ResourceMutation) Where(ps ...predicate.Resource) {
 	m.predicates = append(m.predicates, ps...)
@@ -386,7 +1223,7 @@ func (m *ResourceMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *ResourceMutation) Fields() []string {
-	fields := make([]string, 0, 4)
+	fields := make([]string, 0, 9)
 	if m.name != nil {
 		fields = append(fields, resource.FieldName)
 	}
@@ -399,6 +1236,21 @@ func (m *ResourceMutation) Fields() []string {
 	if m.update_type != nil {
 		fields = append(fields, resource.FieldUpdateType)
 	}
+	if m.version_scheme != nil {
+		fields = append(fields, resource.FieldVersionScheme)
+	}
+	if m.distribution_policy != nil {
+		fields = append(fields, resource.FieldDistributionPolicy)
+	}
+	if m.patch_policy != nil {
+		fields = append(fields, resource.FieldPatchPolicy)
+	}
+	if m.retention_policy != nil {
+		fields = append(fields, resource.FieldRetentionPolicy)
+	}
+	if m.support_policy != nil {
+		fields = append(fields, resource.FieldSupportPolicy)
+	}
 	return fields
 }
 
@@ -415,6 +1267,16 @@ func (m *ResourceMutation) Field(name string) (ent.Value, bool) {
 		return m.CreatedAt()
 	case resource.FieldUpdateType:
 		return m.UpdateType()
+	case resource.FieldVersionScheme:
+		return m.VersionScheme()
+	case resource.FieldDistributionPolicy:
+		return m.DistributionPolicy()
+	case resource.FieldPatchPolicy:
+		return m.PatchPolicy()
+	case resource.FieldRetentionPolicy:
+		return m.RetentionPolicy()
+	case resource.FieldSupportPolicy:
+		return m.SupportPolicy()
 	}
 	return nil, false
 }
@@ -432,6 +1294,16 @@ func (m *ResourceMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldCreatedAt(ctx)
 	case resource.FieldUpdateType:
 		return m.OldUpdateType(ctx)
+	case resource.FieldVersionScheme:
+		return m.OldVersionScheme(ctx)
+	case resource.FieldDistributionPolicy:
+		return m.OldDistributionPolicy(ctx)
+	case resource.FieldPatchPolicy:
+		return m.OldPatchPolicy(ctx)
+	case resource.FieldRetentionPolicy:
+		return m.OldRetentionPolicy(ctx)
+	case resource.FieldSupportPolicy:
+		return m.OldSupportPolicy(ctx)
 	}
 	return nil, fmt.Errorf("unknown Resource field %s", name)
 }
@@ -469,6 +1341,41 @@ func (m *ResourceMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetUpdateType(v)
 		return nil
+	case resource.FieldVersionScheme:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersionScheme(v)
+		return nil
+	case resource.FieldDistributionPolicy:
+		v, ok := value.(types.DistributionPolicy)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDistributionPolicy(v)
+		return nil
+	case resource.FieldPatchPolicy:
+		v, ok := value.(types.PatchPolicy)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPatchPolicy(v)
+		return nil
+	case resource.FieldRetentionPolicy:
+		v, ok := value.(types.RetentionPolicy)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRetentionPolicy(v)
+		return nil
+	case resource.FieldSupportPolicy:
+		v, ok := value.(types.SupportPolicy)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSupportPolicy(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Resource field %s", name)
 }
@@ -498,7 +1405,20 @@ func (m *ResourceMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *ResourceMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(resource.FieldDistributionPolicy) {
+		fields = append(fields, resource.FieldDistributionPolicy)
+	}
+	if m.FieldCleared(resource.FieldPatchPolicy) {
+		fields = append(fields, resource.FieldPatchPolicy)
+	}
+	if m.FieldCleared(resource.FieldRetentionPolicy) {
+		fields = append(fields, resource.FieldRetentionPolicy)
+	}
+	if m.FieldCleared(resource.FieldSupportPolicy) {
+		fields = append(fields, resource.FieldSupportPolicy)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -511,6 +1431,20 @@ func (m *ResourceMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *ResourceMutation) ClearField(name string) error {
+	switch name {
+	case resource.FieldDistributionPolicy:
+		m.ClearDistributionPolicy()
+		return nil
+	case resource.FieldPatchPolicy:
+		m.ClearPatchPolicy()
+		return nil
+	case resource.FieldRetentionPolicy:
+		m.ClearRetentionPolicy()
+		return nil
+	case resource.FieldSupportPolicy:
+		m.ClearSupportPolicy()
+		return nil
+	}
 	return fmt.Errorf("unknown Resource nullable field %s", name)
 }
 
@@ -530,16 +1464,34 @@ func (m *ResourceMutation) ResetField(name string) error {
 	case resource.FieldUpdateType:
 		m.ResetUpdateType()
 		return nil
+	case resource.FieldVersionScheme:
+		m.ResetVersionScheme()
+		return nil
+	case resource.FieldDistributionPolicy:
+		m.ResetDistributionPolicy()
+		return nil
+	case resource.FieldPatchPolicy:
+		m.ResetPatchPolicy()
+		return nil
+	case resource.FieldRetentionPolicy:
+		m.ResetRetentionPolicy()
+		return nil
+	case resource.FieldSupportPolicy:
+		m.ResetSupportPolicy()
+		return nil
 	}
 	return fmt.Errorf("unknown Resource field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
 func (m *ResourceMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 2)
 	if m.versions != nil {
 		edges = append(edges, resource.EdgeVersions)
 	}
+	if m.channels != nil {
+		edges = append(edges, resource.EdgeChannels)
+	}
 	return edges
 }
 
@@ -553,16 +1505,25 @@ func (m *ResourceMutation) AddedIDs(name string) []ent.Value {
 			ids = append(ids, id)
 		}
 		return ids
+	case resource.EdgeChannels:
+		ids := make([]ent.Value, 0, len(m.channels))
+		for id := range m.channels {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
 func (m *ResourceMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 2)
 	if m.removedversions != nil {
 		edges = append(edges, resource.EdgeVersions)
 	}
+	if m.removedchannels != nil {
+		edges = append(edges, resource.EdgeChannels)
+	}
 	return edges
 }
 
@@ -576,16 +1537,25 @@ func (m *ResourceMutation) RemovedIDs(name string) []ent.Value {
 			ids = append(ids, id)
 		}
 		return ids
+	case resource.EdgeChannels:
+		ids := make([]ent.Value, 0, len(m.removedchannels))
+		for id := range m.removedchannels {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
 func (m *ResourceMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 2)
 	if m.clearedversions {
 		edges = append(edges, resource.EdgeVersions)
 	}
+	if m.clearedchannels {
+		edges = append(edges, resource.EdgeChannels)
+	}
 	return edges
 }
 
@@ -595,6 +1565,8 @@ func (m *ResourceMutation) EdgeCleared(name string) bool {
 	switch name {
 	case resource.EdgeVersions:
 		return m.clearedversions
+	case resource.EdgeChannels:
+		return m.clearedchannels
 	}
 	return false
 }
@@ -614,6 +1586,9 @@ func (m *ResourceMutation) ResetEdge(name string) error {
 	case resource.EdgeVersions:
 		m.ResetVersions()
 		return nil
+	case resource.EdgeChannels:
+		m.ResetChannels()
+		return nil
 	}
 	return fmt.Errorf("unknown Resource edge %s", name)
 }
@@ -633,7 +1608,17 @@ type StorageMutation struct {
 	file_size           *int64
 	addfile_size        *int64
 	file_hashes         *map[string]string
+	patch_format        *int
+	addpatch_format     *int
 	created_at          *time.Time
+	broken_at           *time.Time
+	last_verified_at    *time.Time
+	quarantined_at      *time.Time
+	rollout_percent     *int
+	addrollout_percent  *int
+	yanked_at           *time.Time
+	yank_reason         *string
+	yank_downgrade      *bool
 	clearedFields       map[string]struct{}
 	version             *int
 	clearedversion      bool
@@ -1053,89 +2038,482 @@ func (m *StorageMutation) ResetFileSize() {
 	m.addfile_size = nil
 }
 
-// SetFileHashes sets the "file_hashes" field.
-func (m *StorageMutation) SetFileHashes(value map[string]string) {
-	m.file_hashes = &value
+// SetFileHashes sets the "file_hashes" field.
+func (m *StorageMutation) SetFileHashes(value map[string]string) {
+	m.file_hashes = &value
+}
+
+// FileHashes returns the value of the "file_hashes" field in the mutation.
+func (m *StorageMutation) FileHashes() (r map[string]string, exists bool) {
+	v := m.file_hashes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFileHashes returns the old "file_hashes" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldFileHashes(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFileHashes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFileHashes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFileHashes: %w", err)
+	}
+	return oldValue.FileHashes, nil
+}
+
+// ClearFileHashes clears the value of the "file_hashes" field.
+func (m *StorageMutation) ClearFileHashes() {
+	m.file_hashes = nil
+	m.clearedFields[storage.FieldFileHashes] = struct{}{}
+}
+
+// FileHashesCleared returns if the "file_hashes" field was cleared in this mutation.
+func (m *StorageMutation) FileHashesCleared() bool {
+	_, ok := m.clearedFields[storage.FieldFileHashes]
+	return ok
+}
+
+// ResetFileHashes resets all changes to the "file_hashes" field.
+func (m *StorageMutation) ResetFileHashes() {
+	m.file_hashes = nil
+	delete(m.clearedFields, storage.FieldFileHashes)
+}
+
+// SetPatchFormat sets the "patch_format" field.
+func (m *StorageMutation) SetPatchFormat(i int) {
+	m.patch_format = &i
+	m.addpatch_format = nil
+}
+
+// PatchFormat returns the value of the "patch_format" field in the mutation.
+func (m *StorageMutation) PatchFormat() (r int, exists bool) {
+	v := m.patch_format
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPatchFormat returns the old "patch_format" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldPatchFormat(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPatchFormat is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPatchFormat requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPatchFormat: %w", err)
+	}
+	return oldValue.PatchFormat, nil
+}
+
+// AddPatchFormat adds i to the "patch_format" field.
+func (m *StorageMutation) AddPatchFormat(i int) {
+	if m.addpatch_format != nil {
+		*m.addpatch_format += i
+	} else {
+		m.addpatch_format = &i
+	}
+}
+
+// AddedPatchFormat returns the value that was added to the "patch_format" field in this mutation.
+func (m *StorageMutation) AddedPatchFormat() (r int, exists bool) {
+	v := m.addpatch_format
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPatchFormat resets all changes to the "patch_format" field.
+func (m *StorageMutation) ResetPatchFormat() {
+	m.patch_format = nil
+	m.addpatch_format = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *StorageMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *StorageMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *StorageMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetBrokenAt sets the "broken_at" field.
+func (m *StorageMutation) SetBrokenAt(t time.Time) {
+	m.broken_at = &t
+}
+
+// BrokenAt returns the value of the "broken_at" field in the mutation.
+func (m *StorageMutation) BrokenAt() (r time.Time, exists bool) {
+	v := m.broken_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBrokenAt returns the old "broken_at" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldBrokenAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBrokenAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBrokenAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBrokenAt: %w", err)
+	}
+	return oldValue.BrokenAt, nil
+}
+
+// ClearBrokenAt clears the value of the "broken_at" field.
+func (m *StorageMutation) ClearBrokenAt() {
+	m.broken_at = nil
+	m.clearedFields[storage.FieldBrokenAt] = struct{}{}
+}
+
+// BrokenAtCleared returns if the "broken_at" field was cleared in this mutation.
+func (m *StorageMutation) BrokenAtCleared() bool {
+	_, ok := m.clearedFields[storage.FieldBrokenAt]
+	return ok
+}
+
+// ResetBrokenAt resets all changes to the "broken_at" field.
+func (m *StorageMutation) ResetBrokenAt() {
+	m.broken_at = nil
+	delete(m.clearedFields, storage.FieldBrokenAt)
+}
+
+// SetLastVerifiedAt sets the "last_verified_at" field.
+func (m *StorageMutation) SetLastVerifiedAt(t time.Time) {
+	m.last_verified_at = &t
+}
+
+// LastVerifiedAt returns the value of the "last_verified_at" field in the mutation.
+func (m *StorageMutation) LastVerifiedAt() (r time.Time, exists bool) {
+	v := m.last_verified_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastVerifiedAt returns the old "last_verified_at" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldLastVerifiedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastVerifiedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastVerifiedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastVerifiedAt: %w", err)
+	}
+	return oldValue.LastVerifiedAt, nil
+}
+
+// ClearLastVerifiedAt clears the value of the "last_verified_at" field.
+func (m *StorageMutation) ClearLastVerifiedAt() {
+	m.last_verified_at = nil
+	m.clearedFields[storage.FieldLastVerifiedAt] = struct{}{}
+}
+
+// LastVerifiedAtCleared returns if the "last_verified_at" field was cleared in this mutation.
+func (m *StorageMutation) LastVerifiedAtCleared() bool {
+	_, ok := m.clearedFields[storage.FieldLastVerifiedAt]
+	return ok
+}
+
+// ResetLastVerifiedAt resets all changes to the "last_verified_at" field.
+func (m *StorageMutation) ResetLastVerifiedAt() {
+	m.last_verified_at = nil
+	delete(m.clearedFields, storage.FieldLastVerifiedAt)
+}
+
+// SetQuarantinedAt sets the "quarantined_at" field.
+func (m *StorageMutation) SetQuarantinedAt(t time.Time) {
+	m.quarantined_at = &t
+}
+
+// QuarantinedAt returns the value of the "quarantined_at" field in the mutation.
+func (m *StorageMutation) QuarantinedAt() (r time.Time, exists bool) {
+	v := m.quarantined_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQuarantinedAt returns the old "quarantined_at" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldQuarantinedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuarantinedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuarantinedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuarantinedAt: %w", err)
+	}
+	return oldValue.QuarantinedAt, nil
+}
+
+// ClearQuarantinedAt clears the value of the "quarantined_at" field.
+func (m *StorageMutation) ClearQuarantinedAt() {
+	m.quarantined_at = nil
+	m.clearedFields[storage.FieldQuarantinedAt] = struct{}{}
+}
+
+// QuarantinedAtCleared returns if the "quarantined_at" field was cleared in this mutation.
+func (m *StorageMutation) QuarantinedAtCleared() bool {
+	_, ok := m.clearedFields[storage.FieldQuarantinedAt]
+	return ok
+}
+
+// ResetQuarantinedAt resets all changes to the "quarantined_at" field.
+func (m *StorageMutation) ResetQuarantinedAt() {
+	m.quarantined_at = nil
+	delete(m.clearedFields, storage.FieldQuarantinedAt)
+}
+
+// SetRolloutPercent sets the "rollout_percent" field.
+func (m *StorageMutation) SetRolloutPercent(i int) {
+	m.rollout_percent = &i
+	m.addrollout_percent = nil
+}
+
+// RolloutPercent returns the value of the "rollout_percent" field in the mutation.
+func (m *StorageMutation) RolloutPercent() (r int, exists bool) {
+	v := m.rollout_percent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRolloutPercent returns the old "rollout_percent" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldRolloutPercent(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRolloutPercent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRolloutPercent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRolloutPercent: %w", err)
+	}
+	return oldValue.RolloutPercent, nil
+}
+
+// AddRolloutPercent adds i to the "rollout_percent" field.
+func (m *StorageMutation) AddRolloutPercent(i int) {
+	if m.addrollout_percent != nil {
+		*m.addrollout_percent += i
+	} else {
+		m.addrollout_percent = &i
+	}
+}
+
+// AddedRolloutPercent returns the value that was added to the "rollout_percent" field in this mutation.
+func (m *StorageMutation) AddedRolloutPercent() (r int, exists bool) {
+	v := m.addrollout_percent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRolloutPercent resets all changes to the "rollout_percent" field.
+func (m *StorageMutation) ResetRolloutPercent() {
+	m.rollout_percent = nil
+	m.addrollout_percent = nil
+}
+
+// SetYankedAt sets the "yanked_at" field.
+func (m *StorageMutation) SetYankedAt(t time.Time) {
+	m.yanked_at = &t
+}
+
+// YankedAt returns the value of the "yanked_at" field in the mutation.
+func (m *StorageMutation) YankedAt() (r time.Time, exists bool) {
+	v := m.yanked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldYankedAt returns the old "yanked_at" field's value of the Storage entity.
+// If the Storage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *StorageMutation) OldYankedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldYankedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldYankedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldYankedAt: %w", err)
+	}
+	return oldValue.YankedAt, nil
+}
+
+// ClearYankedAt clears the value of the "yanked_at" field.
+func (m *StorageMutation) ClearYankedAt() {
+	m.yanked_at = nil
+	m.clearedFields[storage.FieldYankedAt] = struct{}{}
+}
+
+// YankedAtCleared returns if the "yanked_at" field was cleared in this mutation.
+func (m *StorageMutation) YankedAtCleared() bool {
+	_, ok := m.clearedFields[storage.FieldYankedAt]
+	return ok
+}
+
+// ResetYankedAt resets all changes to the "yanked_at" field.
+func (m *StorageMutation) ResetYankedAt() {
+	m.yanked_at = nil
+	delete(m.clearedFields, storage.FieldYankedAt)
 }
 
-// FileHashes returns the value of the "file_hashes" field in the mutation.
-func (m *StorageMutation) FileHashes() (r map[string]string, exists bool) {
-	v := m.file_hashes
+// SetYankReason sets the "yank_reason" field.
+func (m *StorageMutation) SetYankReason(s string) {
+	m.yank_reason = &s
+}
+
+// YankReason returns the value of the "yank_reason" field in the mutation.
+func (m *StorageMutation) YankReason() (r string, exists bool) {
+	v := m.yank_reason
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFileHashes returns the old "file_hashes" field's value of the Storage entity.
+// OldYankReason returns the old "yank_reason" field's value of the Storage entity.
 // If the Storage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *StorageMutation) OldFileHashes(ctx context.Context) (v map[string]string, err error) {
+func (m *StorageMutation) OldYankReason(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFileHashes is only allowed on UpdateOne operations")
+		return v, errors.New("OldYankReason is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFileHashes requires an ID field in the mutation")
+		return v, errors.New("OldYankReason requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFileHashes: %w", err)
+		return v, fmt.Errorf("querying old value for OldYankReason: %w", err)
 	}
-	return oldValue.FileHashes, nil
+	return oldValue.YankReason, nil
 }
 
-// ClearFileHashes clears the value of the "file_hashes" field.
-func (m *StorageMutation) ClearFileHashes() {
-	m.file_hashes = nil
-	m.clearedFields[storage.FieldFileHashes] = struct{}{}
+// ClearYankReason clears the value of the "yank_reason" field.
+func (m *StorageMutation) ClearYankReason() {
+	m.yank_reason = nil
+	m.clearedFields[storage.FieldYankReason] = struct{}{}
 }
 
-// FileHashesCleared returns if the "file_hashes" field was cleared in this mutation.
-func (m *StorageMutation) FileHashesCleared() bool {
-	_, ok := m.clearedFields[storage.FieldFileHashes]
+// YankReasonCleared returns if the "yank_reason" field was cleared in this mutation.
+func (m *StorageMutation) YankReasonCleared() bool {
+	_, ok := m.clearedFields[storage.FieldYankReason]
 	return ok
 }
 
-// ResetFileHashes resets all changes to the "file_hashes" field.
-func (m *StorageMutation) ResetFileHashes() {
-	m.file_hashes = nil
-	delete(m.clearedFields, storage.FieldFileHashes)
+// ResetYankReason resets all changes to the "yank_reason" field.
+func (m *StorageMutation) ResetYankReason() {
+	m.yank_reason = nil
+	delete(m.clearedFields, storage.FieldYankReason)
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *StorageMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetYankDowngrade sets the "yank_downgrade" field.
+func (m *StorageMutation) SetYankDowngrade(b bool) {
+	m.yank_downgrade = &b
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *StorageMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// YankDowngrade returns the value of the "yank_downgrade" field in the mutation.
+func (m *StorageMutation) YankDowngrade() (r bool, exists bool) {
+	v := m.yank_downgrade
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Storage entity.
+// OldYankDowngrade returns the old "yank_downgrade" field's value of the Storage entity.
 // If the Storage object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *StorageMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *StorageMutation) OldYankDowngrade(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldYankDowngrade is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldYankDowngrade requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldYankDowngrade: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.YankDowngrade, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *StorageMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetYankDowngrade resets all changes to the "yank_downgrade" field.
+func (m *StorageMutation) ResetYankDowngrade() {
+	m.yank_downgrade = nil
 }
 
 // SetVersionStorages sets the "version_storages" field.
@@ -1287,7 +2665,7 @@ func (m *StorageMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *StorageMutation) Fields() []string {
-	fields := make([]string, 0, 10)
+	fields := make([]string, 0, 18)
 	if m.update_type != nil {
 		fields = append(fields, storage.FieldUpdateType)
 	}
@@ -1312,9 +2690,33 @@ func (m *StorageMutation) Fields() []string {
 	if m.file_hashes != nil {
 		fields = append(fields, storage.FieldFileHashes)
 	}
+	if m.patch_format != nil {
+		fields = append(fields, storage.FieldPatchFormat)
+	}
 	if m.created_at != nil {
 		fields = append(fields, storage.FieldCreatedAt)
 	}
+	if m.broken_at != nil {
+		fields = append(fields, storage.FieldBrokenAt)
+	}
+	if m.last_verified_at != nil {
+		fields = append(fields, storage.FieldLastVerifiedAt)
+	}
+	if m.quarantined_at != nil {
+		fields = append(fields, storage.FieldQuarantinedAt)
+	}
+	if m.rollout_percent != nil {
+		fields = append(fields, storage.FieldRolloutPercent)
+	}
+	if m.yanked_at != nil {
+		fields = append(fields, storage.FieldYankedAt)
+	}
+	if m.yank_reason != nil {
+		fields = append(fields, storage.FieldYankReason)
+	}
+	if m.yank_downgrade != nil {
+		fields = append(fields, storage.FieldYankDowngrade)
+	}
 	if m.version != nil {
 		fields = append(fields, storage.FieldVersionStorages)
 	}
@@ -1342,8 +2744,24 @@ func (m *StorageMutation) Field(name string) (ent.Value, bool) {
 		return m.FileSize()
 	case storage.FieldFileHashes:
 		return m.FileHashes()
+	case storage.FieldPatchFormat:
+		return m.PatchFormat()
 	case storage.FieldCreatedAt:
 		return m.CreatedAt()
+	case storage.FieldBrokenAt:
+		return m.BrokenAt()
+	case storage.FieldLastVerifiedAt:
+		return m.LastVerifiedAt()
+	case storage.FieldQuarantinedAt:
+		return m.QuarantinedAt()
+	case storage.FieldRolloutPercent:
+		return m.RolloutPercent()
+	case storage.FieldYankedAt:
+		return m.YankedAt()
+	case storage.FieldYankReason:
+		return m.YankReason()
+	case storage.FieldYankDowngrade:
+		return m.YankDowngrade()
 	case storage.FieldVersionStorages:
 		return m.VersionStorages()
 	}
@@ -1371,8 +2789,24 @@ func (m *StorageMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldFileSize(ctx)
 	case storage.FieldFileHashes:
 		return m.OldFileHashes(ctx)
+	case storage.FieldPatchFormat:
+		return m.OldPatchFormat(ctx)
 	case storage.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case storage.FieldBrokenAt:
+		return m.OldBrokenAt(ctx)
+	case storage.FieldLastVerifiedAt:
+		return m.OldLastVerifiedAt(ctx)
+	case storage.FieldQuarantinedAt:
+		return m.OldQuarantinedAt(ctx)
+	case storage.FieldRolloutPercent:
+		return m.OldRolloutPercent(ctx)
+	case storage.FieldYankedAt:
+		return m.OldYankedAt(ctx)
+	case storage.FieldYankReason:
+		return m.OldYankReason(ctx)
+	case storage.FieldYankDowngrade:
+		return m.OldYankDowngrade(ctx)
 	case storage.FieldVersionStorages:
 		return m.OldVersionStorages(ctx)
 	}
@@ -1440,6 +2874,13 @@ func (m *StorageMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetFileHashes(v)
 		return nil
+	case storage.FieldPatchFormat:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPatchFormat(v)
+		return nil
 	case storage.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -1447,6 +2888,55 @@ func (m *StorageMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case storage.FieldBrokenAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBrokenAt(v)
+		return nil
+	case storage.FieldLastVerifiedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastVerifiedAt(v)
+		return nil
+	case storage.FieldQuarantinedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuarantinedAt(v)
+		return nil
+	case storage.FieldRolloutPercent:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRolloutPercent(v)
+		return nil
+	case storage.FieldYankedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetYankedAt(v)
+		return nil
+	case storage.FieldYankReason:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetYankReason(v)
+		return nil
+	case storage.FieldYankDowngrade:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetYankDowngrade(v)
+		return nil
 	case storage.FieldVersionStorages:
 		v, ok := value.(int)
 		if !ok {
@@ -1465,6 +2955,12 @@ func (m *StorageMutation) AddedFields() []string {
 	if m.addfile_size != nil {
 		fields = append(fields, storage.FieldFileSize)
 	}
+	if m.addpatch_format != nil {
+		fields = append(fields, storage.FieldPatchFormat)
+	}
+	if m.addrollout_percent != nil {
+		fields = append(fields, storage.FieldRolloutPercent)
+	}
 	return fields
 }
 
@@ -1475,6 +2971,10 @@ func (m *StorageMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
 	case storage.FieldFileSize:
 		return m.AddedFileSize()
+	case storage.FieldPatchFormat:
+		return m.AddedPatchFormat()
+	case storage.FieldRolloutPercent:
+		return m.AddedRolloutPercent()
 	}
 	return nil, false
 }
@@ -1491,6 +2991,20 @@ func (m *StorageMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddFileSize(v)
 		return nil
+	case storage.FieldPatchFormat:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPatchFormat(v)
+		return nil
+	case storage.FieldRolloutPercent:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRolloutPercent(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Storage numeric field %s", name)
 }
@@ -1511,6 +3025,21 @@ func (m *StorageMutation) ClearedFields() []string {
 	if m.FieldCleared(storage.FieldFileHashes) {
 		fields = append(fields, storage.FieldFileHashes)
 	}
+	if m.FieldCleared(storage.FieldBrokenAt) {
+		fields = append(fields, storage.FieldBrokenAt)
+	}
+	if m.FieldCleared(storage.FieldLastVerifiedAt) {
+		fields = append(fields, storage.FieldLastVerifiedAt)
+	}
+	if m.FieldCleared(storage.FieldQuarantinedAt) {
+		fields = append(fields, storage.FieldQuarantinedAt)
+	}
+	if m.FieldCleared(storage.FieldYankedAt) {
+		fields = append(fields, storage.FieldYankedAt)
+	}
+	if m.FieldCleared(storage.FieldYankReason) {
+		fields = append(fields, storage.FieldYankReason)
+	}
 	return fields
 }
 
@@ -1537,6 +3066,21 @@ func (m *StorageMutation) ClearField(name string) error {
 	case storage.FieldFileHashes:
 		m.ClearFileHashes()
 		return nil
+	case storage.FieldBrokenAt:
+		m.ClearBrokenAt()
+		return nil
+	case storage.FieldLastVerifiedAt:
+		m.ClearLastVerifiedAt()
+		return nil
+	case storage.FieldQuarantinedAt:
+		m.ClearQuarantinedAt()
+		return nil
+	case storage.FieldYankedAt:
+		m.ClearYankedAt()
+		return nil
+	case storage.FieldYankReason:
+		m.ClearYankReason()
+		return nil
 	}
 	return fmt.Errorf("unknown Storage nullable field %s", name)
 }
@@ -1569,9 +3113,33 @@ func (m *StorageMutation) ResetField(name string) error {
 	case storage.FieldFileHashes:
 		m.ResetFileHashes()
 		return nil
+	case storage.FieldPatchFormat:
+		m.ResetPatchFormat()
+		return nil
 	case storage.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case storage.FieldBrokenAt:
+		m.ResetBrokenAt()
+		return nil
+	case storage.FieldLastVerifiedAt:
+		m.ResetLastVerifiedAt()
+		return nil
+	case storage.FieldQuarantinedAt:
+		m.ResetQuarantinedAt()
+		return nil
+	case storage.FieldRolloutPercent:
+		m.ResetRolloutPercent()
+		return nil
+	case storage.FieldYankedAt:
+		m.ResetYankedAt()
+		return nil
+	case storage.FieldYankReason:
+		m.ResetYankReason()
+		return nil
+	case storage.FieldYankDowngrade:
+		m.ResetYankDowngrade()
+		return nil
 	case storage.FieldVersionStorages:
 		m.ResetVersionStorages()
 		return nil
@@ -1677,13 +3245,17 @@ type VersionMutation struct {
 	op              Op
 	typ             string
 	id              *int
-	channel         *version.Channel
+	channel         *string
 	name            *string
 	number          *uint64
 	addnumber       *int64
 	release_note    *string
+	release_notes   *types.ReleaseNotes
 	custom_data     *string
 	created_at      *time.Time
+	publish_at      *time.Time
+	critical        *bool
+	critical_reason *string
 	clearedFields   map[string]struct{}
 	storages        map[int]struct{}
 	removedstorages map[int]struct{}
@@ -1794,12 +3366,12 @@ func (m *VersionMutation) IDs(ctx context.Context) ([]int, error) {
 }
 
 // SetChannel sets the "channel" field.
-func (m *VersionMutation) SetChannel(v version.Channel) {
-	m.channel = &v
+func (m *VersionMutation) SetChannel(s string) {
+	m.channel = &s
 }
 
 // Channel returns the value of the "channel" field in the mutation.
-func (m *VersionMutation) Channel() (r version.Channel, exists bool) {
+func (m *VersionMutation) Channel() (r string, exists bool) {
 	v := m.channel
 	if v == nil {
 		return
@@ -1810,7 +3382,7 @@ func (m *VersionMutation) Channel() (r version.Channel, exists bool) {
 // OldChannel returns the old "channel" field's value of the Version entity.
 // If the Version object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *VersionMutation) OldChannel(ctx context.Context) (v version.Channel, err error) {
+func (m *VersionMutation) OldChannel(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldChannel is only allowed on UpdateOne operations")
 	}
@@ -1957,6 +3529,55 @@ func (m *VersionMutation) ResetReleaseNote() {
 	m.release_note = nil
 }
 
+// SetReleaseNotes sets the "release_notes" field.
+func (m *VersionMutation) SetReleaseNotes(tn types.ReleaseNotes) {
+	m.release_notes = &tn
+}
+
+// ReleaseNotes returns the value of the "release_notes" field in the mutation.
+func (m *VersionMutation) ReleaseNotes() (r types.ReleaseNotes, exists bool) {
+	v := m.release_notes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReleaseNotes returns the old "release_notes" field's value of the Version entity.
+// If the Version object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VersionMutation) OldReleaseNotes(ctx context.Context) (v types.ReleaseNotes, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReleaseNotes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReleaseNotes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReleaseNotes: %w", err)
+	}
+	return oldValue.ReleaseNotes, nil
+}
+
+// ClearReleaseNotes clears the value of the "release_notes" field.
+func (m *VersionMutation) ClearReleaseNotes() {
+	m.release_notes = nil
+	m.clearedFields[version.FieldReleaseNotes] = struct{}{}
+}
+
+// ReleaseNotesCleared returns if the "release_notes" field was cleared in this mutation.
+func (m *VersionMutation) ReleaseNotesCleared() bool {
+	_, ok := m.clearedFields[version.FieldReleaseNotes]
+	return ok
+}
+
+// ResetReleaseNotes resets all changes to the "release_notes" field.
+func (m *VersionMutation) ResetReleaseNotes() {
+	m.release_notes = nil
+	delete(m.clearedFields, version.FieldReleaseNotes)
+}
+
 // SetCustomData sets the "custom_data" field.
 func (m *VersionMutation) SetCustomData(s string) {
 	m.custom_data = &s
@@ -2029,6 +3650,140 @@ func (m *VersionMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
+// SetPublishAt sets the "publish_at" field.
+func (m *VersionMutation) SetPublishAt(t time.Time) {
+	m.publish_at = &t
+}
+
+// PublishAt returns the value of the "publish_at" field in the mutation.
+func (m *VersionMutation) PublishAt() (r time.Time, exists bool) {
+	v := m.publish_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPublishAt returns the old "publish_at" field's value of the Version entity.
+// If the Version object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VersionMutation) OldPublishAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPublishAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPublishAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPublishAt: %w", err)
+	}
+	return oldValue.PublishAt, nil
+}
+
+// ClearPublishAt clears the value of the "publish_at" field.
+func (m *VersionMutation) ClearPublishAt() {
+	m.publish_at = nil
+	m.clearedFields[version.FieldPublishAt] = struct{}{}
+}
+
+// PublishAtCleared returns if the "publish_at" field was cleared in this mutation.
+func (m *VersionMutation) PublishAtCleared() bool {
+	_, ok := m.clearedFields[version.FieldPublishAt]
+	return ok
+}
+
+// ResetPublishAt resets all changes to the "publish_at" field.
+func (m *VersionMutation) ResetPublishAt() {
+	m.publish_at = nil
+	delete(m.clearedFields, version.FieldPublishAt)
+}
+
+// SetCritical sets the "critical" field.
+func (m *VersionMutation) SetCritical(b bool) {
+	m.critical = &b
+}
+
+// Critical returns the value of the "critical" field in the mutation.
+func (m *VersionMutation) Critical() (r bool, exists bool) {
+	v := m.critical
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCritical returns the old "critical" field's value of the Version entity.
+// If the Version object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VersionMutation) OldCritical(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCritical is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCritical requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCritical: %w", err)
+	}
+	return oldValue.Critical, nil
+}
+
+// ResetCritical resets all changes to the "critical" field.
+func (m *VersionMutation) ResetCritical() {
+	m.critical = nil
+}
+
+// SetCriticalReason sets the "critical_reason" field.
+func (m *VersionMutation) SetCriticalReason(s string) {
+	m.critical_reason = &s
+}
+
+// CriticalReason returns the value of the "critical_reason" field in the mutation.
+func (m *VersionMutation) CriticalReason() (r string, exists bool) {
+	v := m.critical_reason
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCriticalReason returns the old "critical_reason" field's value of the Version entity.
+// If the Version object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VersionMutation) OldCriticalReason(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCriticalReason is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCriticalReason requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCriticalReason: %w", err)
+	}
+	return oldValue.CriticalReason, nil
+}
+
+// ClearCriticalReason clears the value of the "critical_reason" field.
+func (m *VersionMutation) ClearCriticalReason() {
+	m.critical_reason = nil
+	m.clearedFields[version.FieldCriticalReason] = struct{}{}
+}
+
+// CriticalReasonCleared returns if the "critical_reason" field was cleared in this mutation.
+func (m *VersionMutation) CriticalReasonCleared() bool {
+	_, ok := m.clearedFields[version.FieldCriticalReason]
+	return ok
+}
+
+// ResetCriticalReason resets all changes to the "critical_reason" field.
+func (m *VersionMutation) ResetCriticalReason() {
+	m.critical_reason = nil
+	delete(m.clearedFields, version.FieldCriticalReason)
+}
+
 // AddStorageIDs adds the "storages" edge to the Storage entity by ids.
 func (m *VersionMutation) AddStorageIDs(ids ...int) {
 	if m.storages == nil {
@@ -2156,7 +3911,7 @@ func (m *VersionMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *VersionMutation) Fields() []string {
-	fields := make([]string, 0, 6)
+	fields := make([]string, 0, 10)
 	if m.channel != nil {
 		fields = append(fields, version.FieldChannel)
 	}
@@ -2169,12 +3924,24 @@ func (m *VersionMutation) Fields() []string {
 	if m.release_note != nil {
 		fields = append(fields, version.FieldReleaseNote)
 	}
+	if m.release_notes != nil {
+		fields = append(fields, version.FieldReleaseNotes)
+	}
 	if m.custom_data != nil {
 		fields = append(fields, version.FieldCustomData)
 	}
 	if m.created_at != nil {
 		fields = append(fields, version.FieldCreatedAt)
 	}
+	if m.publish_at != nil {
+		fields = append(fields, version.FieldPublishAt)
+	}
+	if m.critical != nil {
+		fields = append(fields, version.FieldCritical)
+	}
+	if m.critical_reason != nil {
+		fields = append(fields, version.FieldCriticalReason)
+	}
 	return fields
 }
 
@@ -2191,10 +3958,18 @@ func (m *VersionMutation) Field(name string) (ent.Value, bool) {
 		return m.Number()
 	case version.FieldReleaseNote:
 		return m.ReleaseNote()
+	case version.FieldReleaseNotes:
+		return m.ReleaseNotes()
 	case version.FieldCustomData:
 		return m.CustomData()
 	case version.FieldCreatedAt:
 		return m.CreatedAt()
+	case version.FieldPublishAt:
+		return m.PublishAt()
+	case version.FieldCritical:
+		return m.Critical()
+	case version.FieldCriticalReason:
+		return m.CriticalReason()
 	}
 	return nil, false
 }
@@ -2212,10 +3987,18 @@ func (m *VersionMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldNumber(ctx)
 	case version.FieldReleaseNote:
 		return m.OldReleaseNote(ctx)
+	case version.FieldReleaseNotes:
+		return m.OldReleaseNotes(ctx)
 	case version.FieldCustomData:
 		return m.OldCustomData(ctx)
 	case version.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case version.FieldPublishAt:
+		return m.OldPublishAt(ctx)
+	case version.FieldCritical:
+		return m.OldCritical(ctx)
+	case version.FieldCriticalReason:
+		return m.OldCriticalReason(ctx)
 	}
 	return nil, fmt.Errorf("unknown Version field %s", name)
 }
@@ -2226,7 +4009,7 @@ func (m *VersionMutation) OldField(ctx context.Context, name string) (ent.Value,
 func (m *VersionMutation) SetField(name string, value ent.Value) error {
 	switch name {
 	case version.FieldChannel:
-		v, ok := value.(version.Channel)
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
@@ -2253,6 +4036,13 @@ func (m *VersionMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetReleaseNote(v)
 		return nil
+	case version.FieldReleaseNotes:
+		v, ok := value.(types.ReleaseNotes)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReleaseNotes(v)
+		return nil
 	case version.FieldCustomData:
 		v, ok := value.(string)
 		if !ok {
@@ -2267,6 +4057,27 @@ func (m *VersionMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case version.FieldPublishAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPublishAt(v)
+		return nil
+	case version.FieldCritical:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCritical(v)
+		return nil
+	case version.FieldCriticalReason:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCriticalReason(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Version field %s", name)
 }
@@ -2311,7 +4122,17 @@ func (m *VersionMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *VersionMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(version.FieldReleaseNotes) {
+		fields = append(fields, version.FieldReleaseNotes)
+	}
+	if m.FieldCleared(version.FieldPublishAt) {
+		fields = append(fields, version.FieldPublishAt)
+	}
+	if m.FieldCleared(version.FieldCriticalReason) {
+		fields = append(fields, version.FieldCriticalReason)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -2324,6 +4145,17 @@ func (m *VersionMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *VersionMutation) ClearField(name string) error {
+	switch name {
+	case version.FieldReleaseNotes:
+		m.ClearReleaseNotes()
+		return nil
+	case version.FieldPublishAt:
+		m.ClearPublishAt()
+		return nil
+	case version.FieldCriticalReason:
+		m.ClearCriticalReason()
+		return nil
+	}
 	return fmt.Errorf("unknown Version nullable field %s", name)
 }
 
@@ -2343,12 +4175,24 @@ func (m *VersionMutation) ResetField(name string) error {
 	case version.FieldReleaseNote:
 		m.ResetReleaseNote()
 		return nil
+	case version.FieldReleaseNotes:
+		m.ResetReleaseNotes()
+		return nil
 	case version.FieldCustomData:
 		m.ResetCustomData()
 		return nil
 	case version.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case version.FieldPublishAt:
+		m.ResetPublishAt()
+		return nil
+	case version.FieldCritical:
+		m.ResetCritical()
+		return nil
+	case version.FieldCriticalReason:
+		m.ResetCriticalReason()
+		return nil
 	}
 	return fmt.Errorf("unknown Version field %s", name)
 }
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 // Resource is the model entity for the Resource schema.
@@ -25,6 +27,16 @@ type Resource struct {
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdateType holds the value of the "update_type" field.
 	UpdateType string `json:"update_type,omitempty"`
+	// semver, loose_semver, calver, pep440, datetime or integer, empty tries the default parsers
+	VersionScheme string `json:"version_scheme,omitempty"`
+	// ordered distributors used for downloads, empty means weighted split
+	DistributionPolicy types.DistributionPolicy `json:"distribution_policy,omitempty"`
+	// how incremental packages are served, empty means defaults
+	PatchPolicy types.PatchPolicy `json:"patch_policy,omitempty"`
+	// which full packages survive the purge job, empty keeps the two newest
+	RetentionPolicy types.RetentionPolicy `json:"retention_policy,omitempty"`
+	// minimum supported version per channel, older clients are forced to update
+	SupportPolicy types.SupportPolicy `json:"support_policy,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ResourceQuery when eager-loading is set.
 	Edges        ResourceEdges `json:"edges"`
@@ -35,9 +47,11 @@ type Resource struct {
 type ResourceEdges struct {
 	// Versions holds the value of the versions edge.
 	Versions []*Version `json:"versions,omitempty"`
+	// Channels holds the value of the channels edge.
+	Channels []*Channel `json:"channels,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // VersionsOrErr returns the Versions value or an error if the edge
@@ -49,12 +63,23 @@ func (e ResourceEdges) VersionsOrErr() ([]*Version, error) {
 	return nil, &NotLoadedError{edge: "versions"}
 }
 
+// ChannelsOrErr returns the Channels value or an error if the edge
+// was not loaded in eager-loading.
+func (e ResourceEdges) ChannelsOrErr() ([]*Channel, error) {
+	if e.loadedTypes[1] {
+		return e.Channels, nil
+	}
+	return nil, &NotLoadedError{edge: "channels"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Resource) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case resource.FieldID, resource.FieldName, resource.FieldDescription, resource.FieldUpdateType:
+		case resource.FieldDistributionPolicy, resource.FieldPatchPolicy, resource.FieldRetentionPolicy, resource.FieldSupportPolicy:
+			values[i] = new([]byte)
+		case resource.FieldID, resource.FieldName, resource.FieldDescription, resource.FieldUpdateType, resource.FieldVersionScheme:
 			values[i] = new(sql.NullString)
 		case resource.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -103,6 +128,44 @@ func (r *Resource) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				r.UpdateType = value.String
 			}
+		case resource.FieldVersionScheme:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field version_scheme", values[i])
+			} else if value.Valid {
+				r.VersionScheme = value.String
+			}
+		case resource.FieldDistributionPolicy:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field distribution_policy", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &r.DistributionPolicy); err != nil {
+					return fmt.Errorf("unmarshal field distribution_policy: %w", err)
+				}
+			}
+		case resource.FieldPatchPolicy:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field patch_policy", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &r.PatchPolicy); err != nil {
+					return fmt.Errorf("unmarshal field patch_policy: %w", err)
+				}
+			}
+		case resource.FieldRetentionPolicy:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field retention_policy", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &r.RetentionPolicy); err != nil {
+					return fmt.Errorf("unmarshal field retention_policy: %w", err)
+				}
+			}
+		case resource.FieldSupportPolicy:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field support_policy", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &r.SupportPolicy); err != nil {
+					return fmt.Errorf("unmarshal field support_policy: %w", err)
+				}
+			}
 		default:
 			r.selectValues.Set(columns[i], values[i])
 		}
@@ -121,6 +184,11 @@ func (r *Resource) QueryVersions() *VersionQuery {
 	return NewResourceClient(r.config).QueryVersions(r)
 }
 
+// QueryChannels queries the "channels" edge of the Resource entity.
+func (r *Resource) QueryChannels() *ChannelQuery {
+	return NewResourceClient(r.config).QueryChannels(r)
+}
+
 // Update returns a builder for updating this Resource.
 // Note that you need to call Resource.Unwrap() before calling this method if this Resource
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -155,6 +223,21 @@ func (r *Resource) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("update_type=")
 	builder.WriteString(r.UpdateType)
+	builder.WriteString(", ")
+	builder.WriteString("version_scheme=")
+	builder.WriteString(r.VersionScheme)
+	builder.WriteString(", ")
+	builder.WriteString("distribution_policy=")
+	builder.WriteString(fmt.Sprintf("%v", r.DistributionPolicy))
+	builder.WriteString(", ")
+	builder.WriteString("patch_policy=")
+	builder.WriteString(fmt.Sprintf("%v", r.PatchPolicy))
+	builder.WriteString(", ")
+	builder.WriteString("retention_policy=")
+	builder.WriteString(fmt.Sprintf("%v", r.RetentionPolicy))
+	builder.WriteString(", ")
+	builder.WriteString("support_policy=")
+	builder.WriteString(fmt.Sprintf("%v", r.SupportPolicy))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -22,8 +22,20 @@ const (
 	FieldCreatedAt = "created_at"
 	// FieldUpdateType holds the string denoting the update_type field in the database.
 	FieldUpdateType = "update_type"
+	// FieldVersionScheme holds the string denoting the version_scheme field in the database.
+	FieldVersionScheme = "version_scheme"
+	// FieldDistributionPolicy holds the string denoting the distribution_policy field in the database.
+	FieldDistributionPolicy = "distribution_policy"
+	// FieldPatchPolicy holds the string denoting the patch_policy field in the database.
+	FieldPatchPolicy = "patch_policy"
+	// FieldRetentionPolicy holds the string denoting the retention_policy field in the database.
+	FieldRetentionPolicy = "retention_policy"
+	// FieldSupportPolicy holds the string denoting the support_policy field in the database.
+	FieldSupportPolicy = "support_policy"
 	// EdgeVersions holds the string denoting the versions edge name in mutations.
 	EdgeVersions = "versions"
+	// EdgeChannels holds the string denoting the channels edge name in mutations.
+	EdgeChannels = "channels"
 	// Table holds the table name of the resource in the database.
 	Table = "resources"
 	// VersionsTable is the table that holds the versions relation/edge.
@@ -33,6 +45,13 @@ const (
 	VersionsInverseTable = "versions"
 	// VersionsColumn is the table column denoting the versions relation/edge.
 	VersionsColumn = "resource_versions"
+	// ChannelsTable is the table that holds the channels relation/edge.
+	ChannelsTable = "channels"
+	// ChannelsInverseTable is the table name for the Channel entity.
+	// It exists in this package in order to avoid circular dependency with the "channel" package.
+	ChannelsInverseTable = "channels"
+	// ChannelsColumn is the table column denoting the channels relation/edge.
+	ChannelsColumn = "resource_channels"
 )
 
 // Columns holds all SQL columns for resource fields.
@@ -42,6 +61,11 @@ var Columns = []string{
 	FieldDescription,
 	FieldCreatedAt,
 	FieldUpdateType,
+	FieldVersionScheme,
+	FieldDistributionPolicy,
+	FieldPatchPolicy,
+	FieldRetentionPolicy,
+	FieldSupportPolicy,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -61,6 +85,8 @@ var (
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdateType holds the default value on creation for the "update_type" field.
 	DefaultUpdateType string
+	// DefaultVersionScheme holds the default value on creation for the "version_scheme" field.
+	DefaultVersionScheme string
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(string) error
 )
@@ -93,6 +119,11 @@ func ByUpdateType(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldUpdateType, opts...).ToFunc()
 }
 
+// ByVersionScheme orders the results by the version_scheme field.
+func ByVersionScheme(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersionScheme, opts...).ToFunc()
+}
+
 // ByVersionsCount orders the results by versions count.
 func ByVersionsCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -106,6 +137,20 @@ func ByVersions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newVersionsStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByChannelsCount orders the results by channels count.
+func ByChannelsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newChannelsStep(), opts...)
+	}
+}
+
+// ByChannels orders the results by channels terms.
+func ByChannels(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newChannelsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newVersionsStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -113,3 +158,10 @@ func newVersionsStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, VersionsTable, VersionsColumn),
 	)
 }
+func newChannelsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ChannelsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ChannelsTable, ChannelsColumn),
+	)
+}
@@ -85,6 +85,11 @@ func UpdateType(v string) predicate.Resource {
 	return predicate.Resource(sql.FieldEQ(FieldUpdateType, v))
 }
 
+// VersionScheme applies equality check predicate on the "version_scheme" field. It's identical to VersionSchemeEQ.
+func VersionScheme(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldEQ(FieldVersionScheme, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Resource {
 	return predicate.Resource(sql.FieldEQ(FieldName, v))
@@ -320,6 +325,111 @@ func UpdateTypeContainsFold(v string) predicate.Resource {
 	return predicate.Resource(sql.FieldContainsFold(FieldUpdateType, v))
 }
 
+// VersionSchemeEQ applies the EQ predicate on the "version_scheme" field.
+func VersionSchemeEQ(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldEQ(FieldVersionScheme, v))
+}
+
+// VersionSchemeNEQ applies the NEQ predicate on the "version_scheme" field.
+func VersionSchemeNEQ(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldNEQ(FieldVersionScheme, v))
+}
+
+// VersionSchemeIn applies the In predicate on the "version_scheme" field.
+func VersionSchemeIn(vs ...string) predicate.Resource {
+	return predicate.Resource(sql.FieldIn(FieldVersionScheme, vs...))
+}
+
+// VersionSchemeNotIn applies the NotIn predicate on the "version_scheme" field.
+func VersionSchemeNotIn(vs ...string) predicate.Resource {
+	return predicate.Resource(sql.FieldNotIn(FieldVersionScheme, vs...))
+}
+
+// VersionSchemeGT applies the GT predicate on the "version_scheme" field.
+func VersionSchemeGT(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldGT(FieldVersionScheme, v))
+}
+
+// VersionSchemeGTE applies the GTE predicate on the "version_scheme" field.
+func VersionSchemeGTE(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldGTE(FieldVersionScheme, v))
+}
+
+// VersionSchemeLT applies the LT predicate on the "version_scheme" field.
+func VersionSchemeLT(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldLT(FieldVersionScheme, v))
+}
+
+// VersionSchemeLTE applies the LTE predicate on the "version_scheme" field.
+func VersionSchemeLTE(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldLTE(FieldVersionScheme, v))
+}
+
+// VersionSchemeContains applies the Contains predicate on the "version_scheme" field.
+func VersionSchemeContains(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldContains(FieldVersionScheme, v))
+}
+
+// VersionSchemeHasPrefix applies the HasPrefix predicate on the "version_scheme" field.
+func VersionSchemeHasPrefix(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldHasPrefix(FieldVersionScheme, v))
+}
+
+// VersionSchemeHasSuffix applies the HasSuffix predicate on the "version_scheme" field.
+func VersionSchemeHasSuffix(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldHasSuffix(FieldVersionScheme, v))
+}
+
+// VersionSchemeEqualFold applies the EqualFold predicate on the "version_scheme" field.
+func VersionSchemeEqualFold(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldEqualFold(FieldVersionScheme, v))
+}
+
+// VersionSchemeContainsFold applies the ContainsFold predicate on the "version_scheme" field.
+func VersionSchemeContainsFold(v string) predicate.Resource {
+	return predicate.Resource(sql.FieldContainsFold(FieldVersionScheme, v))
+}
+
+// DistributionPolicyIsNil applies the IsNil predicate on the "distribution_policy" field.
+func DistributionPolicyIsNil() predicate.Resource {
+	return predicate.Resource(sql.FieldIsNull(FieldDistributionPolicy))
+}
+
+// DistributionPolicyNotNil applies the NotNil predicate on the "distribution_policy" field.
+func DistributionPolicyNotNil() predicate.Resource {
+	return predicate.Resource(sql.FieldNotNull(FieldDistributionPolicy))
+}
+
+// PatchPolicyIsNil applies the IsNil predicate on the "patch_policy" field.
+func PatchPolicyIsNil() predicate.Resource {
+	return predicate.Resource(sql.FieldIsNull(FieldPatchPolicy))
+}
+
+// PatchPolicyNotNil applies the NotNil predicate on the "patch_policy" field.
+func PatchPolicyNotNil() predicate.Resource {
+	return predicate.Resource(sql.FieldNotNull(FieldPatchPolicy))
+}
+
+// RetentionPolicyIsNil applies the IsNil predicate on the "retention_policy" field.
+func RetentionPolicyIsNil() predicate.Resource {
+	return predicate.Resource(sql.FieldIsNull(FieldRetentionPolicy))
+}
+
+// RetentionPolicyNotNil applies the NotNil predicate on the "retention_policy" field.
+func RetentionPolicyNotNil() predicate.Resource {
+	return predicate.Resource(sql.FieldNotNull(FieldRetentionPolicy))
+}
+
+// SupportPolicyIsNil applies the IsNil predicate on the "support_policy" field.
+func SupportPolicyIsNil() predicate.Resource {
+	return predicate.Resource(sql.FieldIsNull(FieldSupportPolicy))
+}
+
+// SupportPolicyNotNil applies the NotNil predicate on the "support_policy" field.
+func SupportPolicyNotNil() predicate.Resource {
+	return predicate.Resource(sql.FieldNotNull(FieldSupportPolicy))
+}
+
 // HasVersions applies the HasEdge predicate on the "versions" edge.
 func HasVersions() predicate.Resource {
 	return predicate.Resource(func(s *sql.Selector) {
@@ -343,6 +453,29 @@ func HasVersionsWith(preds ...predicate.Version) predicate.Resource {
 	})
 }
 
+// HasChannels applies the HasEdge predicate on the "channels" edge.
+func HasChannels() predicate.Resource {
+	return predicate.Resource(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, ChannelsTable, ChannelsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasChannelsWith applies the HasEdge predicate on the "channels" edge with a given conditions (other predicates).
+func HasChannelsWith(preds ...predicate.Channel) predicate.Resource {
+	return predicate.Resource(func(s *sql.Selector) {
+		step := newChannelsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Resource) predicate.Resource {
 	return predicate.Resource(sql.AndPredicates(predicates...))
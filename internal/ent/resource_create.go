@@ -10,8 +10,10 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 // ResourceCreate is the builder for creating a Resource entity.
@@ -61,6 +63,76 @@ func (rc *ResourceCreate) SetNillableUpdateType(s *string) *ResourceCreate {
 	return rc
 }
 
+// SetVersionScheme sets the "version_scheme" field.
+func (rc *ResourceCreate) SetVersionScheme(s string) *ResourceCreate {
+	rc.mutation.SetVersionScheme(s)
+	return rc
+}
+
+// SetNillableVersionScheme sets the "version_scheme" field if the given value is not nil.
+func (rc *ResourceCreate) SetNillableVersionScheme(s *string) *ResourceCreate {
+	if s != nil {
+		rc.SetVersionScheme(*s)
+	}
+	return rc
+}
+
+// SetDistributionPolicy sets the "distribution_policy" field.
+func (rc *ResourceCreate) SetDistributionPolicy(tp types.DistributionPolicy) *ResourceCreate {
+	rc.mutation.SetDistributionPolicy(tp)
+	return rc
+}
+
+// SetNillableDistributionPolicy sets the "distribution_policy" field if the given value is not nil.
+func (rc *ResourceCreate) SetNillableDistributionPolicy(tp *types.DistributionPolicy) *ResourceCreate {
+	if tp != nil {
+		rc.SetDistributionPolicy(*tp)
+	}
+	return rc
+}
+
+// SetPatchPolicy sets the "patch_policy" field.
+func (rc *ResourceCreate) SetPatchPolicy(tp types.PatchPolicy) *ResourceCreate {
+	rc.mutation.SetPatchPolicy(tp)
+	return rc
+}
+
+// SetNillablePatchPolicy sets the "patch_policy" field if the given value is not nil.
+func (rc *ResourceCreate) SetNillablePatchPolicy(tp *types.PatchPolicy) *ResourceCreate {
+	if tp != nil {
+		rc.SetPatchPolicy(*tp)
+	}
+	return rc
+}
+
+// SetRetentionPolicy sets the "retention_policy" field.
+func (rc *ResourceCreate) SetRetentionPolicy(tp types.RetentionPolicy) *ResourceCreate {
+	rc.mutation.SetRetentionPolicy(tp)
+	return rc
+}
+
+// SetNillableRetentionPolicy sets the "retention_policy" field if the given value is not nil.
+func (rc *ResourceCreate) SetNillableRetentionPolicy(tp *types.RetentionPolicy) *ResourceCreate {
+	if tp != nil {
+		rc.SetRetentionPolicy(*tp)
+	}
+	return rc
+}
+
+// SetSupportPolicy sets the "support_policy" field.
+func (rc *ResourceCreate) SetSupportPolicy(tp types.SupportPolicy) *ResourceCreate {
+	rc.mutation.SetSupportPolicy(tp)
+	return rc
+}
+
+// SetNillableSupportPolicy sets the "support_policy" field if the given value is not nil.
+func (rc *ResourceCreate) SetNillableSupportPolicy(tp *types.SupportPolicy) *ResourceCreate {
+	if tp != nil {
+		rc.SetSupportPolicy(*tp)
+	}
+	return rc
+}
+
 // SetID sets the "id" field.
 func (rc *ResourceCreate) SetID(s string) *ResourceCreate {
 	rc.mutation.SetID(s)
@@ -82,6 +154,21 @@ func (rc *ResourceCreate) AddVersions(v ...*Version) *ResourceCreate {
 	return rc.AddVersionIDs(ids...)
 }
 
+// AddChannelIDs adds the "channels" edge to the Channel entity by IDs.
+func (rc *ResourceCreate) AddChannelIDs(ids ...int) *ResourceCreate {
+	rc.mutation.AddChannelIDs(ids...)
+	return rc
+}
+
+// AddChannels adds the "channels" edges to the Channel entity.
+func (rc *ResourceCreate) AddChannels(c ...*Channel) *ResourceCreate {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return rc.AddChannelIDs(ids...)
+}
+
 // Mutation returns the ResourceMutation object of the builder.
 func (rc *ResourceCreate) Mutation() *ResourceMutation {
 	return rc.mutation
@@ -125,6 +212,10 @@ func (rc *ResourceCreate) defaults() {
 		v := resource.DefaultUpdateType
 		rc.mutation.SetUpdateType(v)
 	}
+	if _, ok := rc.mutation.VersionScheme(); !ok {
+		v := resource.DefaultVersionScheme
+		rc.mutation.SetVersionScheme(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -146,6 +237,9 @@ func (rc *ResourceCreate) check() error {
 	if _, ok := rc.mutation.UpdateType(); !ok {
 		return &ValidationError{Name: "update_type", err: errors.New(`ent: missing required field "Resource.update_type"`)}
 	}
+	if _, ok := rc.mutation.VersionScheme(); !ok {
+		return &ValidationError{Name: "version_scheme", err: errors.New(`ent: missing required field "Resource.version_scheme"`)}
+	}
 	if v, ok := rc.mutation.ID(); ok {
 		if err := resource.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Resource.id": %w`, err)}
@@ -202,6 +296,26 @@ func (rc *ResourceCreate) createSpec() (*Resource, *sqlgraph.CreateSpec) {
 		_spec.SetField(resource.FieldUpdateType, field.TypeString, value)
 		_node.UpdateType = value
 	}
+	if value, ok := rc.mutation.VersionScheme(); ok {
+		_spec.SetField(resource.FieldVersionScheme, field.TypeString, value)
+		_node.VersionScheme = value
+	}
+	if value, ok := rc.mutation.DistributionPolicy(); ok {
+		_spec.SetField(resource.FieldDistributionPolicy, field.TypeJSON, value)
+		_node.DistributionPolicy = value
+	}
+	if value, ok := rc.mutation.PatchPolicy(); ok {
+		_spec.SetField(resource.FieldPatchPolicy, field.TypeJSON, value)
+		_node.PatchPolicy = value
+	}
+	if value, ok := rc.mutation.RetentionPolicy(); ok {
+		_spec.SetField(resource.FieldRetentionPolicy, field.TypeJSON, value)
+		_node.RetentionPolicy = value
+	}
+	if value, ok := rc.mutation.SupportPolicy(); ok {
+		_spec.SetField(resource.FieldSupportPolicy, field.TypeJSON, value)
+		_node.SupportPolicy = value
+	}
 	if nodes := rc.mutation.VersionsIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -218,6 +332,22 @@ func (rc *ResourceCreate) createSpec() (*Resource, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := rc.mutation.ChannelsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
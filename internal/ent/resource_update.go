@@ -11,9 +11,11 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
 	"github.com/MirrorChyan/resource-backend/internal/ent/predicate"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 // ResourceUpdate is the builder for updating Resource entities.
@@ -85,6 +87,100 @@ func (ru *ResourceUpdate) SetNillableUpdateType(s *string) *ResourceUpdate {
 	return ru
 }
 
+// SetVersionScheme sets the "version_scheme" field.
+func (ru *ResourceUpdate) SetVersionScheme(s string) *ResourceUpdate {
+	ru.mutation.SetVersionScheme(s)
+	return ru
+}
+
+// SetNillableVersionScheme sets the "version_scheme" field if the given value is not nil.
+func (ru *ResourceUpdate) SetNillableVersionScheme(s *string) *ResourceUpdate {
+	if s != nil {
+		ru.SetVersionScheme(*s)
+	}
+	return ru
+}
+
+// SetDistributionPolicy sets the "distribution_policy" field.
+func (ru *ResourceUpdate) SetDistributionPolicy(tp types.DistributionPolicy) *ResourceUpdate {
+	ru.mutation.SetDistributionPolicy(tp)
+	return ru
+}
+
+// SetNillableDistributionPolicy sets the "distribution_policy" field if the given value is not nil.
+func (ru *ResourceUpdate) SetNillableDistributionPolicy(tp *types.DistributionPolicy) *ResourceUpdate {
+	if tp != nil {
+		ru.SetDistributionPolicy(*tp)
+	}
+	return ru
+}
+
+// ClearDistributionPolicy clears the value of the "distribution_policy" field.
+func (ru *ResourceUpdate) ClearDistributionPolicy() *ResourceUpdate {
+	ru.mutation.ClearDistributionPolicy()
+	return ru
+}
+
+// SetPatchPolicy sets the "patch_policy" field.
+func (ru *ResourceUpdate) SetPatchPolicy(tp types.PatchPolicy) *ResourceUpdate {
+	ru.mutation.SetPatchPolicy(tp)
+	return ru
+}
+
+// SetNillablePatchPolicy sets the "patch_policy" field if the given value is not nil.
+func (ru *ResourceUpdate) SetNillablePatchPolicy(tp *types.PatchPolicy) *ResourceUpdate {
+	if tp != nil {
+		ru.SetPatchPolicy(*tp)
+	}
+	return ru
+}
+
+// ClearPatchPolicy clears the value of the "patch_policy" field.
+func (ru *ResourceUpdate) ClearPatchPolicy() *ResourceUpdate {
+	ru.mutation.ClearPatchPolicy()
+	return ru
+}
+
+// SetRetentionPolicy sets the "retention_policy" field.
+func (ru *ResourceUpdate) SetRetentionPolicy(tp types.RetentionPolicy) *ResourceUpdate {
+	ru.mutation.SetRetentionPolicy(tp)
+	return ru
+}
+
+// SetNillableRetentionPolicy sets the "retention_policy" field if the given value is not nil.
+func (ru *ResourceUpdate) SetNillableRetentionPolicy(tp *types.RetentionPolicy) *ResourceUpdate {
+	if tp != nil {
+		ru.SetRetentionPolicy(*tp)
+	}
+	return ru
+}
+
+// ClearRetentionPolicy clears the value of the "retention_policy" field.
+func (ru *ResourceUpdate) ClearRetentionPolicy() *ResourceUpdate {
+	ru.mutation.ClearRetentionPolicy()
+	return ru
+}
+
+// SetSupportPolicy sets the "support_policy" field.
+func (ru *ResourceUpdate) SetSupportPolicy(tp types.SupportPolicy) *ResourceUpdate {
+	ru.mutation.SetSupportPolicy(tp)
+	return ru
+}
+
+// SetNillableSupportPolicy sets the "support_policy" field if the given value is not nil.
+func (ru *ResourceUpdate) SetNillableSupportPolicy(tp *types.SupportPolicy) *ResourceUpdate {
+	if tp != nil {
+		ru.SetSupportPolicy(*tp)
+	}
+	return ru
+}
+
+// ClearSupportPolicy clears the value of the "support_policy" field.
+func (ru *ResourceUpdate) ClearSupportPolicy() *ResourceUpdate {
+	ru.mutation.ClearSupportPolicy()
+	return ru
+}
+
 // AddVersionIDs adds the "versions" edge to the Version entity by IDs.
 func (ru *ResourceUpdate) AddVersionIDs(ids ...int) *ResourceUpdate {
 	ru.mutation.AddVersionIDs(ids...)
@@ -100,6 +196,21 @@ func (ru *ResourceUpdate) AddVersions(v ...*Version) *ResourceUpdate {
 	return ru.AddVersionIDs(ids...)
 }
 
+// AddChannelIDs adds the "channels" edge to the Channel entity by IDs.
+func (ru *ResourceUpdate) AddChannelIDs(ids ...int) *ResourceUpdate {
+	ru.mutation.AddChannelIDs(ids...)
+	return ru
+}
+
+// AddChannels adds the "channels" edges to the Channel entity.
+func (ru *ResourceUpdate) AddChannels(c ...*Channel) *ResourceUpdate {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return ru.AddChannelIDs(ids...)
+}
+
 // Mutation returns the ResourceMutation object of the builder.
 func (ru *ResourceUpdate) Mutation() *ResourceMutation {
 	return ru.mutation
@@ -126,6 +237,27 @@ func (ru *ResourceUpdate) RemoveVersions(v ...*Version) *ResourceUpdate {
 	return ru.RemoveVersionIDs(ids...)
 }
 
+// ClearChannels clears all "channels" edges to the Channel entity.
+func (ru *ResourceUpdate) ClearChannels() *ResourceUpdate {
+	ru.mutation.ClearChannels()
+	return ru
+}
+
+// RemoveChannelIDs removes the "channels" edge to Channel entities by IDs.
+func (ru *ResourceUpdate) RemoveChannelIDs(ids ...int) *ResourceUpdate {
+	ru.mutation.RemoveChannelIDs(ids...)
+	return ru
+}
+
+// RemoveChannels removes "channels" edges to Channel entities.
+func (ru *ResourceUpdate) RemoveChannels(c ...*Channel) *ResourceUpdate {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return ru.RemoveChannelIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (ru *ResourceUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, ru.sqlSave, ru.mutation, ru.hooks)
@@ -187,6 +319,33 @@ func (ru *ResourceUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := ru.mutation.UpdateType(); ok {
 		_spec.SetField(resource.FieldUpdateType, field.TypeString, value)
 	}
+	if value, ok := ru.mutation.VersionScheme(); ok {
+		_spec.SetField(resource.FieldVersionScheme, field.TypeString, value)
+	}
+	if value, ok := ru.mutation.DistributionPolicy(); ok {
+		_spec.SetField(resource.FieldDistributionPolicy, field.TypeJSON, value)
+	}
+	if ru.mutation.DistributionPolicyCleared() {
+		_spec.ClearField(resource.FieldDistributionPolicy, field.TypeJSON)
+	}
+	if value, ok := ru.mutation.PatchPolicy(); ok {
+		_spec.SetField(resource.FieldPatchPolicy, field.TypeJSON, value)
+	}
+	if ru.mutation.PatchPolicyCleared() {
+		_spec.ClearField(resource.FieldPatchPolicy, field.TypeJSON)
+	}
+	if value, ok := ru.mutation.RetentionPolicy(); ok {
+		_spec.SetField(resource.FieldRetentionPolicy, field.TypeJSON, value)
+	}
+	if ru.mutation.RetentionPolicyCleared() {
+		_spec.ClearField(resource.FieldRetentionPolicy, field.TypeJSON)
+	}
+	if value, ok := ru.mutation.SupportPolicy(); ok {
+		_spec.SetField(resource.FieldSupportPolicy, field.TypeJSON, value)
+	}
+	if ru.mutation.SupportPolicyCleared() {
+		_spec.ClearField(resource.FieldSupportPolicy, field.TypeJSON)
+	}
 	if ru.mutation.VersionsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -232,6 +391,51 @@ func (ru *ResourceUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if ru.mutation.ChannelsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.RemovedChannelsIDs(); len(nodes) > 0 && !ru.mutation.ChannelsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.ChannelsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, ru.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{resource.Label}
@@ -308,6 +512,100 @@ func (ruo *ResourceUpdateOne) SetNillableUpdateType(s *string) *ResourceUpdateOn
 	return ruo
 }
 
+// SetVersionScheme sets the "version_scheme" field.
+func (ruo *ResourceUpdateOne) SetVersionScheme(s string) *ResourceUpdateOne {
+	ruo.mutation.SetVersionScheme(s)
+	return ruo
+}
+
+// SetNillableVersionScheme sets the "version_scheme" field if the given value is not nil.
+func (ruo *ResourceUpdateOne) SetNillableVersionScheme(s *string) *ResourceUpdateOne {
+	if s != nil {
+		ruo.SetVersionScheme(*s)
+	}
+	return ruo
+}
+
+// SetDistributionPolicy sets the "distribution_policy" field.
+func (ruo *ResourceUpdateOne) SetDistributionPolicy(tp types.DistributionPolicy) *ResourceUpdateOne {
+	ruo.mutation.SetDistributionPolicy(tp)
+	return ruo
+}
+
+// SetNillableDistributionPolicy sets the "distribution_policy" field if the given value is not nil.
+func (ruo *ResourceUpdateOne) SetNillableDistributionPolicy(tp *types.DistributionPolicy) *ResourceUpdateOne {
+	if tp != nil {
+		ruo.SetDistributionPolicy(*tp)
+	}
+	return ruo
+}
+
+// ClearDistributionPolicy clears the value of the "distribution_policy" field.
+func (ruo *ResourceUpdateOne) ClearDistributionPolicy() *ResourceUpdateOne {
+	ruo.mutation.ClearDistributionPolicy()
+	return ruo
+}
+
+// SetPatchPolicy sets the "patch_policy" field.
+func (ruo *ResourceUpdateOne) SetPatchPolicy(tp types.PatchPolicy) *ResourceUpdateOne {
+	ruo.mutation.SetPatchPolicy(tp)
+	return ruo
+}
+
+// SetNillablePatchPolicy sets the "patch_policy" field if the given value is not nil.
+func (ruo *ResourceUpdateOne) SetNillablePatchPolicy(tp *types.PatchPolicy) *ResourceUpdateOne {
+	if tp != nil {
+		ruo.SetPatchPolicy(*tp)
+	}
+	return ruo
+}
+
+// ClearPatchPolicy clears the value of the "patch_policy" field.
+func (ruo *ResourceUpdateOne) ClearPatchPolicy() *ResourceUpdateOne {
+	ruo.mutation.ClearPatchPolicy()
+	return ruo
+}
+
+// SetRetentionPolicy sets the "retention_policy" field.
+func (ruo *ResourceUpdateOne) SetRetentionPolicy(tp types.RetentionPolicy) *ResourceUpdateOne {
+	ruo.mutation.SetRetentionPolicy(tp)
+	return ruo
+}
+
+// SetNillableRetentionPolicy sets the "retention_policy" field if the given value is not nil.
+func (ruo *ResourceUpdateOne) SetNillableRetentionPolicy(tp *types.RetentionPolicy) *ResourceUpdateOne {
+	if tp != nil {
+		ruo.SetRetentionPolicy(*tp)
+	}
+	return ruo
+}
+
+// ClearRetentionPolicy clears the value of the "retention_policy" field.
+func (ruo *ResourceUpdateOne) ClearRetentionPolicy() *ResourceUpdateOne {
+	ruo.mutation.ClearRetentionPolicy()
+	return ruo
+}
+
+// SetSupportPolicy sets the "support_policy" field.
+func (ruo *ResourceUpdateOne) SetSupportPolicy(tp types.SupportPolicy) *ResourceUpdateOne {
+	ruo.mutation.SetSupportPolicy(tp)
+	return ruo
+}
+
+// SetNillableSupportPolicy sets the "support_policy" field if the given value is not nil.
+func (ruo *ResourceUpdateOne) SetNillableSupportPolicy(tp *types.SupportPolicy) *ResourceUpdateOne {
+	if tp != nil {
+		ruo.SetSupportPolicy(*tp)
+	}
+	return ruo
+}
+
+// ClearSupportPolicy clears the value of the "support_policy" field.
+func (ruo *ResourceUpdateOne) ClearSupportPolicy() *ResourceUpdateOne {
+	ruo.mutation.ClearSupportPolicy()
+	return ruo
+}
+
 // AddVersionIDs adds the "versions" edge to the Version entity by IDs.
 func (ruo *ResourceUpdateOne) AddVersionIDs(ids ...int) *ResourceUpdateOne {
 	ruo.mutation.AddVersionIDs(ids...)
@@ -323,6 +621,21 @@ func (ruo *ResourceUpdateOne) AddVersions(v ...*Version) *ResourceUpdateOne {
 	return ruo.AddVersionIDs(ids...)
 }
 
+// AddChannelIDs adds the "channels" edge to the Channel entity by IDs.
+func (ruo *ResourceUpdateOne) AddChannelIDs(ids ...int) *ResourceUpdateOne {
+	ruo.mutation.AddChannelIDs(ids...)
+	return ruo
+}
+
+// AddChannels adds the "channels" edges to the Channel entity.
+func (ruo *ResourceUpdateOne) AddChannels(c ...*Channel) *ResourceUpdateOne {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return ruo.AddChannelIDs(ids...)
+}
+
 // Mutation returns the ResourceMutation object of the builder.
 func (ruo *ResourceUpdateOne) Mutation() *ResourceMutation {
 	return ruo.mutation
@@ -349,6 +662,27 @@ func (ruo *ResourceUpdateOne) RemoveVersions(v ...*Version) *ResourceUpdateOne {
 	return ruo.RemoveVersionIDs(ids...)
 }
 
+// ClearChannels clears all "channels" edges to the Channel entity.
+func (ruo *ResourceUpdateOne) ClearChannels() *ResourceUpdateOne {
+	ruo.mutation.ClearChannels()
+	return ruo
+}
+
+// RemoveChannelIDs removes the "channels" edge to Channel entities by IDs.
+func (ruo *ResourceUpdateOne) RemoveChannelIDs(ids ...int) *ResourceUpdateOne {
+	ruo.mutation.RemoveChannelIDs(ids...)
+	return ruo
+}
+
+// RemoveChannels removes "channels" edges to Channel entities.
+func (ruo *ResourceUpdateOne) RemoveChannels(c ...*Channel) *ResourceUpdateOne {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return ruo.RemoveChannelIDs(ids...)
+}
+
 // Where appends a list predicates to the ResourceUpdate builder.
 func (ruo *ResourceUpdateOne) Where(ps ...predicate.Resource) *ResourceUpdateOne {
 	ruo.mutation.Where(ps...)
@@ -440,6 +774,33 @@ func (ruo *ResourceUpdateOne) sqlSave(ctx context.Context) (_node *Resource, err
 	if value, ok := ruo.mutation.UpdateType(); ok {
 		_spec.SetField(resource.FieldUpdateType, field.TypeString, value)
 	}
+	if value, ok := ruo.mutation.VersionScheme(); ok {
+		_spec.SetField(resource.FieldVersionScheme, field.TypeString, value)
+	}
+	if value, ok := ruo.mutation.DistributionPolicy(); ok {
+		_spec.SetField(resource.FieldDistributionPolicy, field.TypeJSON, value)
+	}
+	if ruo.mutation.DistributionPolicyCleared() {
+		_spec.ClearField(resource.FieldDistributionPolicy, field.TypeJSON)
+	}
+	if value, ok := ruo.mutation.PatchPolicy(); ok {
+		_spec.SetField(resource.FieldPatchPolicy, field.TypeJSON, value)
+	}
+	if ruo.mutation.PatchPolicyCleared() {
+		_spec.ClearField(resource.FieldPatchPolicy, field.TypeJSON)
+	}
+	if value, ok := ruo.mutation.RetentionPolicy(); ok {
+		_spec.SetField(resource.FieldRetentionPolicy, field.TypeJSON, value)
+	}
+	if ruo.mutation.RetentionPolicyCleared() {
+		_spec.ClearField(resource.FieldRetentionPolicy, field.TypeJSON)
+	}
+	if value, ok := ruo.mutation.SupportPolicy(); ok {
+		_spec.SetField(resource.FieldSupportPolicy, field.TypeJSON, value)
+	}
+	if ruo.mutation.SupportPolicyCleared() {
+		_spec.ClearField(resource.FieldSupportPolicy, field.TypeJSON)
+	}
 	if ruo.mutation.VersionsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -485,6 +846,51 @@ func (ruo *ResourceUpdateOne) sqlSave(ctx context.Context) (_node *Resource, err
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if ruo.mutation.ChannelsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.RemovedChannelsIDs(); len(nodes) > 0 && !ruo.mutation.ChannelsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.ChannelsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   resource.ChannelsTable,
+			Columns: []string{resource.ChannelsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(channel.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Resource{config: ruo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
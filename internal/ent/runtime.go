@@ -5,6 +5,7 @@ package ent
 import (
 	"time"
 
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/schema"
 	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
@@ -15,6 +16,16 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	channelFields := schema.Channel{}.Fields()
+	_ = channelFields
+	// channelDescName is the schema descriptor for name field.
+	channelDescName := channelFields[0].Descriptor()
+	// channel.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	channel.NameValidator = channelDescName.Validators[0].(func(string) error)
+	// channelDescCreatedAt is the schema descriptor for created_at field.
+	channelDescCreatedAt := channelFields[2].Descriptor()
+	// channel.DefaultCreatedAt holds the default value on creation for the created_at field.
+	channel.DefaultCreatedAt = channelDescCreatedAt.Default.(func() time.Time)
 	resourceFields := schema.Resource{}.Fields()
 	_ = resourceFields
 	// resourceDescName is the schema descriptor for name field.
@@ -29,6 +40,10 @@ func init() {
 	resourceDescUpdateType := resourceFields[4].Descriptor()
 	// resource.DefaultUpdateType holds the default value on creation for the update_type field.
 	resource.DefaultUpdateType = resourceDescUpdateType.Default.(string)
+	// resourceDescVersionScheme is the schema descriptor for version_scheme field.
+	resourceDescVersionScheme := resourceFields[5].Descriptor()
+	// resource.DefaultVersionScheme holds the default value on creation for the version_scheme field.
+	resource.DefaultVersionScheme = resourceDescVersionScheme.Default.(string)
 	// resourceDescID is the schema descriptor for id field.
 	resourceDescID := resourceFields[0].Descriptor()
 	// resource.IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -47,12 +62,30 @@ func init() {
 	storageDescFileSize := storageFields[6].Descriptor()
 	// storage.DefaultFileSize holds the default value on creation for the file_size field.
 	storage.DefaultFileSize = storageDescFileSize.Default.(int64)
+	// storageDescPatchFormat is the schema descriptor for patch_format field.
+	storageDescPatchFormat := storageFields[8].Descriptor()
+	// storage.DefaultPatchFormat holds the default value on creation for the patch_format field.
+	storage.DefaultPatchFormat = storageDescPatchFormat.Default.(int)
 	// storageDescCreatedAt is the schema descriptor for created_at field.
-	storageDescCreatedAt := storageFields[8].Descriptor()
+	storageDescCreatedAt := storageFields[9].Descriptor()
 	// storage.DefaultCreatedAt holds the default value on creation for the created_at field.
 	storage.DefaultCreatedAt = storageDescCreatedAt.Default.(func() time.Time)
+	// storageDescRolloutPercent is the schema descriptor for rollout_percent field.
+	storageDescRolloutPercent := storageFields[13].Descriptor()
+	// storage.DefaultRolloutPercent holds the default value on creation for the rollout_percent field.
+	storage.DefaultRolloutPercent = storageDescRolloutPercent.Default.(int)
+	// storageDescYankDowngrade is the schema descriptor for yank_downgrade field.
+	storageDescYankDowngrade := storageFields[16].Descriptor()
+	// storage.DefaultYankDowngrade holds the default value on creation for the yank_downgrade field.
+	storage.DefaultYankDowngrade = storageDescYankDowngrade.Default.(bool)
 	versionFields := schema.Version{}.Fields()
 	_ = versionFields
+	// versionDescChannel is the schema descriptor for channel field.
+	versionDescChannel := versionFields[0].Descriptor()
+	// version.DefaultChannel holds the default value on creation for the channel field.
+	version.DefaultChannel = versionDescChannel.Default.(string)
+	// version.ChannelValidator is a validator for the "channel" field. It is called by the builders before save.
+	version.ChannelValidator = versionDescChannel.Validators[0].(func(string) error)
 	// versionDescName is the schema descriptor for name field.
 	versionDescName := versionFields[1].Descriptor()
 	// version.NameValidator is a validator for the "name" field. It is called by the builders before save.
@@ -62,11 +95,15 @@ func init() {
 	// version.DefaultReleaseNote holds the default value on creation for the release_note field.
 	version.DefaultReleaseNote = versionDescReleaseNote.Default.(string)
 	// versionDescCustomData is the schema descriptor for custom_data field.
-	versionDescCustomData := versionFields[4].Descriptor()
+	versionDescCustomData := versionFields[5].Descriptor()
 	// version.DefaultCustomData holds the default value on creation for the custom_data field.
 	version.DefaultCustomData = versionDescCustomData.Default.(string)
 	// versionDescCreatedAt is the schema descriptor for created_at field.
-	versionDescCreatedAt := versionFields[5].Descriptor()
+	versionDescCreatedAt := versionFields[6].Descriptor()
 	// version.DefaultCreatedAt holds the default value on creation for the created_at field.
 	version.DefaultCreatedAt = versionDescCreatedAt.Default.(func() time.Time)
+	// versionDescCritical is the schema descriptor for critical field.
+	versionDescCritical := versionFields[8].Descriptor()
+	// version.DefaultCritical holds the default value on creation for the critical field.
+	version.DefaultCritical = versionDescCritical.Default.(bool)
 }
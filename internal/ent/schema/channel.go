@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Channel holds the schema definition for the Channel entity.
+type Channel struct {
+	ent.Schema
+}
+
+// Fields of the Channel.
+func (Channel) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.JSON("includes", []string{}).
+			Optional().
+			Comment("channels whose versions subscribers also see"),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the Channel.
+func (Channel) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("resource", Resource.Type).
+			Ref("channels").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Channel.
+func (Channel) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Edges("resource").
+			Unique(),
+	}
+}
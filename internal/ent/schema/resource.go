@@ -28,6 +28,21 @@ func (Resource) Fields() []ent.Field {
 			Default(time.Now),
 		field.String("update_type").
 			Default(types.UpdateIncremental.String()),
+		field.String("version_scheme").
+			Default(types.VersionSchemeAuto.String()).
+			Comment("semver, loose_semver, calver, pep440, datetime or integer, empty tries the default parsers"),
+		field.JSON("distribution_policy", types.DistributionPolicy{}).
+			Optional().
+			Comment("ordered distributors used for downloads, empty means weighted split"),
+		field.JSON("patch_policy", types.PatchPolicy{}).
+			Optional().
+			Comment("how incremental packages are served, empty means defaults"),
+		field.JSON("retention_policy", types.RetentionPolicy{}).
+			Optional().
+			Comment("which full packages survive the purge job, empty keeps the two newest"),
+		field.JSON("support_policy", types.SupportPolicy{}).
+			Optional().
+			Comment("minimum supported version per channel, older clients are forced to update"),
 	}
 }
 
@@ -35,5 +50,6 @@ func (Resource) Fields() []ent.Field {
 func (Resource) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("versions", Version.Type),
+		edge.To("channels", Channel.Type),
 	}
 }
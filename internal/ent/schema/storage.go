@@ -40,8 +40,35 @@ func (Storage) Fields() []ent.Field {
 		field.JSON("file_hashes", map[string]string{}).
 			Optional().
 			Comment("only for full update"),
+		field.Int("patch_format").
+			Default(1).
+			Comment("only for incremental update, changes.json format version"),
 		field.Time("created_at").
 			Default(time.Now),
+		field.Time("broken_at").
+			Optional().
+			Nillable().
+			Comment("set by the reconciler while the package file is missing"),
+		field.Time("last_verified_at").
+			Optional().
+			Nillable().
+			Comment("last time the scrubber found the package matching package_hash_sha256"),
+		field.Time("quarantined_at").
+			Optional().
+			Nillable().
+			Comment("set by the scrubber on a hash mismatch, quarantined storages are not served"),
+		field.Int("rollout_percent").
+			Default(types.RolloutFull).
+			Comment("only for full update, share of clients offered the version as latest"),
+		field.Time("yanked_at").
+			Optional().
+			Nillable().
+			Comment("set when the version is withdrawn, yanked storages are not served as latest version or patch"),
+		field.String("yank_reason").
+			Optional(),
+		field.Bool("yank_downgrade").
+			Default(false).
+			Comment("offer clients on the yanked version the previous release"),
 		field.Int("version_storages"),
 	}
 }
@@ -18,13 +18,10 @@ type Version struct {
 // Fields of the Version.
 func (Version) Fields() []ent.Field {
 	return []ent.Field{
-		field.Enum("channel").
-			Values(
-				types.ChannelStable.String(),
-				types.ChannelBeta.String(),
-				types.ChannelAlpha.String(),
-			).
-			Default(types.ChannelStable.String()),
+		field.String("channel").
+			NotEmpty().
+			Default(types.ChannelStable.String()).
+			Comment("stable, beta, alpha or a channel of the resource"),
 		field.String("name").
 			NotEmpty(),
 		field.Uint64("number"),
@@ -33,7 +30,11 @@ func (Version) Fields() []ent.Field {
 				map[string]string{
 					dialect.MySQL: "longtext",
 				}).
-			Default(""),
+			Default("").
+			Comment("release note of the default locale"),
+		field.JSON("release_notes", types.ReleaseNotes{}).
+			Optional().
+			Comment("release notes of the other locales"),
 		field.String("custom_data").
 			SchemaType(
 				map[string]string{
@@ -42,6 +43,15 @@ func (Version) Fields() []ent.Field {
 			Default(""),
 		field.Time("created_at").
 			Default(time.Now),
+		field.Time("publish_at").
+			Optional().
+			Nillable().
+			Comment("not served as latest version before this time, nil is published"),
+		field.Bool("critical").
+			Default(false).
+			Comment("clients on older versions are forced to update"),
+		field.String("critical_reason").
+			Optional(),
 	}
 }
 
@@ -35,8 +35,24 @@ type Storage struct {
 	FileSize int64 `json:"file_size,omitempty"`
 	// only for full update
 	FileHashes map[string]string `json:"file_hashes,omitempty"`
+	// only for incremental update, changes.json format version
+	PatchFormat int `json:"patch_format,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// set by the reconciler while the package file is missing
+	BrokenAt *time.Time `json:"broken_at,omitempty"`
+	// last time the scrubber found the package matching package_hash_sha256
+	LastVerifiedAt *time.Time `json:"last_verified_at,omitempty"`
+	// set by the scrubber on a hash mismatch, quarantined storages are not served
+	QuarantinedAt *time.Time `json:"quarantined_at,omitempty"`
+	// only for full update, share of clients offered the version as latest
+	RolloutPercent int `json:"rollout_percent,omitempty"`
+	// set when the version is withdrawn, yanked storages are not served as latest version or patch
+	YankedAt *time.Time `json:"yanked_at,omitempty"`
+	// YankReason holds the value of the "yank_reason" field.
+	YankReason string `json:"yank_reason,omitempty"`
+	// offer clients on the yanked version the previous release
+	YankDowngrade bool `json:"yank_downgrade,omitempty"`
 	// VersionStorages holds the value of the "version_storages" field.
 	VersionStorages int `json:"version_storages,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -86,11 +102,13 @@ func (*Storage) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case storage.FieldFileHashes:
 			values[i] = new([]byte)
-		case storage.FieldID, storage.FieldFileSize, storage.FieldVersionStorages:
+		case storage.FieldYankDowngrade:
+			values[i] = new(sql.NullBool)
+		case storage.FieldID, storage.FieldFileSize, storage.FieldPatchFormat, storage.FieldRolloutPercent, storage.FieldVersionStorages:
 			values[i] = new(sql.NullInt64)
-		case storage.FieldUpdateType, storage.FieldOs, storage.FieldArch, storage.FieldPackagePath, storage.FieldPackageHashSha256, storage.FieldFileType:
+		case storage.FieldUpdateType, storage.FieldOs, storage.FieldArch, storage.FieldPackagePath, storage.FieldPackageHashSha256, storage.FieldFileType, storage.FieldYankReason:
 			values[i] = new(sql.NullString)
-		case storage.FieldCreatedAt:
+		case storage.FieldCreatedAt, storage.FieldBrokenAt, storage.FieldLastVerifiedAt, storage.FieldQuarantinedAt, storage.FieldYankedAt:
 			values[i] = new(sql.NullTime)
 		case storage.ForeignKeys[0]: // storage_old_version
 			values[i] = new(sql.NullInt64)
@@ -165,12 +183,64 @@ func (s *Storage) assignValues(columns []string, values []any) error {
 					return fmt.Errorf("unmarshal field file_hashes: %w", err)
 				}
 			}
+		case storage.FieldPatchFormat:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field patch_format", values[i])
+			} else if value.Valid {
+				s.PatchFormat = int(value.Int64)
+			}
 		case storage.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
 			} else if value.Valid {
 				s.CreatedAt = value.Time
 			}
+		case storage.FieldBrokenAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field broken_at", values[i])
+			} else if value.Valid {
+				s.BrokenAt = new(time.Time)
+				*s.BrokenAt = value.Time
+			}
+		case storage.FieldLastVerifiedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_verified_at", values[i])
+			} else if value.Valid {
+				s.LastVerifiedAt = new(time.Time)
+				*s.LastVerifiedAt = value.Time
+			}
+		case storage.FieldQuarantinedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field quarantined_at", values[i])
+			} else if value.Valid {
+				s.QuarantinedAt = new(time.Time)
+				*s.QuarantinedAt = value.Time
+			}
+		case storage.FieldRolloutPercent:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rollout_percent", values[i])
+			} else if value.Valid {
+				s.RolloutPercent = int(value.Int64)
+			}
+		case storage.FieldYankedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field yanked_at", values[i])
+			} else if value.Valid {
+				s.YankedAt = new(time.Time)
+				*s.YankedAt = value.Time
+			}
+		case storage.FieldYankReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field yank_reason", values[i])
+			} else if value.Valid {
+				s.YankReason = value.String
+			}
+		case storage.FieldYankDowngrade:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field yank_downgrade", values[i])
+			} else if value.Valid {
+				s.YankDowngrade = value.Bool
+			}
 		case storage.FieldVersionStorages:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field version_storages", values[i])
@@ -254,9 +324,41 @@ func (s *Storage) String() string {
 	builder.WriteString("file_hashes=")
 	builder.WriteString(fmt.Sprintf("%v", s.FileHashes))
 	builder.WriteString(", ")
+	builder.WriteString("patch_format=")
+	builder.WriteString(fmt.Sprintf("%v", s.PatchFormat))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(s.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
+	if v := s.BrokenAt; v != nil {
+		builder.WriteString("broken_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := s.LastVerifiedAt; v != nil {
+		builder.WriteString("last_verified_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := s.QuarantinedAt; v != nil {
+		builder.WriteString("quarantined_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("rollout_percent=")
+	builder.WriteString(fmt.Sprintf("%v", s.RolloutPercent))
+	builder.WriteString(", ")
+	if v := s.YankedAt; v != nil {
+		builder.WriteString("yanked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("yank_reason=")
+	builder.WriteString(s.YankReason)
+	builder.WriteString(", ")
+	builder.WriteString("yank_downgrade=")
+	builder.WriteString(fmt.Sprintf("%v", s.YankDowngrade))
+	builder.WriteString(", ")
 	builder.WriteString("version_storages=")
 	builder.WriteString(fmt.Sprintf("%v", s.VersionStorages))
 	builder.WriteByte(')')
@@ -31,8 +31,24 @@ const (
 	FieldFileSize = "file_size"
 	// FieldFileHashes holds the string denoting the file_hashes field in the database.
 	FieldFileHashes = "file_hashes"
+	// FieldPatchFormat holds the string denoting the patch_format field in the database.
+	FieldPatchFormat = "patch_format"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldBrokenAt holds the string denoting the broken_at field in the database.
+	FieldBrokenAt = "broken_at"
+	// FieldLastVerifiedAt holds the string denoting the last_verified_at field in the database.
+	FieldLastVerifiedAt = "last_verified_at"
+	// FieldQuarantinedAt holds the string denoting the quarantined_at field in the database.
+	FieldQuarantinedAt = "quarantined_at"
+	// FieldRolloutPercent holds the string denoting the rollout_percent field in the database.
+	FieldRolloutPercent = "rollout_percent"
+	// FieldYankedAt holds the string denoting the yanked_at field in the database.
+	FieldYankedAt = "yanked_at"
+	// FieldYankReason holds the string denoting the yank_reason field in the database.
+	FieldYankReason = "yank_reason"
+	// FieldYankDowngrade holds the string denoting the yank_downgrade field in the database.
+	FieldYankDowngrade = "yank_downgrade"
 	// FieldVersionStorages holds the string denoting the version_storages field in the database.
 	FieldVersionStorages = "version_storages"
 	// EdgeVersion holds the string denoting the version edge name in mutations.
@@ -68,7 +84,15 @@ var Columns = []string{
 	FieldFileType,
 	FieldFileSize,
 	FieldFileHashes,
+	FieldPatchFormat,
 	FieldCreatedAt,
+	FieldBrokenAt,
+	FieldLastVerifiedAt,
+	FieldQuarantinedAt,
+	FieldRolloutPercent,
+	FieldYankedAt,
+	FieldYankReason,
+	FieldYankDowngrade,
 	FieldVersionStorages,
 }
 
@@ -100,8 +124,14 @@ var (
 	DefaultArch string
 	// DefaultFileSize holds the default value on creation for the "file_size" field.
 	DefaultFileSize int64
+	// DefaultPatchFormat holds the default value on creation for the "patch_format" field.
+	DefaultPatchFormat int
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
+	// DefaultRolloutPercent holds the default value on creation for the "rollout_percent" field.
+	DefaultRolloutPercent int
+	// DefaultYankDowngrade holds the default value on creation for the "yank_downgrade" field.
+	DefaultYankDowngrade bool
 )
 
 // UpdateType defines the type for the "update_type" enum field.
@@ -170,11 +200,51 @@ func ByFileSize(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldFileSize, opts...).ToFunc()
 }
 
+// ByPatchFormat orders the results by the patch_format field.
+func ByPatchFormat(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPatchFormat, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByBrokenAt orders the results by the broken_at field.
+func ByBrokenAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBrokenAt, opts...).ToFunc()
+}
+
+// ByLastVerifiedAt orders the results by the last_verified_at field.
+func ByLastVerifiedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastVerifiedAt, opts...).ToFunc()
+}
+
+// ByQuarantinedAt orders the results by the quarantined_at field.
+func ByQuarantinedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQuarantinedAt, opts...).ToFunc()
+}
+
+// ByRolloutPercent orders the results by the rollout_percent field.
+func ByRolloutPercent(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRolloutPercent, opts...).ToFunc()
+}
+
+// ByYankedAt orders the results by the yanked_at field.
+func ByYankedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldYankedAt, opts...).ToFunc()
+}
+
+// ByYankReason orders the results by the yank_reason field.
+func ByYankReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldYankReason, opts...).ToFunc()
+}
+
+// ByYankDowngrade orders the results by the yank_downgrade field.
+func ByYankDowngrade(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldYankDowngrade, opts...).ToFunc()
+}
+
 // ByVersionStorages orders the results by the version_storages field.
 func ByVersionStorages(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldVersionStorages, opts...).ToFunc()
@@ -85,11 +85,51 @@ func FileSize(v int64) predicate.Storage {
 	return predicate.Storage(sql.FieldEQ(FieldFileSize, v))
 }
 
+// PatchFormat applies equality check predicate on the "patch_format" field. It's identical to PatchFormatEQ.
+func PatchFormat(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldPatchFormat, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Storage {
 	return predicate.Storage(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// BrokenAt applies equality check predicate on the "broken_at" field. It's identical to BrokenAtEQ.
+func BrokenAt(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldBrokenAt, v))
+}
+
+// LastVerifiedAt applies equality check predicate on the "last_verified_at" field. It's identical to LastVerifiedAtEQ.
+func LastVerifiedAt(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldLastVerifiedAt, v))
+}
+
+// QuarantinedAt applies equality check predicate on the "quarantined_at" field. It's identical to QuarantinedAtEQ.
+func QuarantinedAt(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldQuarantinedAt, v))
+}
+
+// RolloutPercent applies equality check predicate on the "rollout_percent" field. It's identical to RolloutPercentEQ.
+func RolloutPercent(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldRolloutPercent, v))
+}
+
+// YankedAt applies equality check predicate on the "yanked_at" field. It's identical to YankedAtEQ.
+func YankedAt(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldYankedAt, v))
+}
+
+// YankReason applies equality check predicate on the "yank_reason" field. It's identical to YankReasonEQ.
+func YankReason(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldYankReason, v))
+}
+
+// YankDowngrade applies equality check predicate on the "yank_downgrade" field. It's identical to YankDowngradeEQ.
+func YankDowngrade(v bool) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldYankDowngrade, v))
+}
+
 // VersionStorages applies equality check predicate on the "version_storages" field. It's identical to VersionStoragesEQ.
 func VersionStorages(v int) predicate.Storage {
 	return predicate.Storage(sql.FieldEQ(FieldVersionStorages, v))
@@ -520,6 +560,46 @@ func FileHashesNotNil() predicate.Storage {
 	return predicate.Storage(sql.FieldNotNull(FieldFileHashes))
 }
 
+// PatchFormatEQ applies the EQ predicate on the "patch_format" field.
+func PatchFormatEQ(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldPatchFormat, v))
+}
+
+// PatchFormatNEQ applies the NEQ predicate on the "patch_format" field.
+func PatchFormatNEQ(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldPatchFormat, v))
+}
+
+// PatchFormatIn applies the In predicate on the "patch_format" field.
+func PatchFormatIn(vs ...int) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldPatchFormat, vs...))
+}
+
+// PatchFormatNotIn applies the NotIn predicate on the "patch_format" field.
+func PatchFormatNotIn(vs ...int) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldPatchFormat, vs...))
+}
+
+// PatchFormatGT applies the GT predicate on the "patch_format" field.
+func PatchFormatGT(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldPatchFormat, v))
+}
+
+// PatchFormatGTE applies the GTE predicate on the "patch_format" field.
+func PatchFormatGTE(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldPatchFormat, v))
+}
+
+// PatchFormatLT applies the LT predicate on the "patch_format" field.
+func PatchFormatLT(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldPatchFormat, v))
+}
+
+// PatchFormatLTE applies the LTE predicate on the "patch_format" field.
+func PatchFormatLTE(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldPatchFormat, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Storage {
 	return predicate.Storage(sql.FieldEQ(FieldCreatedAt, v))
@@ -560,6 +640,331 @@ func CreatedAtLTE(v time.Time) predicate.Storage {
 	return predicate.Storage(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// BrokenAtEQ applies the EQ predicate on the "broken_at" field.
+func BrokenAtEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldBrokenAt, v))
+}
+
+// BrokenAtNEQ applies the NEQ predicate on the "broken_at" field.
+func BrokenAtNEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldBrokenAt, v))
+}
+
+// BrokenAtIn applies the In predicate on the "broken_at" field.
+func BrokenAtIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldBrokenAt, vs...))
+}
+
+// BrokenAtNotIn applies the NotIn predicate on the "broken_at" field.
+func BrokenAtNotIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldBrokenAt, vs...))
+}
+
+// BrokenAtGT applies the GT predicate on the "broken_at" field.
+func BrokenAtGT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldBrokenAt, v))
+}
+
+// BrokenAtGTE applies the GTE predicate on the "broken_at" field.
+func BrokenAtGTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldBrokenAt, v))
+}
+
+// BrokenAtLT applies the LT predicate on the "broken_at" field.
+func BrokenAtLT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldBrokenAt, v))
+}
+
+// BrokenAtLTE applies the LTE predicate on the "broken_at" field.
+func BrokenAtLTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldBrokenAt, v))
+}
+
+// BrokenAtIsNil applies the IsNil predicate on the "broken_at" field.
+func BrokenAtIsNil() predicate.Storage {
+	return predicate.Storage(sql.FieldIsNull(FieldBrokenAt))
+}
+
+// BrokenAtNotNil applies the NotNil predicate on the "broken_at" field.
+func BrokenAtNotNil() predicate.Storage {
+	return predicate.Storage(sql.FieldNotNull(FieldBrokenAt))
+}
+
+// LastVerifiedAtEQ applies the EQ predicate on the "last_verified_at" field.
+func LastVerifiedAtEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldLastVerifiedAt, v))
+}
+
+// LastVerifiedAtNEQ applies the NEQ predicate on the "last_verified_at" field.
+func LastVerifiedAtNEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldLastVerifiedAt, v))
+}
+
+// LastVerifiedAtIn applies the In predicate on the "last_verified_at" field.
+func LastVerifiedAtIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldLastVerifiedAt, vs...))
+}
+
+// LastVerifiedAtNotIn applies the NotIn predicate on the "last_verified_at" field.
+func LastVerifiedAtNotIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldLastVerifiedAt, vs...))
+}
+
+// LastVerifiedAtGT applies the GT predicate on the "last_verified_at" field.
+func LastVerifiedAtGT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldLastVerifiedAt, v))
+}
+
+// LastVerifiedAtGTE applies the GTE predicate on the "last_verified_at" field.
+func LastVerifiedAtGTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldLastVerifiedAt, v))
+}
+
+// LastVerifiedAtLT applies the LT predicate on the "last_verified_at" field.
+func LastVerifiedAtLT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldLastVerifiedAt, v))
+}
+
+// LastVerifiedAtLTE applies the LTE predicate on the "last_verified_at" field.
+func LastVerifiedAtLTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldLastVerifiedAt, v))
+}
+
+// LastVerifiedAtIsNil applies the IsNil predicate on the "last_verified_at" field.
+func LastVerifiedAtIsNil() predicate.Storage {
+	return predicate.Storage(sql.FieldIsNull(FieldLastVerifiedAt))
+}
+
+// LastVerifiedAtNotNil applies the NotNil predicate on the "last_verified_at" field.
+func LastVerifiedAtNotNil() predicate.Storage {
+	return predicate.Storage(sql.FieldNotNull(FieldLastVerifiedAt))
+}
+
+// QuarantinedAtEQ applies the EQ predicate on the "quarantined_at" field.
+func QuarantinedAtEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldQuarantinedAt, v))
+}
+
+// QuarantinedAtNEQ applies the NEQ predicate on the "quarantined_at" field.
+func QuarantinedAtNEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldQuarantinedAt, v))
+}
+
+// QuarantinedAtIn applies the In predicate on the "quarantined_at" field.
+func QuarantinedAtIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldQuarantinedAt, vs...))
+}
+
+// QuarantinedAtNotIn applies the NotIn predicate on the "quarantined_at" field.
+func QuarantinedAtNotIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldQuarantinedAt, vs...))
+}
+
+// QuarantinedAtGT applies the GT predicate on the "quarantined_at" field.
+func QuarantinedAtGT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldQuarantinedAt, v))
+}
+
+// QuarantinedAtGTE applies the GTE predicate on the "quarantined_at" field.
+func QuarantinedAtGTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldQuarantinedAt, v))
+}
+
+// QuarantinedAtLT applies the LT predicate on the "quarantined_at" field.
+func QuarantinedAtLT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldQuarantinedAt, v))
+}
+
+// QuarantinedAtLTE applies the LTE predicate on the "quarantined_at" field.
+func QuarantinedAtLTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldQuarantinedAt, v))
+}
+
+// QuarantinedAtIsNil applies the IsNil predicate on the "quarantined_at" field.
+func QuarantinedAtIsNil() predicate.Storage {
+	return predicate.Storage(sql.FieldIsNull(FieldQuarantinedAt))
+}
+
+// QuarantinedAtNotNil applies the NotNil predicate on the "quarantined_at" field.
+func QuarantinedAtNotNil() predicate.Storage {
+	return predicate.Storage(sql.FieldNotNull(FieldQuarantinedAt))
+}
+
+// RolloutPercentEQ applies the EQ predicate on the "rollout_percent" field.
+func RolloutPercentEQ(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldRolloutPercent, v))
+}
+
+// RolloutPercentNEQ applies the NEQ predicate on the "rollout_percent" field.
+func RolloutPercentNEQ(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldRolloutPercent, v))
+}
+
+// RolloutPercentIn applies the In predicate on the "rollout_percent" field.
+func RolloutPercentIn(vs ...int) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldRolloutPercent, vs...))
+}
+
+// RolloutPercentNotIn applies the NotIn predicate on the "rollout_percent" field.
+func RolloutPercentNotIn(vs ...int) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldRolloutPercent, vs...))
+}
+
+// RolloutPercentGT applies the GT predicate on the "rollout_percent" field.
+func RolloutPercentGT(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldRolloutPercent, v))
+}
+
+// RolloutPercentGTE applies the GTE predicate on the "rollout_percent" field.
+func RolloutPercentGTE(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldRolloutPercent, v))
+}
+
+// RolloutPercentLT applies the LT predicate on the "rollout_percent" field.
+func RolloutPercentLT(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldRolloutPercent, v))
+}
+
+// RolloutPercentLTE applies the LTE predicate on the "rollout_percent" field.
+func RolloutPercentLTE(v int) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldRolloutPercent, v))
+}
+
+// YankedAtEQ applies the EQ predicate on the "yanked_at" field.
+func YankedAtEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldYankedAt, v))
+}
+
+// YankedAtNEQ applies the NEQ predicate on the "yanked_at" field.
+func YankedAtNEQ(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldYankedAt, v))
+}
+
+// YankedAtIn applies the In predicate on the "yanked_at" field.
+func YankedAtIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldYankedAt, vs...))
+}
+
+// YankedAtNotIn applies the NotIn predicate on the "yanked_at" field.
+func YankedAtNotIn(vs ...time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldYankedAt, vs...))
+}
+
+// YankedAtGT applies the GT predicate on the "yanked_at" field.
+func YankedAtGT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldYankedAt, v))
+}
+
+// YankedAtGTE applies the GTE predicate on the "yanked_at" field.
+func YankedAtGTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldYankedAt, v))
+}
+
+// YankedAtLT applies the LT predicate on the "yanked_at" field.
+func YankedAtLT(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldYankedAt, v))
+}
+
+// YankedAtLTE applies the LTE predicate on the "yanked_at" field.
+func YankedAtLTE(v time.Time) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldYankedAt, v))
+}
+
+// YankedAtIsNil applies the IsNil predicate on the "yanked_at" field.
+func YankedAtIsNil() predicate.Storage {
+	return predicate.Storage(sql.FieldIsNull(FieldYankedAt))
+}
+
+// YankedAtNotNil applies the NotNil predicate on the "yanked_at" field.
+func YankedAtNotNil() predicate.Storage {
+	return predicate.Storage(sql.FieldNotNull(FieldYankedAt))
+}
+
+// YankReasonEQ applies the EQ predicate on the "yank_reason" field.
+func YankReasonEQ(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldYankReason, v))
+}
+
+// YankReasonNEQ applies the NEQ predicate on the "yank_reason" field.
+func YankReasonNEQ(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldYankReason, v))
+}
+
+// YankReasonIn applies the In predicate on the "yank_reason" field.
+func YankReasonIn(vs ...string) predicate.Storage {
+	return predicate.Storage(sql.FieldIn(FieldYankReason, vs...))
+}
+
+// YankReasonNotIn applies the NotIn predicate on the "yank_reason" field.
+func YankReasonNotIn(vs ...string) predicate.Storage {
+	return predicate.Storage(sql.FieldNotIn(FieldYankReason, vs...))
+}
+
+// YankReasonGT applies the GT predicate on the "yank_reason" field.
+func YankReasonGT(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldGT(FieldYankReason, v))
+}
+
+// YankReasonGTE applies the GTE predicate on the "yank_reason" field.
+func YankReasonGTE(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldGTE(FieldYankReason, v))
+}
+
+// YankReasonLT applies the LT predicate on the "yank_reason" field.
+func YankReasonLT(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldLT(FieldYankReason, v))
+}
+
+// YankReasonLTE applies the LTE predicate on the "yank_reason" field.
+func YankReasonLTE(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldLTE(FieldYankReason, v))
+}
+
+// YankReasonContains applies the Contains predicate on the "yank_reason" field.
+func YankReasonContains(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldContains(FieldYankReason, v))
+}
+
+// YankReasonHasPrefix applies the HasPrefix predicate on the "yank_reason" field.
+func YankReasonHasPrefix(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldHasPrefix(FieldYankReason, v))
+}
+
+// YankReasonHasSuffix applies the HasSuffix predicate on the "yank_reason" field.
+func YankReasonHasSuffix(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldHasSuffix(FieldYankReason, v))
+}
+
+// YankReasonIsNil applies the IsNil predicate on the "yank_reason" field.
+func YankReasonIsNil() predicate.Storage {
+	return predicate.Storage(sql.FieldIsNull(FieldYankReason))
+}
+
+// YankReasonNotNil applies the NotNil predicate on the "yank_reason" field.
+func YankReasonNotNil() predicate.Storage {
+	return predicate.Storage(sql.FieldNotNull(FieldYankReason))
+}
+
+// YankReasonEqualFold applies the EqualFold predicate on the "yank_reason" field.
+func YankReasonEqualFold(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldEqualFold(FieldYankReason, v))
+}
+
+// YankReasonContainsFold applies the ContainsFold predicate on the "yank_reason" field.
+func YankReasonContainsFold(v string) predicate.Storage {
+	return predicate.Storage(sql.FieldContainsFold(FieldYankReason, v))
+}
+
+// YankDowngradeEQ applies the EQ predicate on the "yank_downgrade" field.
+func YankDowngradeEQ(v bool) predicate.Storage {
+	return predicate.Storage(sql.FieldEQ(FieldYankDowngrade, v))
+}
+
+// YankDowngradeNEQ applies the NEQ predicate on the "yank_downgrade" field.
+func YankDowngradeNEQ(v bool) predicate.Storage {
+	return predicate.Storage(sql.FieldNEQ(FieldYankDowngrade, v))
+}
+
 // VersionStoragesEQ applies the EQ predicate on the "version_storages" field.
 func VersionStoragesEQ(v int) predicate.Storage {
 	return predicate.Storage(sql.FieldEQ(FieldVersionStorages, v))
@@ -117,6 +117,20 @@ func (sc *StorageCreate) SetFileHashes(m map[string]string) *StorageCreate {
 	return sc
 }
 
+// SetPatchFormat sets the "patch_format" field.
+func (sc *StorageCreate) SetPatchFormat(i int) *StorageCreate {
+	sc.mutation.SetPatchFormat(i)
+	return sc
+}
+
+// SetNillablePatchFormat sets the "patch_format" field if the given value is not nil.
+func (sc *StorageCreate) SetNillablePatchFormat(i *int) *StorageCreate {
+	if i != nil {
+		sc.SetPatchFormat(*i)
+	}
+	return sc
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (sc *StorageCreate) SetCreatedAt(t time.Time) *StorageCreate {
 	sc.mutation.SetCreatedAt(t)
@@ -131,6 +145,104 @@ func (sc *StorageCreate) SetNillableCreatedAt(t *time.Time) *StorageCreate {
 	return sc
 }
 
+// SetBrokenAt sets the "broken_at" field.
+func (sc *StorageCreate) SetBrokenAt(t time.Time) *StorageCreate {
+	sc.mutation.SetBrokenAt(t)
+	return sc
+}
+
+// SetNillableBrokenAt sets the "broken_at" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableBrokenAt(t *time.Time) *StorageCreate {
+	if t != nil {
+		sc.SetBrokenAt(*t)
+	}
+	return sc
+}
+
+// SetLastVerifiedAt sets the "last_verified_at" field.
+func (sc *StorageCreate) SetLastVerifiedAt(t time.Time) *StorageCreate {
+	sc.mutation.SetLastVerifiedAt(t)
+	return sc
+}
+
+// SetNillableLastVerifiedAt sets the "last_verified_at" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableLastVerifiedAt(t *time.Time) *StorageCreate {
+	if t != nil {
+		sc.SetLastVerifiedAt(*t)
+	}
+	return sc
+}
+
+// SetQuarantinedAt sets the "quarantined_at" field.
+func (sc *StorageCreate) SetQuarantinedAt(t time.Time) *StorageCreate {
+	sc.mutation.SetQuarantinedAt(t)
+	return sc
+}
+
+// SetNillableQuarantinedAt sets the "quarantined_at" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableQuarantinedAt(t *time.Time) *StorageCreate {
+	if t != nil {
+		sc.SetQuarantinedAt(*t)
+	}
+	return sc
+}
+
+// SetRolloutPercent sets the "rollout_percent" field.
+func (sc *StorageCreate) SetRolloutPercent(i int) *StorageCreate {
+	sc.mutation.SetRolloutPercent(i)
+	return sc
+}
+
+// SetNillableRolloutPercent sets the "rollout_percent" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableRolloutPercent(i *int) *StorageCreate {
+	if i != nil {
+		sc.SetRolloutPercent(*i)
+	}
+	return sc
+}
+
+// SetYankedAt sets the "yanked_at" field.
+func (sc *StorageCreate) SetYankedAt(t time.Time) *StorageCreate {
+	sc.mutation.SetYankedAt(t)
+	return sc
+}
+
+// SetNillableYankedAt sets the "yanked_at" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableYankedAt(t *time.Time) *StorageCreate {
+	if t != nil {
+		sc.SetYankedAt(*t)
+	}
+	return sc
+}
+
+// SetYankReason sets the "yank_reason" field.
+func (sc *StorageCreate) SetYankReason(s string) *StorageCreate {
+	sc.mutation.SetYankReason(s)
+	return sc
+}
+
+// SetNillableYankReason sets the "yank_reason" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableYankReason(s *string) *StorageCreate {
+	if s != nil {
+		sc.SetYankReason(*s)
+	}
+	return sc
+}
+
+// SetYankDowngrade sets the "yank_downgrade" field.
+func (sc *StorageCreate) SetYankDowngrade(b bool) *StorageCreate {
+	sc.mutation.SetYankDowngrade(b)
+	return sc
+}
+
+// SetNillableYankDowngrade sets the "yank_downgrade" field if the given value is not nil.
+func (sc *StorageCreate) SetNillableYankDowngrade(b *bool) *StorageCreate {
+	if b != nil {
+		sc.SetYankDowngrade(*b)
+	}
+	return sc
+}
+
 // SetVersionStorages sets the "version_storages" field.
 func (sc *StorageCreate) SetVersionStorages(i int) *StorageCreate {
 	sc.mutation.SetVersionStorages(i)
@@ -214,10 +326,22 @@ func (sc *StorageCreate) defaults() {
 		v := storage.DefaultFileSize
 		sc.mutation.SetFileSize(v)
 	}
+	if _, ok := sc.mutation.PatchFormat(); !ok {
+		v := storage.DefaultPatchFormat
+		sc.mutation.SetPatchFormat(v)
+	}
 	if _, ok := sc.mutation.CreatedAt(); !ok {
 		v := storage.DefaultCreatedAt()
 		sc.mutation.SetCreatedAt(v)
 	}
+	if _, ok := sc.mutation.RolloutPercent(); !ok {
+		v := storage.DefaultRolloutPercent
+		sc.mutation.SetRolloutPercent(v)
+	}
+	if _, ok := sc.mutation.YankDowngrade(); !ok {
+		v := storage.DefaultYankDowngrade
+		sc.mutation.SetYankDowngrade(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -239,9 +363,18 @@ func (sc *StorageCreate) check() error {
 	if _, ok := sc.mutation.FileSize(); !ok {
 		return &ValidationError{Name: "file_size", err: errors.New(`ent: missing required field "Storage.file_size"`)}
 	}
+	if _, ok := sc.mutation.PatchFormat(); !ok {
+		return &ValidationError{Name: "patch_format", err: errors.New(`ent: missing required field "Storage.patch_format"`)}
+	}
 	if _, ok := sc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Storage.created_at"`)}
 	}
+	if _, ok := sc.mutation.RolloutPercent(); !ok {
+		return &ValidationError{Name: "rollout_percent", err: errors.New(`ent: missing required field "Storage.rollout_percent"`)}
+	}
+	if _, ok := sc.mutation.YankDowngrade(); !ok {
+		return &ValidationError{Name: "yank_downgrade", err: errors.New(`ent: missing required field "Storage.yank_downgrade"`)}
+	}
 	if _, ok := sc.mutation.VersionStorages(); !ok {
 		return &ValidationError{Name: "version_storages", err: errors.New(`ent: missing required field "Storage.version_storages"`)}
 	}
@@ -306,10 +439,42 @@ func (sc *StorageCreate) createSpec() (*Storage, *sqlgraph.CreateSpec) {
 		_spec.SetField(storage.FieldFileHashes, field.TypeJSON, value)
 		_node.FileHashes = value
 	}
+	if value, ok := sc.mutation.PatchFormat(); ok {
+		_spec.SetField(storage.FieldPatchFormat, field.TypeInt, value)
+		_node.PatchFormat = value
+	}
 	if value, ok := sc.mutation.CreatedAt(); ok {
 		_spec.SetField(storage.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := sc.mutation.BrokenAt(); ok {
+		_spec.SetField(storage.FieldBrokenAt, field.TypeTime, value)
+		_node.BrokenAt = &value
+	}
+	if value, ok := sc.mutation.LastVerifiedAt(); ok {
+		_spec.SetField(storage.FieldLastVerifiedAt, field.TypeTime, value)
+		_node.LastVerifiedAt = &value
+	}
+	if value, ok := sc.mutation.QuarantinedAt(); ok {
+		_spec.SetField(storage.FieldQuarantinedAt, field.TypeTime, value)
+		_node.QuarantinedAt = &value
+	}
+	if value, ok := sc.mutation.RolloutPercent(); ok {
+		_spec.SetField(storage.FieldRolloutPercent, field.TypeInt, value)
+		_node.RolloutPercent = value
+	}
+	if value, ok := sc.mutation.YankedAt(); ok {
+		_spec.SetField(storage.FieldYankedAt, field.TypeTime, value)
+		_node.YankedAt = &value
+	}
+	if value, ok := sc.mutation.YankReason(); ok {
+		_spec.SetField(storage.FieldYankReason, field.TypeString, value)
+		_node.YankReason = value
+	}
+	if value, ok := sc.mutation.YankDowngrade(); ok {
+		_spec.SetField(storage.FieldYankDowngrade, field.TypeBool, value)
+		_node.YankDowngrade = value
+	}
 	if nodes := sc.mutation.VersionIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -164,6 +164,27 @@ func (su *StorageUpdate) ClearFileHashes() *StorageUpdate {
 	return su
 }
 
+// SetPatchFormat sets the "patch_format" field.
+func (su *StorageUpdate) SetPatchFormat(i int) *StorageUpdate {
+	su.mutation.ResetPatchFormat()
+	su.mutation.SetPatchFormat(i)
+	return su
+}
+
+// SetNillablePatchFormat sets the "patch_format" field if the given value is not nil.
+func (su *StorageUpdate) SetNillablePatchFormat(i *int) *StorageUpdate {
+	if i != nil {
+		su.SetPatchFormat(*i)
+	}
+	return su
+}
+
+// AddPatchFormat adds i to the "patch_format" field.
+func (su *StorageUpdate) AddPatchFormat(i int) *StorageUpdate {
+	su.mutation.AddPatchFormat(i)
+	return su
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (su *StorageUpdate) SetCreatedAt(t time.Time) *StorageUpdate {
 	su.mutation.SetCreatedAt(t)
@@ -178,6 +199,141 @@ func (su *StorageUpdate) SetNillableCreatedAt(t *time.Time) *StorageUpdate {
 	return su
 }
 
+// SetBrokenAt sets the "broken_at" field.
+func (su *StorageUpdate) SetBrokenAt(t time.Time) *StorageUpdate {
+	su.mutation.SetBrokenAt(t)
+	return su
+}
+
+// SetNillableBrokenAt sets the "broken_at" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableBrokenAt(t *time.Time) *StorageUpdate {
+	if t != nil {
+		su.SetBrokenAt(*t)
+	}
+	return su
+}
+
+// ClearBrokenAt clears the value of the "broken_at" field.
+func (su *StorageUpdate) ClearBrokenAt() *StorageUpdate {
+	su.mutation.ClearBrokenAt()
+	return su
+}
+
+// SetLastVerifiedAt sets the "last_verified_at" field.
+func (su *StorageUpdate) SetLastVerifiedAt(t time.Time) *StorageUpdate {
+	su.mutation.SetLastVerifiedAt(t)
+	return su
+}
+
+// SetNillableLastVerifiedAt sets the "last_verified_at" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableLastVerifiedAt(t *time.Time) *StorageUpdate {
+	if t != nil {
+		su.SetLastVerifiedAt(*t)
+	}
+	return su
+}
+
+// ClearLastVerifiedAt clears the value of the "last_verified_at" field.
+func (su *StorageUpdate) ClearLastVerifiedAt() *StorageUpdate {
+	su.mutation.ClearLastVerifiedAt()
+	return su
+}
+
+// SetQuarantinedAt sets the "quarantined_at" field.
+func (su *StorageUpdate) SetQuarantinedAt(t time.Time) *StorageUpdate {
+	su.mutation.SetQuarantinedAt(t)
+	return su
+}
+
+// SetNillableQuarantinedAt sets the "quarantined_at" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableQuarantinedAt(t *time.Time) *StorageUpdate {
+	if t != nil {
+		su.SetQuarantinedAt(*t)
+	}
+	return su
+}
+
+// ClearQuarantinedAt clears the value of the "quarantined_at" field.
+func (su *StorageUpdate) ClearQuarantinedAt() *StorageUpdate {
+	su.mutation.ClearQuarantinedAt()
+	return su
+}
+
+// SetRolloutPercent sets the "rollout_percent" field.
+func (su *StorageUpdate) SetRolloutPercent(i int) *StorageUpdate {
+	su.mutation.ResetRolloutPercent()
+	su.mutation.SetRolloutPercent(i)
+	return su
+}
+
+// SetNillableRolloutPercent sets the "rollout_percent" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableRolloutPercent(i *int) *StorageUpdate {
+	if i != nil {
+		su.SetRolloutPercent(*i)
+	}
+	return su
+}
+
+// AddRolloutPercent adds i to the "rollout_percent" field.
+func (su *StorageUpdate) AddRolloutPercent(i int) *StorageUpdate {
+	su.mutation.AddRolloutPercent(i)
+	return su
+}
+
+// SetYankedAt sets the "yanked_at" field.
+func (su *StorageUpdate) SetYankedAt(t time.Time) *StorageUpdate {
+	su.mutation.SetYankedAt(t)
+	return su
+}
+
+// SetNillableYankedAt sets the "yanked_at" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableYankedAt(t *time.Time) *StorageUpdate {
+	if t != nil {
+		su.SetYankedAt(*t)
+	}
+	return su
+}
+
+// ClearYankedAt clears the value of the "yanked_at" field.
+func (su *StorageUpdate) ClearYankedAt() *StorageUpdate {
+	su.mutation.ClearYankedAt()
+	return su
+}
+
+// SetYankReason sets the "yank_reason" field.
+func (su *StorageUpdate) SetYankReason(s string) *StorageUpdate {
+	su.mutation.SetYankReason(s)
+	return su
+}
+
+// SetNillableYankReason sets the "yank_reason" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableYankReason(s *string) *StorageUpdate {
+	if s != nil {
+		su.SetYankReason(*s)
+	}
+	return su
+}
+
+// ClearYankReason clears the value of the "yank_reason" field.
+func (su *StorageUpdate) ClearYankReason() *StorageUpdate {
+	su.mutation.ClearYankReason()
+	return su
+}
+
+// SetYankDowngrade sets the "yank_downgrade" field.
+func (su *StorageUpdate) SetYankDowngrade(b bool) *StorageUpdate {
+	su.mutation.SetYankDowngrade(b)
+	return su
+}
+
+// SetNillableYankDowngrade sets the "yank_downgrade" field if the given value is not nil.
+func (su *StorageUpdate) SetNillableYankDowngrade(b *bool) *StorageUpdate {
+	if b != nil {
+		su.SetYankDowngrade(*b)
+	}
+	return su
+}
+
 // SetVersionStorages sets the "version_storages" field.
 func (su *StorageUpdate) SetVersionStorages(i int) *StorageUpdate {
 	su.mutation.SetVersionStorages(i)
@@ -330,9 +486,54 @@ func (su *StorageUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if su.mutation.FileHashesCleared() {
 		_spec.ClearField(storage.FieldFileHashes, field.TypeJSON)
 	}
+	if value, ok := su.mutation.PatchFormat(); ok {
+		_spec.SetField(storage.FieldPatchFormat, field.TypeInt, value)
+	}
+	if value, ok := su.mutation.AddedPatchFormat(); ok {
+		_spec.AddField(storage.FieldPatchFormat, field.TypeInt, value)
+	}
 	if value, ok := su.mutation.CreatedAt(); ok {
 		_spec.SetField(storage.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := su.mutation.BrokenAt(); ok {
+		_spec.SetField(storage.FieldBrokenAt, field.TypeTime, value)
+	}
+	if su.mutation.BrokenAtCleared() {
+		_spec.ClearField(storage.FieldBrokenAt, field.TypeTime)
+	}
+	if value, ok := su.mutation.LastVerifiedAt(); ok {
+		_spec.SetField(storage.FieldLastVerifiedAt, field.TypeTime, value)
+	}
+	if su.mutation.LastVerifiedAtCleared() {
+		_spec.ClearField(storage.FieldLastVerifiedAt, field.TypeTime)
+	}
+	if value, ok := su.mutation.QuarantinedAt(); ok {
+		_spec.SetField(storage.FieldQuarantinedAt, field.TypeTime, value)
+	}
+	if su.mutation.QuarantinedAtCleared() {
+		_spec.ClearField(storage.FieldQuarantinedAt, field.TypeTime)
+	}
+	if value, ok := su.mutation.RolloutPercent(); ok {
+		_spec.SetField(storage.FieldRolloutPercent, field.TypeInt, value)
+	}
+	if value, ok := su.mutation.AddedRolloutPercent(); ok {
+		_spec.AddField(storage.FieldRolloutPercent, field.TypeInt, value)
+	}
+	if value, ok := su.mutation.YankedAt(); ok {
+		_spec.SetField(storage.FieldYankedAt, field.TypeTime, value)
+	}
+	if su.mutation.YankedAtCleared() {
+		_spec.ClearField(storage.FieldYankedAt, field.TypeTime)
+	}
+	if value, ok := su.mutation.YankReason(); ok {
+		_spec.SetField(storage.FieldYankReason, field.TypeString, value)
+	}
+	if su.mutation.YankReasonCleared() {
+		_spec.ClearField(storage.FieldYankReason, field.TypeString)
+	}
+	if value, ok := su.mutation.YankDowngrade(); ok {
+		_spec.SetField(storage.FieldYankDowngrade, field.TypeBool, value)
+	}
 	if su.mutation.VersionCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -546,6 +747,27 @@ func (suo *StorageUpdateOne) ClearFileHashes() *StorageUpdateOne {
 	return suo
 }
 
+// SetPatchFormat sets the "patch_format" field.
+func (suo *StorageUpdateOne) SetPatchFormat(i int) *StorageUpdateOne {
+	suo.mutation.ResetPatchFormat()
+	suo.mutation.SetPatchFormat(i)
+	return suo
+}
+
+// SetNillablePatchFormat sets the "patch_format" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillablePatchFormat(i *int) *StorageUpdateOne {
+	if i != nil {
+		suo.SetPatchFormat(*i)
+	}
+	return suo
+}
+
+// AddPatchFormat adds i to the "patch_format" field.
+func (suo *StorageUpdateOne) AddPatchFormat(i int) *StorageUpdateOne {
+	suo.mutation.AddPatchFormat(i)
+	return suo
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (suo *StorageUpdateOne) SetCreatedAt(t time.Time) *StorageUpdateOne {
 	suo.mutation.SetCreatedAt(t)
@@ -560,6 +782,141 @@ func (suo *StorageUpdateOne) SetNillableCreatedAt(t *time.Time) *StorageUpdateOn
 	return suo
 }
 
+// SetBrokenAt sets the "broken_at" field.
+func (suo *StorageUpdateOne) SetBrokenAt(t time.Time) *StorageUpdateOne {
+	suo.mutation.SetBrokenAt(t)
+	return suo
+}
+
+// SetNillableBrokenAt sets the "broken_at" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableBrokenAt(t *time.Time) *StorageUpdateOne {
+	if t != nil {
+		suo.SetBrokenAt(*t)
+	}
+	return suo
+}
+
+// ClearBrokenAt clears the value of the "broken_at" field.
+func (suo *StorageUpdateOne) ClearBrokenAt() *StorageUpdateOne {
+	suo.mutation.ClearBrokenAt()
+	return suo
+}
+
+// SetLastVerifiedAt sets the "last_verified_at" field.
+func (suo *StorageUpdateOne) SetLastVerifiedAt(t time.Time) *StorageUpdateOne {
+	suo.mutation.SetLastVerifiedAt(t)
+	return suo
+}
+
+// SetNillableLastVerifiedAt sets the "last_verified_at" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableLastVerifiedAt(t *time.Time) *StorageUpdateOne {
+	if t != nil {
+		suo.SetLastVerifiedAt(*t)
+	}
+	return suo
+}
+
+// ClearLastVerifiedAt clears the value of the "last_verified_at" field.
+func (suo *StorageUpdateOne) ClearLastVerifiedAt() *StorageUpdateOne {
+	suo.mutation.ClearLastVerifiedAt()
+	return suo
+}
+
+// SetQuarantinedAt sets the "quarantined_at" field.
+func (suo *StorageUpdateOne) SetQuarantinedAt(t time.Time) *StorageUpdateOne {
+	suo.mutation.SetQuarantinedAt(t)
+	return suo
+}
+
+// SetNillableQuarantinedAt sets the "quarantined_at" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableQuarantinedAt(t *time.Time) *StorageUpdateOne {
+	if t != nil {
+		suo.SetQuarantinedAt(*t)
+	}
+	return suo
+}
+
+// ClearQuarantinedAt clears the value of the "quarantined_at" field.
+func (suo *StorageUpdateOne) ClearQuarantinedAt() *StorageUpdateOne {
+	suo.mutation.ClearQuarantinedAt()
+	return suo
+}
+
+// SetRolloutPercent sets the "rollout_percent" field.
+func (suo *StorageUpdateOne) SetRolloutPercent(i int) *StorageUpdateOne {
+	suo.mutation.ResetRolloutPercent()
+	suo.mutation.SetRolloutPercent(i)
+	return suo
+}
+
+// SetNillableRolloutPercent sets the "rollout_percent" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableRolloutPercent(i *int) *StorageUpdateOne {
+	if i != nil {
+		suo.SetRolloutPercent(*i)
+	}
+	return suo
+}
+
+// AddRolloutPercent adds i to the "rollout_percent" field.
+func (suo *StorageUpdateOne) AddRolloutPercent(i int) *StorageUpdateOne {
+	suo.mutation.AddRolloutPercent(i)
+	return suo
+}
+
+// SetYankedAt sets the "yanked_at" field.
+func (suo *StorageUpdateOne) SetYankedAt(t time.Time) *StorageUpdateOne {
+	suo.mutation.SetYankedAt(t)
+	return suo
+}
+
+// SetNillableYankedAt sets the "yanked_at" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableYankedAt(t *time.Time) *StorageUpdateOne {
+	if t != nil {
+		suo.SetYankedAt(*t)
+	}
+	return suo
+}
+
+// ClearYankedAt clears the value of the "yanked_at" field.
+func (suo *StorageUpdateOne) ClearYankedAt() *StorageUpdateOne {
+	suo.mutation.ClearYankedAt()
+	return suo
+}
+
+// SetYankReason sets the "yank_reason" field.
+func (suo *StorageUpdateOne) SetYankReason(s string) *StorageUpdateOne {
+	suo.mutation.SetYankReason(s)
+	return suo
+}
+
+// SetNillableYankReason sets the "yank_reason" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableYankReason(s *string) *StorageUpdateOne {
+	if s != nil {
+		suo.SetYankReason(*s)
+	}
+	return suo
+}
+
+// ClearYankReason clears the value of the "yank_reason" field.
+func (suo *StorageUpdateOne) ClearYankReason() *StorageUpdateOne {
+	suo.mutation.ClearYankReason()
+	return suo
+}
+
+// SetYankDowngrade sets the "yank_downgrade" field.
+func (suo *StorageUpdateOne) SetYankDowngrade(b bool) *StorageUpdateOne {
+	suo.mutation.SetYankDowngrade(b)
+	return suo
+}
+
+// SetNillableYankDowngrade sets the "yank_downgrade" field if the given value is not nil.
+func (suo *StorageUpdateOne) SetNillableYankDowngrade(b *bool) *StorageUpdateOne {
+	if b != nil {
+		suo.SetYankDowngrade(*b)
+	}
+	return suo
+}
+
 // SetVersionStorages sets the "version_storages" field.
 func (suo *StorageUpdateOne) SetVersionStorages(i int) *StorageUpdateOne {
 	suo.mutation.SetVersionStorages(i)
@@ -742,9 +1099,54 @@ func (suo *StorageUpdateOne) sqlSave(ctx context.Context) (_node *Storage, err e
 	if suo.mutation.FileHashesCleared() {
 		_spec.ClearField(storage.FieldFileHashes, field.TypeJSON)
 	}
+	if value, ok := suo.mutation.PatchFormat(); ok {
+		_spec.SetField(storage.FieldPatchFormat, field.TypeInt, value)
+	}
+	if value, ok := suo.mutation.AddedPatchFormat(); ok {
+		_spec.AddField(storage.FieldPatchFormat, field.TypeInt, value)
+	}
 	if value, ok := suo.mutation.CreatedAt(); ok {
 		_spec.SetField(storage.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := suo.mutation.BrokenAt(); ok {
+		_spec.SetField(storage.FieldBrokenAt, field.TypeTime, value)
+	}
+	if suo.mutation.BrokenAtCleared() {
+		_spec.ClearField(storage.FieldBrokenAt, field.TypeTime)
+	}
+	if value, ok := suo.mutation.LastVerifiedAt(); ok {
+		_spec.SetField(storage.FieldLastVerifiedAt, field.TypeTime, value)
+	}
+	if suo.mutation.LastVerifiedAtCleared() {
+		_spec.ClearField(storage.FieldLastVerifiedAt, field.TypeTime)
+	}
+	if value, ok := suo.mutation.QuarantinedAt(); ok {
+		_spec.SetField(storage.FieldQuarantinedAt, field.TypeTime, value)
+	}
+	if suo.mutation.QuarantinedAtCleared() {
+		_spec.ClearField(storage.FieldQuarantinedAt, field.TypeTime)
+	}
+	if value, ok := suo.mutation.RolloutPercent(); ok {
+		_spec.SetField(storage.FieldRolloutPercent, field.TypeInt, value)
+	}
+	if value, ok := suo.mutation.AddedRolloutPercent(); ok {
+		_spec.AddField(storage.FieldRolloutPercent, field.TypeInt, value)
+	}
+	if value, ok := suo.mutation.YankedAt(); ok {
+		_spec.SetField(storage.FieldYankedAt, field.TypeTime, value)
+	}
+	if suo.mutation.YankedAtCleared() {
+		_spec.ClearField(storage.FieldYankedAt, field.TypeTime)
+	}
+	if value, ok := suo.mutation.YankReason(); ok {
+		_spec.SetField(storage.FieldYankReason, field.TypeString, value)
+	}
+	if suo.mutation.YankReasonCleared() {
+		_spec.ClearField(storage.FieldYankReason, field.TypeString)
+	}
+	if value, ok := suo.mutation.YankDowngrade(); ok {
+		_spec.SetField(storage.FieldYankDowngrade, field.TypeBool, value)
+	}
 	if suo.mutation.VersionCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 // Version is the model entity for the Version schema.
@@ -18,18 +20,26 @@ type Version struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
-	// Channel holds the value of the "channel" field.
-	Channel version.Channel `json:"channel,omitempty"`
+	// stable, beta, alpha or a channel of the resource
+	Channel string `json:"channel,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
 	// Number holds the value of the "number" field.
 	Number uint64 `json:"number,omitempty"`
-	// ReleaseNote holds the value of the "release_note" field.
+	// release note of the default locale
 	ReleaseNote string `json:"release_note,omitempty"`
+	// release notes of the other locales
+	ReleaseNotes types.ReleaseNotes `json:"release_notes,omitempty"`
 	// CustomData holds the value of the "custom_data" field.
 	CustomData string `json:"custom_data,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// not served as latest version before this time, nil is published
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// clients on older versions are forced to update
+	Critical bool `json:"critical,omitempty"`
+	// CriticalReason holds the value of the "critical_reason" field.
+	CriticalReason string `json:"critical_reason,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the VersionQuery when eager-loading is set.
 	Edges             VersionEdges `json:"edges"`
@@ -73,11 +83,15 @@ func (*Version) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case version.FieldReleaseNotes:
+			values[i] = new([]byte)
+		case version.FieldCritical:
+			values[i] = new(sql.NullBool)
 		case version.FieldID, version.FieldNumber:
 			values[i] = new(sql.NullInt64)
-		case version.FieldChannel, version.FieldName, version.FieldReleaseNote, version.FieldCustomData:
+		case version.FieldChannel, version.FieldName, version.FieldReleaseNote, version.FieldCustomData, version.FieldCriticalReason:
 			values[i] = new(sql.NullString)
-		case version.FieldCreatedAt:
+		case version.FieldCreatedAt, version.FieldPublishAt:
 			values[i] = new(sql.NullTime)
 		case version.ForeignKeys[0]: // resource_versions
 			values[i] = new(sql.NullString)
@@ -106,7 +120,7 @@ func (v *Version) assignValues(columns []string, values []any) error {
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field channel", values[i])
 			} else if value.Valid {
-				v.Channel = version.Channel(value.String)
+				v.Channel = value.String
 			}
 		case version.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
@@ -126,6 +140,14 @@ func (v *Version) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				v.ReleaseNote = value.String
 			}
+		case version.FieldReleaseNotes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field release_notes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &v.ReleaseNotes); err != nil {
+					return fmt.Errorf("unmarshal field release_notes: %w", err)
+				}
+			}
 		case version.FieldCustomData:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field custom_data", values[i])
@@ -138,6 +160,25 @@ func (v *Version) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				v.CreatedAt = value.Time
 			}
+		case version.FieldPublishAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field publish_at", values[i])
+			} else if value.Valid {
+				v.PublishAt = new(time.Time)
+				*v.PublishAt = value.Time
+			}
+		case version.FieldCritical:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field critical", values[i])
+			} else if value.Valid {
+				v.Critical = value.Bool
+			}
+		case version.FieldCriticalReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field critical_reason", values[i])
+			} else if value.Valid {
+				v.CriticalReason = value.String
+			}
 		case version.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field resource_versions", values[i])
@@ -192,7 +233,7 @@ func (v *Version) String() string {
 	builder.WriteString("Version(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", v.ID))
 	builder.WriteString("channel=")
-	builder.WriteString(fmt.Sprintf("%v", v.Channel))
+	builder.WriteString(v.Channel)
 	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(v.Name)
@@ -203,11 +244,25 @@ func (v *Version) String() string {
 	builder.WriteString("release_note=")
 	builder.WriteString(v.ReleaseNote)
 	builder.WriteString(", ")
+	builder.WriteString("release_notes=")
+	builder.WriteString(fmt.Sprintf("%v", v.ReleaseNotes))
+	builder.WriteString(", ")
 	builder.WriteString("custom_data=")
 	builder.WriteString(v.CustomData)
 	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(v.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := v.PublishAt; v != nil {
+		builder.WriteString("publish_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("critical=")
+	builder.WriteString(fmt.Sprintf("%v", v.Critical))
+	builder.WriteString(", ")
+	builder.WriteString("critical_reason=")
+	builder.WriteString(v.CriticalReason)
 	builder.WriteByte(')')
 	return builder.String()
 }
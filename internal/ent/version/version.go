@@ -3,7 +3,6 @@
 package version
 
 import (
-	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -23,10 +22,18 @@ const (
 	FieldNumber = "number"
 	// FieldReleaseNote holds the string denoting the release_note field in the database.
 	FieldReleaseNote = "release_note"
+	// FieldReleaseNotes holds the string denoting the release_notes field in the database.
+	FieldReleaseNotes = "release_notes"
 	// FieldCustomData holds the string denoting the custom_data field in the database.
 	FieldCustomData = "custom_data"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldPublishAt holds the string denoting the publish_at field in the database.
+	FieldPublishAt = "publish_at"
+	// FieldCritical holds the string denoting the critical field in the database.
+	FieldCritical = "critical"
+	// FieldCriticalReason holds the string denoting the critical_reason field in the database.
+	FieldCriticalReason = "critical_reason"
 	// EdgeStorages holds the string denoting the storages edge name in mutations.
 	EdgeStorages = "storages"
 	// EdgeResource holds the string denoting the resource edge name in mutations.
@@ -56,8 +63,12 @@ var Columns = []string{
 	FieldName,
 	FieldNumber,
 	FieldReleaseNote,
+	FieldReleaseNotes,
 	FieldCustomData,
 	FieldCreatedAt,
+	FieldPublishAt,
+	FieldCritical,
+	FieldCriticalReason,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "versions"
@@ -82,6 +93,10 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultChannel holds the default value on creation for the "channel" field.
+	DefaultChannel string
+	// ChannelValidator is a validator for the "channel" field. It is called by the builders before save.
+	ChannelValidator func(string) error
 	// NameValidator is a validator for the "name" field. It is called by the builders before save.
 	NameValidator func(string) error
 	// DefaultReleaseNote holds the default value on creation for the "release_note" field.
@@ -90,35 +105,10 @@ var (
 	DefaultCustomData string
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
+	// DefaultCritical holds the default value on creation for the "critical" field.
+	DefaultCritical bool
 )
 
-// Channel defines the type for the "channel" enum field.
-type Channel string
-
-// ChannelStable is the default value of the Channel enum.
-const DefaultChannel = ChannelStable
-
-// Channel values.
-const (
-	ChannelStable Channel = "stable"
-	ChannelBeta   Channel = "beta"
-	ChannelAlpha  Channel = "alpha"
-)
-
-func (c Channel) String() string {
-	return string(c)
-}
-
-// ChannelValidator is a validator for the "channel" field enum values. It is called by the builders before save.
-func ChannelValidator(c Channel) error {
-	switch c {
-	case ChannelStable, ChannelBeta, ChannelAlpha:
-		return nil
-	default:
-		return fmt.Errorf("version: invalid enum value for channel field: %q", c)
-	}
-}
-
 // OrderOption defines the ordering options for the Version queries.
 type OrderOption func(*sql.Selector)
 
@@ -157,6 +147,21 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByPublishAt orders the results by the publish_at field.
+func ByPublishAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPublishAt, opts...).ToFunc()
+}
+
+// ByCritical orders the results by the critical field.
+func ByCritical(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCritical, opts...).ToFunc()
+}
+
+// ByCriticalReason orders the results by the critical_reason field.
+func ByCriticalReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCriticalReason, opts...).ToFunc()
+}
+
 // ByStoragesCount orders the results by storages count.
 func ByStoragesCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -55,6 +55,11 @@ func IDLTE(id int) predicate.Version {
 	return predicate.Version(sql.FieldLTE(FieldID, id))
 }
 
+// Channel applies equality check predicate on the "channel" field. It's identical to ChannelEQ.
+func Channel(v string) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldChannel, v))
+}
+
 // Name applies equality check predicate on the "name" field. It's identical to NameEQ.
 func Name(v string) predicate.Version {
 	return predicate.Version(sql.FieldEQ(FieldName, v))
@@ -80,26 +85,86 @@ func CreatedAt(v time.Time) predicate.Version {
 	return predicate.Version(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// PublishAt applies equality check predicate on the "publish_at" field. It's identical to PublishAtEQ.
+func PublishAt(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldPublishAt, v))
+}
+
+// Critical applies equality check predicate on the "critical" field. It's identical to CriticalEQ.
+func Critical(v bool) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldCritical, v))
+}
+
+// CriticalReason applies equality check predicate on the "critical_reason" field. It's identical to CriticalReasonEQ.
+func CriticalReason(v string) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldCriticalReason, v))
+}
+
 // ChannelEQ applies the EQ predicate on the "channel" field.
-func ChannelEQ(v Channel) predicate.Version {
+func ChannelEQ(v string) predicate.Version {
 	return predicate.Version(sql.FieldEQ(FieldChannel, v))
 }
 
 // ChannelNEQ applies the NEQ predicate on the "channel" field.
-func ChannelNEQ(v Channel) predicate.Version {
+func ChannelNEQ(v string) predicate.Version {
 	return predicate.Version(sql.FieldNEQ(FieldChannel, v))
 }
 
 // ChannelIn applies the In predicate on the "channel" field.
-func ChannelIn(vs ...Channel) predicate.Version {
+func ChannelIn(vs ...string) predicate.Version {
 	return predicate.Version(sql.FieldIn(FieldChannel, vs...))
 }
 
 // ChannelNotIn applies the NotIn predicate on the "channel" field.
-func ChannelNotIn(vs ...Channel) predicate.Version {
+func ChannelNotIn(vs ...string) predicate.Version {
 	return predicate.Version(sql.FieldNotIn(FieldChannel, vs...))
 }
 
+// ChannelGT applies the GT predicate on the "channel" field.
+func ChannelGT(v string) predicate.Version {
+	return predicate.Version(sql.FieldGT(FieldChannel, v))
+}
+
+// ChannelGTE applies the GTE predicate on the "channel" field.
+func ChannelGTE(v string) predicate.Version {
+	return predicate.Version(sql.FieldGTE(FieldChannel, v))
+}
+
+// ChannelLT applies the LT predicate on the "channel" field.
+func ChannelLT(v string) predicate.Version {
+	return predicate.Version(sql.FieldLT(FieldChannel, v))
+}
+
+// ChannelLTE applies the LTE predicate on the "channel" field.
+func ChannelLTE(v string) predicate.Version {
+	return predicate.Version(sql.FieldLTE(FieldChannel, v))
+}
+
+// ChannelContains applies the Contains predicate on the "channel" field.
+func ChannelContains(v string) predicate.Version {
+	return predicate.Version(sql.FieldContains(FieldChannel, v))
+}
+
+// ChannelHasPrefix applies the HasPrefix predicate on the "channel" field.
+func ChannelHasPrefix(v string) predicate.Version {
+	return predicate.Version(sql.FieldHasPrefix(FieldChannel, v))
+}
+
+// ChannelHasSuffix applies the HasSuffix predicate on the "channel" field.
+func ChannelHasSuffix(v string) predicate.Version {
+	return predicate.Version(sql.FieldHasSuffix(FieldChannel, v))
+}
+
+// ChannelEqualFold applies the EqualFold predicate on the "channel" field.
+func ChannelEqualFold(v string) predicate.Version {
+	return predicate.Version(sql.FieldEqualFold(FieldChannel, v))
+}
+
+// ChannelContainsFold applies the ContainsFold predicate on the "channel" field.
+func ChannelContainsFold(v string) predicate.Version {
+	return predicate.Version(sql.FieldContainsFold(FieldChannel, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Version {
 	return predicate.Version(sql.FieldEQ(FieldName, v))
@@ -270,6 +335,16 @@ func ReleaseNoteContainsFold(v string) predicate.Version {
 	return predicate.Version(sql.FieldContainsFold(FieldReleaseNote, v))
 }
 
+// ReleaseNotesIsNil applies the IsNil predicate on the "release_notes" field.
+func ReleaseNotesIsNil() predicate.Version {
+	return predicate.Version(sql.FieldIsNull(FieldReleaseNotes))
+}
+
+// ReleaseNotesNotNil applies the NotNil predicate on the "release_notes" field.
+func ReleaseNotesNotNil() predicate.Version {
+	return predicate.Version(sql.FieldNotNull(FieldReleaseNotes))
+}
+
 // CustomDataEQ applies the EQ predicate on the "custom_data" field.
 func CustomDataEQ(v string) predicate.Version {
 	return predicate.Version(sql.FieldEQ(FieldCustomData, v))
@@ -375,6 +450,141 @@ func CreatedAtLTE(v time.Time) predicate.Version {
 	return predicate.Version(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// PublishAtEQ applies the EQ predicate on the "publish_at" field.
+func PublishAtEQ(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldPublishAt, v))
+}
+
+// PublishAtNEQ applies the NEQ predicate on the "publish_at" field.
+func PublishAtNEQ(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldNEQ(FieldPublishAt, v))
+}
+
+// PublishAtIn applies the In predicate on the "publish_at" field.
+func PublishAtIn(vs ...time.Time) predicate.Version {
+	return predicate.Version(sql.FieldIn(FieldPublishAt, vs...))
+}
+
+// PublishAtNotIn applies the NotIn predicate on the "publish_at" field.
+func PublishAtNotIn(vs ...time.Time) predicate.Version {
+	return predicate.Version(sql.FieldNotIn(FieldPublishAt, vs...))
+}
+
+// PublishAtGT applies the GT predicate on the "publish_at" field.
+func PublishAtGT(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldGT(FieldPublishAt, v))
+}
+
+// PublishAtGTE applies the GTE predicate on the "publish_at" field.
+func PublishAtGTE(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldGTE(FieldPublishAt, v))
+}
+
+// PublishAtLT applies the LT predicate on the "publish_at" field.
+func PublishAtLT(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldLT(FieldPublishAt, v))
+}
+
+// PublishAtLTE applies the LTE predicate on the "publish_at" field.
+func PublishAtLTE(v time.Time) predicate.Version {
+	return predicate.Version(sql.FieldLTE(FieldPublishAt, v))
+}
+
+// PublishAtIsNil applies the IsNil predicate on the "publish_at" field.
+func PublishAtIsNil() predicate.Version {
+	return predicate.Version(sql.FieldIsNull(FieldPublishAt))
+}
+
+// PublishAtNotNil applies the NotNil predicate on the "publish_at" field.
+func PublishAtNotNil() predicate.Version {
+	return predicate.Version(sql.FieldNotNull(FieldPublishAt))
+}
+
+// CriticalEQ applies the EQ predicate on the "critical" field.
+func CriticalEQ(v bool) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldCritical, v))
+}
+
+// CriticalNEQ applies the NEQ predicate on the "critical" field.
+func CriticalNEQ(v bool) predicate.Version {
+	return predicate.Version(sql.FieldNEQ(FieldCritical, v))
+}
+
+// CriticalReasonEQ applies the EQ predicate on the "critical_reason" field.
+func CriticalReasonEQ(v string) predicate.Version {
+	return predicate.Version(sql.FieldEQ(FieldCriticalReason, v))
+}
+
+// CriticalReasonNEQ applies the NEQ predicate on the "critical_reason" field.
+func CriticalReasonNEQ(v string) predicate.Version {
+	return predicate.Version(sql.FieldNEQ(FieldCriticalReason, v))
+}
+
+// CriticalReasonIn applies the In predicate on the "critical_reason" field.
+func CriticalReasonIn(vs ...string) predicate.Version {
+	return predicate.Version(sql.FieldIn(FieldCriticalReason, vs...))
+}
+
+// CriticalReasonNotIn applies the NotIn predicate on the "critical_reason" field.
+func CriticalReasonNotIn(vs ...string) predicate.Version {
+	return predicate.Version(sql.FieldNotIn(FieldCriticalReason, vs...))
+}
+
+// CriticalReasonGT applies the GT predicate on the "critical_reason" field.
+func CriticalReasonGT(v string) predicate.Version {
+	return predicate.Version(sql.FieldGT(FieldCriticalReason, v))
+}
+
+// CriticalReasonGTE applies the GTE predicate on the "critical_reason" field.
+func CriticalReasonGTE(v string) predicate.Version {
+	return predicate.Version(sql.FieldGTE(FieldCriticalReason, v))
+}
+
+// CriticalReasonLT applies the LT predicate on the "critical_reason" field.
+func CriticalReasonLT(v string) predicate.Version {
+	return predicate.Version(sql.FieldLT(FieldCriticalReason, v))
+}
+
+// CriticalReasonLTE applies the LTE predicate on the "critical_reason" field.
+func CriticalReasonLTE(v string) predicate.Version {
+	return predicate.Version(sql.FieldLTE(FieldCriticalReason, v))
+}
+
+// CriticalReasonContains applies the Contains predicate on the "critical_reason" field.
+func CriticalReasonContains(v string) predicate.Version {
+	return predicate.Version(sql.FieldContains(FieldCriticalReason, v))
+}
+
+// CriticalReasonHasPrefix applies the HasPrefix predicate on the "critical_reason" field.
+func CriticalReasonHasPrefix(v string) predicate.Version {
+	return predicate.Version(sql.FieldHasPrefix(FieldCriticalReason, v))
+}
+
+// CriticalReasonHasSuffix applies the HasSuffix predicate on the "critical_reason" field.
+func CriticalReasonHasSuffix(v string) predicate.Version {
+	return predicate.Version(sql.FieldHasSuffix(FieldCriticalReason, v))
+}
+
+// CriticalReasonIsNil applies the IsNil predicate on the "critical_reason" field.
+func CriticalReasonIsNil() predicate.Version {
+	return predicate.Version(sql.FieldIsNull(FieldCriticalReason))
+}
+
+// CriticalReasonNotNil applies the NotNil predicate on the "critical_reason" field.
+func CriticalReasonNotNil() predicate.Version {
+	return predicate.Version(sql.FieldNotNull(FieldCriticalReason))
+}
+
+// CriticalReasonEqualFold applies the EqualFold predicate on the "critical_reason" field.
+func CriticalReasonEqualFold(v string) predicate.Version {
+	return predicate.Version(sql.FieldEqualFold(FieldCriticalReason, v))
+}
+
+// CriticalReasonContainsFold applies the ContainsFold predicate on the "critical_reason" field.
+func CriticalReasonContainsFold(v string) predicate.Version {
+	return predicate.Version(sql.FieldContainsFold(FieldCriticalReason, v))
+}
+
 // HasStorages applies the HasEdge predicate on the "storages" edge.
 func HasStorages() predicate.Version {
 	return predicate.Version(func(s *sql.Selector) {
@@ -13,6 +13,7 @@ import (
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 // VersionCreate is the builder for creating a Version entity.
@@ -23,15 +24,15 @@ type VersionCreate struct {
 }
 
 // SetChannel sets the "channel" field.
-func (vc *VersionCreate) SetChannel(v version.Channel) *VersionCreate {
-	vc.mutation.SetChannel(v)
+func (vc *VersionCreate) SetChannel(s string) *VersionCreate {
+	vc.mutation.SetChannel(s)
 	return vc
 }
 
 // SetNillableChannel sets the "channel" field if the given value is not nil.
-func (vc *VersionCreate) SetNillableChannel(v *version.Channel) *VersionCreate {
-	if v != nil {
-		vc.SetChannel(*v)
+func (vc *VersionCreate) SetNillableChannel(s *string) *VersionCreate {
+	if s != nil {
+		vc.SetChannel(*s)
 	}
 	return vc
 }
@@ -62,6 +63,12 @@ func (vc *VersionCreate) SetNillableReleaseNote(s *string) *VersionCreate {
 	return vc
 }
 
+// SetReleaseNotes sets the "release_notes" field.
+func (vc *VersionCreate) SetReleaseNotes(tn types.ReleaseNotes) *VersionCreate {
+	vc.mutation.SetReleaseNotes(tn)
+	return vc
+}
+
 // SetCustomData sets the "custom_data" field.
 func (vc *VersionCreate) SetCustomData(s string) *VersionCreate {
 	vc.mutation.SetCustomData(s)
@@ -90,6 +97,48 @@ func (vc *VersionCreate) SetNillableCreatedAt(t *time.Time) *VersionCreate {
 	return vc
 }
 
+// SetPublishAt sets the "publish_at" field.
+func (vc *VersionCreate) SetPublishAt(t time.Time) *VersionCreate {
+	vc.mutation.SetPublishAt(t)
+	return vc
+}
+
+// SetNillablePublishAt sets the "publish_at" field if the given value is not nil.
+func (vc *VersionCreate) SetNillablePublishAt(t *time.Time) *VersionCreate {
+	if t != nil {
+		vc.SetPublishAt(*t)
+	}
+	return vc
+}
+
+// SetCritical sets the "critical" field.
+func (vc *VersionCreate) SetCritical(b bool) *VersionCreate {
+	vc.mutation.SetCritical(b)
+	return vc
+}
+
+// SetNillableCritical sets the "critical" field if the given value is not nil.
+func (vc *VersionCreate) SetNillableCritical(b *bool) *VersionCreate {
+	if b != nil {
+		vc.SetCritical(*b)
+	}
+	return vc
+}
+
+// SetCriticalReason sets the "critical_reason" field.
+func (vc *VersionCreate) SetCriticalReason(s string) *VersionCreate {
+	vc.mutation.SetCriticalReason(s)
+	return vc
+}
+
+// SetNillableCriticalReason sets the "critical_reason" field if the given value is not nil.
+func (vc *VersionCreate) SetNillableCriticalReason(s *string) *VersionCreate {
+	if s != nil {
+		vc.SetCriticalReason(*s)
+	}
+	return vc
+}
+
 // AddStorageIDs adds the "storages" edge to the Storage entity by IDs.
 func (vc *VersionCreate) AddStorageIDs(ids ...int) *VersionCreate {
 	vc.mutation.AddStorageIDs(ids...)
@@ -175,6 +224,10 @@ func (vc *VersionCreate) defaults() {
 		v := version.DefaultCreatedAt()
 		vc.mutation.SetCreatedAt(v)
 	}
+	if _, ok := vc.mutation.Critical(); !ok {
+		v := version.DefaultCritical
+		vc.mutation.SetCritical(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -207,6 +260,9 @@ func (vc *VersionCreate) check() error {
 	if _, ok := vc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Version.created_at"`)}
 	}
+	if _, ok := vc.mutation.Critical(); !ok {
+		return &ValidationError{Name: "critical", err: errors.New(`ent: missing required field "Version.critical"`)}
+	}
 	return nil
 }
 
@@ -234,7 +290,7 @@ func (vc *VersionCreate) createSpec() (*Version, *sqlgraph.CreateSpec) {
 		_spec = sqlgraph.NewCreateSpec(version.Table, sqlgraph.NewFieldSpec(version.FieldID, field.TypeInt))
 	)
 	if value, ok := vc.mutation.Channel(); ok {
-		_spec.SetField(version.FieldChannel, field.TypeEnum, value)
+		_spec.SetField(version.FieldChannel, field.TypeString, value)
 		_node.Channel = value
 	}
 	if value, ok := vc.mutation.Name(); ok {
@@ -249,6 +305,10 @@ func (vc *VersionCreate) createSpec() (*Version, *sqlgraph.CreateSpec) {
 		_spec.SetField(version.FieldReleaseNote, field.TypeString, value)
 		_node.ReleaseNote = value
 	}
+	if value, ok := vc.mutation.ReleaseNotes(); ok {
+		_spec.SetField(version.FieldReleaseNotes, field.TypeJSON, value)
+		_node.ReleaseNotes = value
+	}
 	if value, ok := vc.mutation.CustomData(); ok {
 		_spec.SetField(version.FieldCustomData, field.TypeString, value)
 		_node.CustomData = value
@@ -257,6 +317,18 @@ func (vc *VersionCreate) createSpec() (*Version, *sqlgraph.CreateSpec) {
 		_spec.SetField(version.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := vc.mutation.PublishAt(); ok {
+		_spec.SetField(version.FieldPublishAt, field.TypeTime, value)
+		_node.PublishAt = &value
+	}
+	if value, ok := vc.mutation.Critical(); ok {
+		_spec.SetField(version.FieldCritical, field.TypeBool, value)
+		_node.Critical = value
+	}
+	if value, ok := vc.mutation.CriticalReason(); ok {
+		_spec.SetField(version.FieldCriticalReason, field.TypeString, value)
+		_node.CriticalReason = value
+	}
 	if nodes := vc.mutation.StoragesIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -336,7 +336,7 @@ func (vq *VersionQuery) WithResource(opts ...func(*ResourceQuery)) *VersionQuery
 // Example:
 //
 //	var v []struct {
-//		Channel version.Channel `json:"channel,omitempty"`
+//		Channel string `json:"channel,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
@@ -359,7 +359,7 @@ func (vq *VersionQuery) GroupBy(field string, fields ...string) *VersionGroupBy
 // Example:
 //
 //	var v []struct {
-//		Channel version.Channel `json:"channel,omitempty"`
+//		Channel string `json:"channel,omitempty"`
 //	}
 //
 //	client.Version.Query().
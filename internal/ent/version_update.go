@@ -15,6 +15,7 @@ import (
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 // VersionUpdate is the builder for updating Version entities.
@@ -31,15 +32,15 @@ func (vu *VersionUpdate) Where(ps ...predicate.Version) *VersionUpdate {
 }
 
 // SetChannel sets the "channel" field.
-func (vu *VersionUpdate) SetChannel(v version.Channel) *VersionUpdate {
-	vu.mutation.SetChannel(v)
+func (vu *VersionUpdate) SetChannel(s string) *VersionUpdate {
+	vu.mutation.SetChannel(s)
 	return vu
 }
 
 // SetNillableChannel sets the "channel" field if the given value is not nil.
-func (vu *VersionUpdate) SetNillableChannel(v *version.Channel) *VersionUpdate {
-	if v != nil {
-		vu.SetChannel(*v)
+func (vu *VersionUpdate) SetNillableChannel(s *string) *VersionUpdate {
+	if s != nil {
+		vu.SetChannel(*s)
 	}
 	return vu
 }
@@ -93,6 +94,18 @@ func (vu *VersionUpdate) SetNillableReleaseNote(s *string) *VersionUpdate {
 	return vu
 }
 
+// SetReleaseNotes sets the "release_notes" field.
+func (vu *VersionUpdate) SetReleaseNotes(tn types.ReleaseNotes) *VersionUpdate {
+	vu.mutation.SetReleaseNotes(tn)
+	return vu
+}
+
+// ClearReleaseNotes clears the value of the "release_notes" field.
+func (vu *VersionUpdate) ClearReleaseNotes() *VersionUpdate {
+	vu.mutation.ClearReleaseNotes()
+	return vu
+}
+
 // SetCustomData sets the "custom_data" field.
 func (vu *VersionUpdate) SetCustomData(s string) *VersionUpdate {
 	vu.mutation.SetCustomData(s)
@@ -121,6 +134,60 @@ func (vu *VersionUpdate) SetNillableCreatedAt(t *time.Time) *VersionUpdate {
 	return vu
 }
 
+// SetPublishAt sets the "publish_at" field.
+func (vu *VersionUpdate) SetPublishAt(t time.Time) *VersionUpdate {
+	vu.mutation.SetPublishAt(t)
+	return vu
+}
+
+// SetNillablePublishAt sets the "publish_at" field if the given value is not nil.
+func (vu *VersionUpdate) SetNillablePublishAt(t *time.Time) *VersionUpdate {
+	if t != nil {
+		vu.SetPublishAt(*t)
+	}
+	return vu
+}
+
+// ClearPublishAt clears the value of the "publish_at" field.
+func (vu *VersionUpdate) ClearPublishAt() *VersionUpdate {
+	vu.mutation.ClearPublishAt()
+	return vu
+}
+
+// SetCritical sets the "critical" field.
+func (vu *VersionUpdate) SetCritical(b bool) *VersionUpdate {
+	vu.mutation.SetCritical(b)
+	return vu
+}
+
+// SetNillableCritical sets the "critical" field if the given value is not nil.
+func (vu *VersionUpdate) SetNillableCritical(b *bool) *VersionUpdate {
+	if b != nil {
+		vu.SetCritical(*b)
+	}
+	return vu
+}
+
+// SetCriticalReason sets the "critical_reason" field.
+func (vu *VersionUpdate) SetCriticalReason(s string) *VersionUpdate {
+	vu.mutation.SetCriticalReason(s)
+	return vu
+}
+
+// SetNillableCriticalReason sets the "critical_reason" field if the given value is not nil.
+func (vu *VersionUpdate) SetNillableCriticalReason(s *string) *VersionUpdate {
+	if s != nil {
+		vu.SetCriticalReason(*s)
+	}
+	return vu
+}
+
+// ClearCriticalReason clears the value of the "critical_reason" field.
+func (vu *VersionUpdate) ClearCriticalReason() *VersionUpdate {
+	vu.mutation.ClearCriticalReason()
+	return vu
+}
+
 // AddStorageIDs adds the "storages" edge to the Storage entity by IDs.
 func (vu *VersionUpdate) AddStorageIDs(ids ...int) *VersionUpdate {
 	vu.mutation.AddStorageIDs(ids...)
@@ -242,7 +309,7 @@ func (vu *VersionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 	}
 	if value, ok := vu.mutation.Channel(); ok {
-		_spec.SetField(version.FieldChannel, field.TypeEnum, value)
+		_spec.SetField(version.FieldChannel, field.TypeString, value)
 	}
 	if value, ok := vu.mutation.Name(); ok {
 		_spec.SetField(version.FieldName, field.TypeString, value)
@@ -256,12 +323,33 @@ func (vu *VersionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := vu.mutation.ReleaseNote(); ok {
 		_spec.SetField(version.FieldReleaseNote, field.TypeString, value)
 	}
+	if value, ok := vu.mutation.ReleaseNotes(); ok {
+		_spec.SetField(version.FieldReleaseNotes, field.TypeJSON, value)
+	}
+	if vu.mutation.ReleaseNotesCleared() {
+		_spec.ClearField(version.FieldReleaseNotes, field.TypeJSON)
+	}
 	if value, ok := vu.mutation.CustomData(); ok {
 		_spec.SetField(version.FieldCustomData, field.TypeString, value)
 	}
 	if value, ok := vu.mutation.CreatedAt(); ok {
 		_spec.SetField(version.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := vu.mutation.PublishAt(); ok {
+		_spec.SetField(version.FieldPublishAt, field.TypeTime, value)
+	}
+	if vu.mutation.PublishAtCleared() {
+		_spec.ClearField(version.FieldPublishAt, field.TypeTime)
+	}
+	if value, ok := vu.mutation.Critical(); ok {
+		_spec.SetField(version.FieldCritical, field.TypeBool, value)
+	}
+	if value, ok := vu.mutation.CriticalReason(); ok {
+		_spec.SetField(version.FieldCriticalReason, field.TypeString, value)
+	}
+	if vu.mutation.CriticalReasonCleared() {
+		_spec.ClearField(version.FieldCriticalReason, field.TypeString)
+	}
 	if vu.mutation.StoragesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -357,15 +445,15 @@ type VersionUpdateOne struct {
 }
 
 // SetChannel sets the "channel" field.
-func (vuo *VersionUpdateOne) SetChannel(v version.Channel) *VersionUpdateOne {
-	vuo.mutation.SetChannel(v)
+func (vuo *VersionUpdateOne) SetChannel(s string) *VersionUpdateOne {
+	vuo.mutation.SetChannel(s)
 	return vuo
 }
 
 // SetNillableChannel sets the "channel" field if the given value is not nil.
-func (vuo *VersionUpdateOne) SetNillableChannel(v *version.Channel) *VersionUpdateOne {
-	if v != nil {
-		vuo.SetChannel(*v)
+func (vuo *VersionUpdateOne) SetNillableChannel(s *string) *VersionUpdateOne {
+	if s != nil {
+		vuo.SetChannel(*s)
 	}
 	return vuo
 }
@@ -419,6 +507,18 @@ func (vuo *VersionUpdateOne) SetNillableReleaseNote(s *string) *VersionUpdateOne
 	return vuo
 }
 
+// SetReleaseNotes sets the "release_notes" field.
+func (vuo *VersionUpdateOne) SetReleaseNotes(tn types.ReleaseNotes) *VersionUpdateOne {
+	vuo.mutation.SetReleaseNotes(tn)
+	return vuo
+}
+
+// ClearReleaseNotes clears the value of the "release_notes" field.
+func (vuo *VersionUpdateOne) ClearReleaseNotes() *VersionUpdateOne {
+	vuo.mutation.ClearReleaseNotes()
+	return vuo
+}
+
 // SetCustomData sets the "custom_data" field.
 func (vuo *VersionUpdateOne) SetCustomData(s string) *VersionUpdateOne {
 	vuo.mutation.SetCustomData(s)
@@ -447,6 +547,60 @@ func (vuo *VersionUpdateOne) SetNillableCreatedAt(t *time.Time) *VersionUpdateOn
 	return vuo
 }
 
+// SetPublishAt sets the "publish_at" field.
+func (vuo *VersionUpdateOne) SetPublishAt(t time.Time) *VersionUpdateOne {
+	vuo.mutation.SetPublishAt(t)
+	return vuo
+}
+
+// SetNillablePublishAt sets the "publish_at" field if the given value is not nil.
+func (vuo *VersionUpdateOne) SetNillablePublishAt(t *time.Time) *VersionUpdateOne {
+	if t != nil {
+		vuo.SetPublishAt(*t)
+	}
+	return vuo
+}
+
+// ClearPublishAt clears the value of the "publish_at" field.
+func (vuo *VersionUpdateOne) ClearPublishAt() *VersionUpdateOne {
+	vuo.mutation.ClearPublishAt()
+	return vuo
+}
+
+// SetCritical sets the "critical" field.
+func (vuo *VersionUpdateOne) SetCritical(b bool) *VersionUpdateOne {
+	vuo.mutation.SetCritical(b)
+	return vuo
+}
+
+// SetNillableCritical sets the "critical" field if the given value is not nil.
+func (vuo *VersionUpdateOne) SetNillableCritical(b *bool) *VersionUpdateOne {
+	if b != nil {
+		vuo.SetCritical(*b)
+	}
+	return vuo
+}
+
+// SetCriticalReason sets the "critical_reason" field.
+func (vuo *VersionUpdateOne) SetCriticalReason(s string) *VersionUpdateOne {
+	vuo.mutation.SetCriticalReason(s)
+	return vuo
+}
+
+// SetNillableCriticalReason sets the "critical_reason" field if the given value is not nil.
+func (vuo *VersionUpdateOne) SetNillableCriticalReason(s *string) *VersionUpdateOne {
+	if s != nil {
+		vuo.SetCriticalReason(*s)
+	}
+	return vuo
+}
+
+// ClearCriticalReason clears the value of the "critical_reason" field.
+func (vuo *VersionUpdateOne) ClearCriticalReason() *VersionUpdateOne {
+	vuo.mutation.ClearCriticalReason()
+	return vuo
+}
+
 // AddStorageIDs adds the "storages" edge to the Storage entity by IDs.
 func (vuo *VersionUpdateOne) AddStorageIDs(ids ...int) *VersionUpdateOne {
 	vuo.mutation.AddStorageIDs(ids...)
@@ -598,7 +752,7 @@ func (vuo *VersionUpdateOne) sqlSave(ctx context.Context) (_node *Version, err e
 		}
 	}
 	if value, ok := vuo.mutation.Channel(); ok {
-		_spec.SetField(version.FieldChannel, field.TypeEnum, value)
+		_spec.SetField(version.FieldChannel, field.TypeString, value)
 	}
 	if value, ok := vuo.mutation.Name(); ok {
 		_spec.SetField(version.FieldName, field.TypeString, value)
@@ -612,12 +766,33 @@ func (vuo *VersionUpdateOne) sqlSave(ctx context.Context) (_node *Version, err e
 	if value, ok := vuo.mutation.ReleaseNote(); ok {
 		_spec.SetField(version.FieldReleaseNote, field.TypeString, value)
 	}
+	if value, ok := vuo.mutation.ReleaseNotes(); ok {
+		_spec.SetField(version.FieldReleaseNotes, field.TypeJSON, value)
+	}
+	if vuo.mutation.ReleaseNotesCleared() {
+		_spec.ClearField(version.FieldReleaseNotes, field.TypeJSON)
+	}
 	if value, ok := vuo.mutation.CustomData(); ok {
 		_spec.SetField(version.FieldCustomData, field.TypeString, value)
 	}
 	if value, ok := vuo.mutation.CreatedAt(); ok {
 		_spec.SetField(version.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := vuo.mutation.PublishAt(); ok {
+		_spec.SetField(version.FieldPublishAt, field.TypeTime, value)
+	}
+	if vuo.mutation.PublishAtCleared() {
+		_spec.ClearField(version.FieldPublishAt, field.TypeTime)
+	}
+	if value, ok := vuo.mutation.Critical(); ok {
+		_spec.SetField(version.FieldCritical, field.TypeBool, value)
+	}
+	if value, ok := vuo.mutation.CriticalReason(); ok {
+		_spec.SetField(version.FieldCriticalReason, field.TypeString, value)
+	}
+	if vuo.mutation.CriticalReasonCleared() {
+		_spec.ClearField(version.FieldCriticalReason, field.TypeString)
+	}
 	if vuo.mutation.StoragesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -3,6 +3,7 @@ package handler
 import (
 	"github.com/MirrorChyan/resource-backend/internal/ent"
 	"github.com/MirrorChyan/resource-backend/internal/logic"
+	"github.com/MirrorChyan/resource-backend/internal/logic/dispense"
 	. "github.com/MirrorChyan/resource-backend/internal/logic/misc"
 	. "github.com/MirrorChyan/resource-backend/internal/model"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
@@ -19,20 +20,26 @@ import (
 // at the APISIX gateway, which can match the shared `/admin` prefix to enforce
 // internal-network / token admission.
 type AdminHandler struct {
-	logger        *zap.Logger
-	resourceLogic *logic.ResourceLogic
-	versionLogic  *logic.VersionLogic
+	logger          *zap.Logger
+	resourceLogic   *logic.ResourceLogic
+	versionLogic    *logic.VersionLogic
+	storageLogic    *logic.StorageLogic
+	distributeLogic *dispense.DistributeLogic
 }
 
 func NewAdminHandler(
 	logger *zap.Logger,
 	resourceLogic *logic.ResourceLogic,
 	versionLogic *logic.VersionLogic,
+	storageLogic *logic.StorageLogic,
+	distributeLogic *dispense.DistributeLogic,
 ) *AdminHandler {
 	return &AdminHandler{
-		logger:        logger,
-		resourceLogic: resourceLogic,
-		versionLogic:  versionLogic,
+		logger:          logger,
+		resourceLogic:   resourceLogic,
+		versionLogic:    versionLogic,
+		storageLogic:    storageLogic,
+		distributeLogic: distributeLogic,
 	}
 }
 
@@ -47,6 +54,10 @@ func (h *AdminHandler) Register(r fiber.Router) {
 	g.Get("/", h.ListResources)
 	g.Get("/:rid", h.GetResource)
 	g.Get("/:rid/versions", h.ListVersions)
+	g.Get("/:rid/purge-preview", h.PreviewPurge)
+
+	r.Get("/admin/mirrors", h.ListMirrors)
+	r.Get("/admin/releases/scheduled", h.ListScheduledReleases)
 }
 
 func normalizePage(page, size int) (int, int) {
@@ -97,8 +108,12 @@ func (h *AdminHandler) GetResource(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(response.Success(&ResourceDetailData{
-		ResourceItem: toResourceItem(res),
-		VersionCount: count,
+		ResourceItem:       toResourceItem(res),
+		VersionCount:       count,
+		DistributionPolicy: res.DistributionPolicy.Distributors,
+		PatchPolicy:        res.PatchPolicy,
+		RetentionPolicy:    res.RetentionPolicy,
+		SupportPolicy:      res.SupportPolicy,
 	}))
 }
 
@@ -123,9 +138,9 @@ func (h *AdminHandler) ListVersions(c *fiber.Ctx) error {
 
 	channel := req.Channel
 	if channel != "" {
-		ch, ok := ChannelMap[channel]
-		if !ok {
-			return errs.ErrResourceInvalidChannel
+		ch, err := h.resourceLogic.ResolveChannel(ctx, rid, channel)
+		if err != nil {
+			return err
 		}
 		channel = ch
 	}
@@ -138,23 +153,97 @@ func (h *AdminHandler) ListVersions(c *fiber.Ctx) error {
 
 	list := make([]VersionItem, len(items))
 	for i, it := range items {
+		platforms := make([]VersionPlatformItem, len(it.Edges.Storages))
+		for j, s := range it.Edges.Storages {
+			platforms[j] = VersionPlatformItem{
+				OS:             s.Os,
+				Arch:           s.Arch,
+				RolloutPercent: s.RolloutPercent,
+				YankedAt:       s.YankedAt,
+				YankReason:     s.YankReason,
+			}
+		}
 		list[i] = VersionItem{
 			ID:        it.ID,
 			Channel:   string(it.Channel),
 			Name:      it.Name,
 			Number:    it.Number,
 			CreatedAt: it.CreatedAt,
+			PublishAt: it.PublishAt,
+			Critical:  it.Critical,
+			Locales:   logic.ReleaseNoteLocales(it.ReleaseNote, it.ReleaseNotes),
+			Platforms: platforms,
 		}
 	}
 	return c.JSON(response.Success(&PageData{List: list, Total: total, Page: page, PageSize: size}))
 }
 
+// PreviewPurge full packages the next purge job would remove under the retention policy
+func (h *AdminHandler) PreviewPurge(c *fiber.Ctx) error {
+	var (
+		ctx = c.UserContext()
+		rid = c.Params(ResourceKey)
+	)
+
+	policy, items, err := h.storageLogic.PreviewPurge(ctx, rid)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errs.ErrResourceNotFound
+		}
+		return err
+	}
+
+	list := make([]PurgePreviewItem, len(items))
+	for i, it := range items {
+		list[i] = PurgePreviewItem{
+			VersionId:   it.VersionId,
+			VersionName: it.VersionName,
+			Channel:     it.Channel,
+			OS:          it.OS,
+			Arch:        it.Arch,
+			StorageId:   it.StorageId,
+			CreatedAt:   it.CreatedAt,
+		}
+	}
+	return c.JSON(response.Success(&PurgePreviewData{Policy: policy, List: list}))
+}
+
+// ListMirrors probe state of every download mirror seen by this instance
+func (h *AdminHandler) ListMirrors(c *fiber.Ctx) error {
+	return c.JSON(response.Success(h.distributeLogic.MirrorStatus()))
+}
+
+// ListScheduledReleases versions waiting for their publish time, the next to go live first
+func (h *AdminHandler) ListScheduledReleases(c *fiber.Ctx) error {
+	items, err := h.versionLogic.ListScheduledReleases(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	list := make([]ScheduledReleaseItem, 0, len(items))
+	for _, it := range items {
+		item := ScheduledReleaseItem{
+			VersionId:   it.ID,
+			VersionName: it.Name,
+			Channel:     string(it.Channel),
+			PublishAt:   *it.PublishAt,
+		}
+		if res := it.Edges.Resource; res != nil {
+			item.ResourceId = res.ID
+			item.ResourceName = res.Name
+		}
+		list = append(list, item)
+	}
+	return c.JSON(response.Success(list))
+}
+
 func toResourceItem(r *ent.Resource) ResourceItem {
 	return ResourceItem{
-		ID:          r.ID,
-		Name:        r.Name,
-		Description: r.Description,
-		UpdateType:  r.UpdateType,
-		CreatedAt:   r.CreatedAt,
+		ID:            r.ID,
+		Name:          r.Name,
+		Description:   r.Description,
+		UpdateType:    r.UpdateType,
+		VersionScheme: r.VersionScheme,
+		CreatedAt:     r.CreatedAt,
 	}
 }
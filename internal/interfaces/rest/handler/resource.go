@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/MirrorChyan/resource-backend/internal/logic"
+	. "github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	"github.com/MirrorChyan/resource-backend/internal/middleware"
 	. "github.com/MirrorChyan/resource-backend/internal/model"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/restserver/response"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/validator"
 	"github.com/gofiber/fiber/v2"
@@ -13,6 +19,8 @@ type ResourceHandler struct {
 	resourceLogic *logic.ResourceLogic
 }
 
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
 func NewResourceHandler(resourceLogic *logic.ResourceLogic) *ResourceHandler {
 	return &ResourceHandler{
 		resourceLogic: resourceLogic,
@@ -22,6 +30,14 @@ func NewResourceHandler(resourceLogic *logic.ResourceLogic) *ResourceHandler {
 func (h *ResourceHandler) Register(r fiber.Router) {
 	// For Developer
 	r.Post("/resources", h.Create)
+	r.Put("/resources/:rid/distribution-policy", middleware.NewValidateUploader(), h.UpdateDistributionPolicy)
+	r.Put("/resources/:rid/patch-policy", middleware.NewValidateUploader(), h.UpdatePatchPolicy)
+	r.Put("/resources/:rid/retention-policy", middleware.NewValidateUploader(), h.UpdateRetentionPolicy)
+	r.Put("/resources/:rid/version-scheme", middleware.NewValidateUploader(), h.UpdateVersionScheme)
+	r.Put("/resources/:rid/support-policy", middleware.NewValidateUploader(), h.UpdateSupportPolicy)
+	r.Get("/resources/:rid/channels", middleware.NewValidateUploader(), h.ListChannels)
+	r.Put("/resources/:rid/channels/:name", middleware.NewValidateUploader(), h.UpsertChannel)
+	r.Delete("/resources/:rid/channels/:name", middleware.NewValidateUploader(), h.DeleteChannel)
 }
 
 func (h *ResourceHandler) Create(c *fiber.Ctx) error {
@@ -37,10 +53,11 @@ func (h *ResourceHandler) Create(c *fiber.Ctx) error {
 	}
 
 	res, err := h.resourceLogic.Create(c.UserContext(), CreateResourceParam{
-		ID:          req.ID,
-		Name:        req.Name,
-		Description: req.Description,
-		UpdateType:  t,
+		ID:            req.ID,
+		Name:          req.Name,
+		Description:   req.Description,
+		UpdateType:    t,
+		VersionScheme: req.VersionScheme,
 	})
 
 	if err != nil {
@@ -54,3 +71,126 @@ func (h *ResourceHandler) Create(c *fiber.Ctx) error {
 	})
 	return c.JSON(resp)
 }
+
+func (h *ResourceHandler) UpdateDistributionPolicy(c *fiber.Ctx) error {
+
+	var req UpdateDistributionPolicyRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	err := h.resourceLogic.UpdateDistributionPolicy(c.UserContext(), c.Params(ResourceKey), types.DistributionPolicy{
+		Distributors: req.Distributors,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(nil))
+}
+
+func (h *ResourceHandler) UpdatePatchPolicy(c *fiber.Ctx) error {
+
+	var req UpdatePatchPolicyRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	err := h.resourceLogic.UpdatePatchPolicy(c.UserContext(), c.Params(ResourceKey), types.PatchPolicy{
+		MaxSizeRatio:     req.MaxSizeRatio,
+		PregenerateCount: req.PregenerateCount,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(nil))
+}
+
+func (h *ResourceHandler) UpdateRetentionPolicy(c *fiber.Ctx) error {
+
+	var req UpdateRetentionPolicyRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	err := h.resourceLogic.UpdateRetentionPolicy(c.UserContext(), c.Params(ResourceKey), types.RetentionPolicy{
+		KeepLatest:        req.KeepLatest,
+		KeepDays:          req.KeepDays,
+		PinnedVersions:    req.PinnedVersions,
+		MinActiveRequests: req.MinActiveRequests,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(nil))
+}
+
+func (h *ResourceHandler) UpdateVersionScheme(c *fiber.Ctx) error {
+
+	var req UpdateVersionSchemeRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	err := h.resourceLogic.UpdateVersionScheme(c.UserContext(), c.Params(ResourceKey), types.VersionScheme(req.VersionScheme))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(nil))
+}
+
+func (h *ResourceHandler) UpdateSupportPolicy(c *fiber.Ctx) error {
+
+	var req UpdateSupportPolicyRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+	err := h.resourceLogic.UpdateSupportPolicy(c.UserContext(), c.Params(ResourceKey), types.SupportPolicy{
+		MinVersions: req.MinVersions,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(nil))
+}
+
+func (h *ResourceHandler) ListChannels(c *fiber.Ctx) error {
+	list, err := h.resourceLogic.ListChannels(c.UserContext(), c.Params(ResourceKey))
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(list))
+}
+
+func (h *ResourceHandler) UpsertChannel(c *fiber.Ctx) error {
+	var req UpsertChannelRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	name := strings.ToLower(c.Params("name"))
+	if !channelNamePattern.MatchString(name) {
+		return errs.ErrResourceInvalidChannel.WithDetails("channel name must be 1-32 lowercase letters, digits, underscore or hyphen")
+	}
+	includes := make([]string, len(req.Includes))
+	for i, inc := range req.Includes {
+		includes[i] = strings.ToLower(inc)
+	}
+
+	if err := h.resourceLogic.UpsertChannel(c.UserContext(), c.Params(ResourceKey), name, includes); err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
+
+func (h *ResourceHandler) DeleteChannel(c *fiber.Ctx) error {
+	name := strings.ToLower(c.Params("name"))
+	if err := h.resourceLogic.DeleteChannel(c.UserContext(), c.Params(ResourceKey), name); err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
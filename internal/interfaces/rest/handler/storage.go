@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/MirrorChyan/resource-backend/internal/logic"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/restserver/response"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/validator"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -22,22 +27,93 @@ func NewStorageHandler(
 	}
 }
 
+const defaultPurgeReportLimit = 20
+
 func (h *StorageHandler) Register(r fiber.Router) {
 	r.Get("/storages/purge", h.Purge)
+	r.Get("/storages/purge/reports", h.ListPurgeReports)
+	r.Get("/storages/purge/reports/:id", h.GetPurgeReport)
+	r.Get("/storages/reconcile", h.Reconcile)
+	r.Delete("/storages/:sid/quarantine", h.ReleaseQuarantine)
 }
 
 func (h *StorageHandler) Purge(ctx *fiber.Ctx) error {
+	var req PurgeRequest
+	if err := validator.ValidateQuery(ctx, &req); err != nil {
+		return err
+	}
 
-	err := h.storageLogic.ClearOldStorages(ctx.UserContext())
+	report, err := h.storageLogic.ClearOldStorages(ctx.UserContext(), req.DryRun)
 
 	if err != nil {
 		h.logger.Error("failed to clear old storages",
+			zap.Bool("dry run", req.DryRun),
 			zap.Error(err),
 		)
-		resp := response.UnexpectedError()
-		return ctx.Status(fiber.StatusInternalServerError).JSON(resp)
+		if report == nil {
+			resp := response.UnexpectedError()
+			return ctx.Status(fiber.StatusInternalServerError).JSON(resp)
+		}
 	}
 
-	resp := response.Success(nil)
+	// partial failures are listed in the report
+	resp := response.Success(report)
 	return ctx.Status(fiber.StatusOK).JSON(resp)
 }
+
+func (h *StorageHandler) ListPurgeReports(ctx *fiber.Ctx) error {
+	var req ListPurgeReportsRequest
+	if err := validator.ValidateQuery(ctx, &req); err != nil {
+		return err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPurgeReportLimit
+	}
+
+	list, err := h.storageLogic.ListPurgeReports(ctx.UserContext(), limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Success(list))
+}
+
+func (h *StorageHandler) GetPurgeReport(ctx *fiber.Ctx) error {
+	report, err := h.storageLogic.GetPurgeReport(ctx.UserContext(), ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Success(report))
+}
+
+func (h *StorageHandler) Reconcile(ctx *fiber.Ctx) error {
+	var req ReconcileRequest
+	if err := validator.ValidateQuery(ctx, &req); err != nil {
+		return err
+	}
+	var grace time.Duration
+	if req.Grace != "" {
+		d, err := time.ParseDuration(req.Grace)
+		if err != nil || d < 0 {
+			return errs.ErrInvalidParams.WithDetails("invalid grace duration")
+		}
+		grace = d
+	}
+
+	report, err := h.storageLogic.ReconcileStorage(ctx.UserContext(), req.DryRun, grace)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Success(report))
+}
+
+func (h *StorageHandler) ReleaseQuarantine(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("sid")
+	if err != nil || id <= 0 {
+		return errs.ErrInvalidParams.WithDetails("invalid storage id")
+	}
+	if err := h.storageLogic.ReleaseQuarantine(ctx.UserContext(), id); err != nil {
+		return err
+	}
+	return ctx.JSON(response.Success(nil))
+}
@@ -10,6 +10,7 @@ import (
 	. "github.com/MirrorChyan/resource-backend/internal/logic/misc"
 	"github.com/MirrorChyan/resource-backend/internal/middleware"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/locale"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/validator"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
 	"github.com/bytedance/sonic"
@@ -63,6 +64,15 @@ const (
 	syncInterval    = 15 * time.Minute
 )
 
+func (h *VersionHandler) isKnownVersion(ctx context.Context, rid, version string) bool {
+	ok, err := h.versionLogic.IsKnownVersion(ctx, rid, version)
+	if err != nil {
+		h.logger.Warn("collector failed to look up version", zap.String("rid", rid), zap.Error(err))
+		return false
+	}
+	return ok
+}
+
 func (h *VersionHandler) getCollector() func(string, string, string) {
 	rdb := h.versionLogic.GetRedisClient()
 
@@ -73,6 +83,7 @@ func (h *VersionHandler) getCollector() func(string, string, string) {
 			ctx           = context.Background()
 			expiredHLL    = make(map[string]struct{})
 			expiredActive = make(map[string]struct{})
+			expiredRanked = make(map[string]struct{})
 			lastDate      string
 		)
 		for val := range ch {
@@ -80,6 +91,7 @@ func (h *VersionHandler) getCollector() func(string, string, string) {
 			if date != lastDate {
 				clear(expiredHLL)
 				clear(expiredActive)
+				clear(expiredRanked)
 				lastDate = date
 			}
 
@@ -93,6 +105,18 @@ func (h *VersionHandler) getCollector() func(string, string, string) {
 				expiredHLL[val.rid] = struct{}{}
 			}
 
+			// ranks the versions worth pre-generating patches from,
+			// names the resource doesn't have are dropped so clients can't grow the ranking
+			if val.version != "" && h.isKnownVersion(ctx, val.rid, val.version) {
+				rankKey := VersionRequestKeyPrefix + val.rid + ":" + date
+				if _, err := rdb.ZIncrBy(ctx, rankKey, 1, val.version).Result(); err != nil {
+					h.logger.Warn("collector ZIncrBy error", zap.String("rid", val.rid), zap.Error(err))
+				} else if _, ok := expiredRanked[val.rid]; !ok {
+					rdb.Expire(ctx, rankKey, keyTTL)
+					expiredRanked[val.rid] = struct{}{}
+				}
+			}
+
 			activeKey := activeSetPrefix + date
 			added, err := rdb.SAdd(ctx, activeKey, val.rid).Result()
 			if err != nil {
@@ -174,6 +198,7 @@ func (h *VersionHandler) Register(r fiber.Router) {
 	dau := middleware.NewDailyActiveUserRecorder(h.versionLogic.GetRedisClient())
 
 	r.Get("/resources/:rid/latest", dau, h.GetLatest)
+	r.Get("/resources/:rid/changelog", h.GetChangelog)
 	r.Head("/resources/download/:key", h.HeadDownloadInfo)
 	r.Get("/resources/download/:key", h.RedirectToDownload)
 
@@ -184,11 +209,24 @@ func (h *VersionHandler) Register(r fiber.Router) {
 	versions.Post("/callback", h.CreateVersionCallBack)
 	versions.Get("/status", h.GetVersionStatus)
 
+	// resumable upload for deployments without oss
+	versions.Post("/uploads", h.CreateUpload)
+	versions.Get("/uploads/:sid", h.GetUpload)
+	versions.Put("/uploads/:sid", h.UploadChunk)
+	versions.Post("/uploads/:sid/complete", h.CompleteUpload)
+
 	versions.Put("/release-note", h.UpdateReleaseNote)
 	versions.Put("/custom-data", h.UpdateCustomData)
+	versions.Put("/rollout", h.Rollout)
+	versions.Put("/publish-at", h.SchedulePublish)
+	versions.Put("/critical", h.MarkCritical)
+	versions.Post("/promote", h.Promote)
+	versions.Post("/yank", h.Yank)
+	versions.Post("/unyank", h.Unyank)
 }
 
-func (h *VersionHandler) bindRequiredParams(os, arch, channel *string) error {
+// bindRequiredParams channel is a built-in channel or one defined for the resource
+func (h *VersionHandler) bindRequiredParams(ctx context.Context, resourceId string, os, arch, channel *string) error {
 	*os = strings.ToLower(*os)
 	*arch = strings.ToLower(*arch)
 	*channel = strings.ToLower(*channel)
@@ -204,11 +242,11 @@ func (h *VersionHandler) bindRequiredParams(os, arch, channel *string) error {
 		*arch = a
 	}
 
-	if c, ok := ChannelMap[*channel]; !ok {
-		return errs.ErrResourceInvalidChannel
-	} else {
-		*channel = c
+	c, err := h.resourceLogic.ResolveChannel(ctx, resourceId, *channel)
+	if err != nil {
+		return err
 	}
+	*channel = c
 	return nil
 }
 
@@ -221,7 +259,7 @@ func (h *VersionHandler) Create(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := h.bindRequiredParams(&req.OS, &req.Arch, &req.Channel); err != nil {
+	if err := h.bindRequiredParams(c.UserContext(), resourceId, &req.OS, &req.Arch, &req.Channel); err != nil {
 		return err
 	}
 
@@ -232,6 +270,7 @@ func (h *VersionHandler) Create(c *fiber.Ctx) error {
 		Arch:       req.Arch,
 		Channel:    req.Channel,
 		Filename:   req.Filename,
+		PublishAt:  req.PublishAt,
 	})
 	if err != nil {
 		return err
@@ -249,7 +288,7 @@ func (h *VersionHandler) CreateVersionCallBack(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := h.bindRequiredParams(&req.OS, &req.Arch, &req.Channel); err != nil {
+	if err := h.bindRequiredParams(c.UserContext(), resourceId, &req.OS, &req.Arch, &req.Channel); err != nil {
 		return err
 	}
 
@@ -260,6 +299,8 @@ func (h *VersionHandler) CreateVersionCallBack(c *fiber.Ctx) error {
 		Arch:       req.Arch,
 		Channel:    req.Channel,
 		Key:        req.Key,
+
+		RolloutPercent: req.RolloutPercent,
 	})
 	if err != nil {
 		return err
@@ -268,6 +309,78 @@ func (h *VersionHandler) CreateVersionCallBack(c *fiber.Ctx) error {
 	return c.JSON(response.Success(&CreateVersionCallBackResponseData{StatusKey: statusKey}))
 }
 
+func (h *VersionHandler) CreateUpload(c *fiber.Ctx) error {
+
+	resourceId := c.Params(ResourceKey)
+
+	var req CreateUploadRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.bindRequiredParams(c.UserContext(), resourceId, &req.OS, &req.Arch, &req.Channel); err != nil {
+		return err
+	}
+
+	session, err := h.versionLogic.CreateUploadSession(c.UserContext(), CreateVersionParam{
+		ResourceID: resourceId,
+		Name:       req.Name,
+		OS:         req.OS,
+		Arch:       req.Arch,
+		Channel:    req.Channel,
+		Filename:   req.Filename,
+		PublishAt:  req.PublishAt,
+
+		RolloutPercent: req.RolloutPercent,
+	}, req.Size)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(session))
+}
+
+func (h *VersionHandler) GetUpload(c *fiber.Ctx) error {
+	session, err := h.versionLogic.GetUploadSession(c.UserContext(), c.Params(ResourceKey), c.Params("sid"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(session))
+}
+
+func (h *VersionHandler) UploadChunk(c *fiber.Ctx) error {
+
+	var req UploadChunkRequest
+	if err := validator.ValidateQuery(c, &req); err != nil {
+		return err
+	}
+
+	session, err := h.versionLogic.WriteUploadChunk(c.UserContext(),
+		c.Params(ResourceKey), c.Params("sid"),
+		req.Offset, c.Body(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(session))
+}
+
+func (h *VersionHandler) CompleteUpload(c *fiber.Ctx) error {
+
+	var req CompleteUploadRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+
+	statusKey, err := h.versionLogic.CompleteUpload(c.UserContext(), c.Params(ResourceKey), c.Params("sid"), req.SHA256)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(response.Success(&CreateVersionCallBackResponseData{StatusKey: statusKey}))
+}
+
 func (h *VersionHandler) doValidateCDK(info *GetLatestVersionRequest, resourceId, ip string) (int64, error) {
 
 	h.logger.Info("Validating CDK")
@@ -358,7 +471,7 @@ func (h *VersionHandler) doHandleGetLatestParam(c *fiber.Ctx) (*GetLatestVersion
 
 	req.ResourceID = c.Params(ResourceKey)
 
-	err := h.bindRequiredParams(&req.OS, &req.Arch, &req.Channel)
+	err := h.bindRequiredParams(c.UserContext(), req.ResourceID, &req.OS, &req.Arch, &req.Channel)
 	if err != nil {
 		return nil, err
 	}
@@ -384,7 +497,11 @@ func (h *VersionHandler) GetLatest(c *fiber.Ctx) error {
 		channel        = param.Channel
 	)
 
-	latest, err := h.versionLogic.GetMultiLatestVersionInfo(resourceId, system, arch, channel)
+	rolloutKey := cdk
+	if rolloutKey == "" {
+		rolloutKey = ip
+	}
+	latest, err := h.versionLogic.GetMultiLatestVersionInfo(resourceId, system, arch, channel, rolloutKey, currentVersion)
 	if err != nil {
 		return err
 	}
@@ -392,14 +509,51 @@ func (h *VersionHandler) GetLatest(c *fiber.Ctx) error {
 	var data = &QueryLatestResponseData{
 		VersionName:   latest.VersionName,
 		VersionNumber: latest.VersionNumber,
-		ReleaseNote:   latest.ReleaseNote,
 		Channel:       channel,
 		OS:            system,
 		Arch:          arch,
 	}
+	prefs := releaseNotePreferences(c, param.Lang)
+	data.ReleaseNote, data.ReleaseNoteLocale = logic.PickReleaseNote(latest.ReleaseNote, latest.ReleaseNotes, prefs)
 
 	h.collect(resourceId, currentVersion, ip)
 
+	if currentVersion != latest.VersionName {
+		yanked, downgrade, err := h.versionLogic.GetYankState(resourceId, system, arch, channel, currentVersion)
+		if err != nil {
+			return err
+		}
+		if yanked && !downgrade {
+			// stay on the yanked version until a newer release ships
+			held, err := h.versionLogic.HoldOnYanked(ctx, resourceId, currentVersion, latest)
+			if err != nil {
+				return err
+			}
+			if held != nil {
+				data.VersionName = held.Name
+				data.VersionNumber = held.Number
+				data.ReleaseNote, data.ReleaseNoteLocale = logic.PickReleaseNote(held.ReleaseNote, held.ReleaseNotes, prefs)
+				resp := response.Success(data, "current version is withdrawn")
+				return c.JSON(resp)
+			}
+		}
+
+		force, reason, err := h.versionLogic.GetForceUpdate(ctx, resourceId, system, arch, channel, currentVersion, latest.VersionName)
+		if err != nil {
+			return err
+		}
+		data.ForceUpdate = force
+		data.ForceUpdateReason = reason
+
+		if param.WithChangelog {
+			list, err := h.versionLogic.GetChangelog(ctx, resourceId, channel, currentVersion, latest.VersionName)
+			if err != nil {
+				return err
+			}
+			data.Changelog = toChangelogItems(list, prefs)
+		}
+	}
+
 	if cdk == "" {
 		if latest.VersionName == currentVersion {
 			data.ReleaseNote = "placeholder"
@@ -428,11 +582,42 @@ func (h *VersionHandler) GetLatest(c *fiber.Ctx) error {
 		ResourceId:         resourceId,
 		CurrentVersionName: currentVersion,
 		TargetVersionInfo:  latest,
+		PatchFormat:        param.PatchFormat,
+		PatchChain:         param.PatchChain,
 	})
 	if err != nil {
 		return err
 	}
 
+	if len(result.Chain) > 0 {
+		for _, hop := range result.Chain {
+			url, err := h.versionLogic.GetDistributeURL(&DistributeInfo{
+				CDK:      cdk,
+				UA:       param.UserAgent,
+				IP:       ip,
+				Resource: resourceId,
+				Version:  hop.ToVersion,
+				Filesize: hop.Filesize,
+				RelPath:  hop.RelPath,
+			})
+			if err != nil {
+				return err
+			}
+			data.Patches = append(data.Patches, PatchChainItem{
+				FromVersion: hop.FromVersion,
+				ToVersion:   hop.ToVersion,
+				Url:         url,
+				SHA256:      hop.SHA256,
+				Filesize:    hop.Filesize,
+			})
+		}
+		data.Filesize = result.Filesize
+		data.UpdateType = result.UpdateType
+		data.CustomData = latest.CustomData
+		data.CDKExpiredTime = ts
+		return c.JSON(response.Success(data))
+	}
+
 	url, err := h.versionLogic.GetDistributeURL(&DistributeInfo{
 		CDK:      cdk,
 		UA:       param.UserAgent,
@@ -456,6 +641,50 @@ func (h *VersionHandler) GetLatest(c *fiber.Ctx) error {
 	return c.JSON(response.Success(data))
 }
 
+// GetChangelog release notes of the versions after from up to to, newest first
+func (h *VersionHandler) GetChangelog(c *fiber.Ctx) error {
+	var req ChangelogRequest
+	if err := validator.ValidateQuery(c, &req); err != nil {
+		return err
+	}
+	var (
+		ctx        = c.UserContext()
+		resourceId = c.Params(ResourceKey)
+	)
+	channel, err := h.resourceLogic.ResolveChannel(ctx, resourceId, req.Channel)
+	if err != nil {
+		return err
+	}
+	list, err := h.versionLogic.GetChangelog(ctx, resourceId, channel, req.From, req.To)
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(toChangelogItems(list, releaseNotePreferences(c, req.Lang))))
+}
+
+func toChangelogItems(list []ChangelogVersion, prefs []string) []ChangelogItem {
+	items := make([]ChangelogItem, 0, len(list))
+	for _, v := range list {
+		note, _ := logic.PickReleaseNote(v.ReleaseNote, v.ReleaseNotes, prefs)
+		items = append(items, ChangelogItem{
+			VersionName:   v.Name,
+			VersionNumber: v.Number,
+			Channel:       v.Channel,
+			ReleaseNote:   note,
+			CreatedAt:     v.CreatedAt,
+		})
+	}
+	return items
+}
+
+// releaseNotePreferences the lang query parameter wins over the Accept-Language header
+func releaseNotePreferences(c *fiber.Ctx, lang string) []string {
+	if lang == "" {
+		lang = c.Get(fiber.HeaderAcceptLanguage)
+	}
+	return locale.ParsePreferences(lang)
+}
+
 func (h *VersionHandler) RedirectToDownload(c *fiber.Ctx) error {
 	var (
 		rk  = c.Params("key")
@@ -464,7 +693,7 @@ func (h *VersionHandler) RedirectToDownload(c *fiber.Ctx) error {
 
 	url, err := h.versionLogic.GetDistributeLocation(ctx, rk)
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
+		if errors.Is(err, DistributeKeyNotFoundError) {
 			return c.Status(fiber.StatusNotFound).JSON(response.BusinessError("resource not found"))
 		}
 		return err
@@ -484,7 +713,7 @@ func (h *VersionHandler) HeadDownloadInfo(c *fiber.Ctx) error {
 
 	info, err := h.versionLogic.GetDownloadInfo(ctx, rk)
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
+		if errors.Is(err, DistributeKeyNotFoundError) {
 			return c.Status(fiber.StatusNotFound).JSON(response.BusinessError("resource not found"))
 		}
 		h.logger.Error("Failed to get download info",
@@ -537,12 +766,20 @@ func (h *VersionHandler) UpdateReleaseNote(c *fiber.Ctx) error {
 
 	req.Content = truncateUTF8Runes(req.Content, 20000)
 
-	if ch, ok := ChannelMap[req.Channel]; ok {
-		req.Channel = ch
-	} else {
-		return errors.New("invalid channel")
+	if req.Locale != "" {
+		l, ok := locale.Normalize(req.Locale)
+		if !ok {
+			return errs.ErrInvalidParams.WithDetails("invalid locale")
+		}
+		req.Locale = l
 	}
 
+	ch, err := h.resourceLogic.ResolveChannel(ctx, resourceId, req.Channel)
+	if err != nil {
+		return err
+	}
+	req.Channel = ch
+
 	ver, err := h.versionLogic.LoadStoreNewVersionTx(ctx, resourceId, req.VersionName, req.Channel)
 	if err != nil {
 		h.logger.Error("failed to load store version",
@@ -555,6 +792,7 @@ func (h *VersionHandler) UpdateReleaseNote(c *fiber.Ctx) error {
 	}
 	err = h.versionLogic.UpdateReleaseNote(ctx, UpdateReleaseNoteDetailParam{
 		VersionID:         ver.ID,
+		Locale:            req.Locale,
 		ReleaseNoteDetail: req.Content,
 	})
 	if err != nil {
@@ -610,11 +848,11 @@ func (h *VersionHandler) UpdateCustomData(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
-	if ch, ok := ChannelMap[req.Channel]; ok {
-		req.Channel = ch
-	} else {
-		return errors.New("invalid channel")
+	ch, err := h.resourceLogic.ResolveChannel(ctx, resourceId, req.Channel)
+	if err != nil {
+		return err
 	}
+	req.Channel = ch
 
 	ver, err := h.versionLogic.LoadStoreNewVersionTx(ctx, resourceId, req.VersionName, req.Channel)
 	if err != nil {
@@ -647,6 +885,131 @@ func (h *VersionHandler) UpdateCustomData(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
+// bindOptionalPlatform nil stands for every platform
+func (h *VersionHandler) bindOptionalPlatform(os, arch **string) error {
+	if *os != nil {
+		o, ok := OsMap[strings.ToLower(**os)]
+		if !ok {
+			return errs.ErrResourceInvalidOS
+		}
+		*os = &o
+	}
+	if *arch != nil {
+		a, ok := ArchMap[strings.ToLower(**arch)]
+		if !ok {
+			return errs.ErrResourceInvalidArch
+		}
+		*arch = &a
+	}
+	return nil
+}
+
+func (h *VersionHandler) SchedulePublish(c *fiber.Ctx) error {
+	var req SchedulePublishRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+	err := h.versionLogic.SchedulePublish(c.UserContext(), c.Params(ResourceKey), req.VersionName, req.PublishAt)
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
+
+func (h *VersionHandler) Rollout(c *fiber.Ctx) error {
+	var req RolloutVersionRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+	if err := h.bindOptionalPlatform(&req.OS, &req.Arch); err != nil {
+		return err
+	}
+	err := h.versionLogic.SetRollout(c.UserContext(), RolloutVersionParam{
+		ResourceId:  c.Params(ResourceKey),
+		VersionName: req.VersionName,
+		OS:          req.OS,
+		Arch:        req.Arch,
+		Percent:     req.Percent,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
+
+func (h *VersionHandler) MarkCritical(c *fiber.Ctx) error {
+	var req MarkCriticalRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+	err := h.versionLogic.SetCritical(c.UserContext(), c.Params(ResourceKey), req.VersionName, req.Critical, req.Reason)
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
+
+func (h *VersionHandler) Promote(c *fiber.Ctx) error {
+	var req PromoteVersionRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return err
+	}
+	ver, err := h.versionLogic.PromoteVersion(c.UserContext(), PromoteVersionParam{
+		ResourceId:  c.Params(ResourceKey),
+		VersionName: req.VersionName,
+		Channel:     req.Channel,
+		CloneName:   req.CloneName,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(response.Success(PromoteVersionResponseData{
+		Name:    ver.Name,
+		Number:  ver.Number,
+		Channel: ver.Channel,
+	}))
+}
+
+func (h *VersionHandler) doBindYankParam(c *fiber.Ctx) (*YankVersionParam, error) {
+	var req YankVersionRequest
+	if err := validator.ValidateBody(c, &req); err != nil {
+		return nil, err
+	}
+	if err := h.bindOptionalPlatform(&req.OS, &req.Arch); err != nil {
+		return nil, err
+	}
+	return &YankVersionParam{
+		ResourceId:  c.Params(ResourceKey),
+		VersionName: req.VersionName,
+		OS:          req.OS,
+		Arch:        req.Arch,
+		Reason:      req.Reason,
+		Downgrade:   req.Downgrade,
+	}, nil
+}
+
+func (h *VersionHandler) Yank(c *fiber.Ctx) error {
+	param, err := h.doBindYankParam(c)
+	if err != nil {
+		return err
+	}
+	if err := h.versionLogic.YankVersion(c.UserContext(), *param); err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
+
+func (h *VersionHandler) Unyank(c *fiber.Ctx) error {
+	param, err := h.doBindYankParam(c)
+	if err != nil {
+		return err
+	}
+	if err := h.versionLogic.UnyankVersion(c.UserContext(), *param); err != nil {
+		return err
+	}
+	return c.JSON(response.Success(nil))
+}
+
 func (h *VersionHandler) GetVersionStatus(c *fiber.Ctx) error {
 	var req GetVersionStatusRequest
 	if err := validator.ValidateQuery(c, &req); err != nil {
@@ -666,16 +1029,7 @@ func (h *VersionHandler) GetVersionStatus(c *fiber.Ctx) error {
 }
 
 func (h *VersionHandler) doEvictCache(resourceId string) {
-	// The cache does not support iteration to temporarily clear all
-	cg := h.versionLogic.GetCacheGroup()
-	for _, system := range TotalOs {
-		for _, arch := range TotalArch {
-			for _, channel := range TotalChannel {
-				key := cg.GetCacheKey(resourceId, system, arch, channel)
-				cg.MultiVersionInfoCache.Delete(key)
-			}
-		}
-	}
+	h.versionLogic.EvictLatestVersionInfo(resourceId)
 }
 
 func truncateUTF8Runes(s string, limit int) string {
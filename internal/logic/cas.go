@@ -0,0 +1,233 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/segmentio/ksuid"
+	"go.uber.org/zap"
+)
+
+// full packages are stored once per content under cas/<sha256[:2]>/<sha256><ext>,
+// the storages pointing at an object are its references
+const casPrefix = "cas"
+
+func casKey(hash, ext string) string {
+	return path.Join(casPrefix, hash[:2], hash+ext)
+}
+
+func packageExt(name string) string {
+	if strings.HasSuffix(name, misc.TgzSuffix) {
+		return misc.TgzSuffix
+	}
+	return path.Ext(name)
+}
+
+// lockContentAddressed serializes storing and releasing an object,
+// a release can't delete the object between the dedup check and the new storage pointing at it
+func (l *StorageLogic) lockContentAddressed(ctx context.Context, key string) (func(), error) {
+	mutex := l.sync.NewMutex(strings.Join([]string{misc.ContentAddressedLockKey, key}, ":"), redsync.WithExpiry(time.Minute))
+	if err := mutex.LockContext(ctx); err != nil {
+		return nil, err
+	}
+	c, cancel := context.WithCancel(context.Background())
+	go renewMutex(c, mutex)
+	return func() {
+		cancel()
+		_, _ = mutex.Unlock()
+	}, nil
+}
+
+// StoreContentAddressed moves an uploaded package into the content addressed layout and returns its package path,
+// local is a copy of the upload on disk or empty to copy the object itself,
+// save has to create the reference to the package path, it runs while the object is locked
+func (l *StorageLogic) StoreContentAddressed(ctx context.Context, sourceKey, local, hash string, size int64, save func(packagePath string) error) (string, error) {
+	key := casKey(hash, packageExt(sourceKey))
+
+	unlock, err := l.lockContentAddressed(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	info, err := l.Blob.Stat(ctx, key)
+	var tainted bool
+	if err == nil {
+		if tainted, err = l.storageRepo.HasTaintedPackageReferences(ctx, l.BlobPath(key)); err != nil {
+			return "", err
+		}
+	}
+	reuse, err := canReuseObject(info, err, size, tainted)
+	switch {
+	case err != nil:
+		return "", err
+	case reuse:
+		casDedupCounter.Inc()
+		l.logger.Info("package deduplicated",
+			zap.String("source", sourceKey),
+			zap.String("key", key),
+			zap.Int64("size", size),
+		)
+		return l.BlobPath(key), save(l.BlobPath(key))
+	case tainted:
+		l.logger.Warn("replace content addressed package of a quarantined or broken storage",
+			zap.String("source", sourceKey),
+			zap.String("key", key),
+		)
+	}
+
+	if local != "" {
+		err = l.PushBlob(ctx, local, key)
+	} else {
+		err = l.copyBlob(ctx, sourceKey, key, size)
+	}
+	if err != nil {
+		l.logger.Error("failed to store package by content",
+			zap.String("source", sourceKey),
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return "", err
+	}
+	return l.BlobPath(key), save(l.BlobPath(key))
+}
+
+// canReuseObject whether the stat result of an existing object can back a new storage,
+// the content of a quarantined or broken storage is uploaded again instead
+func canReuseObject(info blob.Info, statErr error, size int64, tainted bool) (bool, error) {
+	switch {
+	case errors.Is(statErr, blob.ErrNotFound):
+		return false, nil
+	case statErr != nil:
+		return false, statErr
+	}
+	return info.Size == size && !tainted, nil
+}
+
+func (l *StorageLogic) copyBlob(ctx context.Context, src, dest string, size int64) error {
+	r, err := l.Blob.Get(ctx, src)
+	if err != nil {
+		return err
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+	return l.Blob.Put(ctx, dest, r, size)
+}
+
+// isContentAddressed legacy storages keep their package below the version directory
+func (l *StorageLogic) isContentAddressed(packagePath string) (string, bool) {
+	key, ok := l.BlobKey(packagePath)
+	if !ok || !strings.HasPrefix(key, casPrefix+"/") {
+		return "", false
+	}
+	return key, true
+}
+
+// releaseContentAddressed deletes the object once no storage points at it anymore
+func (l *StorageLogic) releaseContentAddressed(ctx context.Context, packagePath string) error {
+	key, ok := l.isContentAddressed(packagePath)
+	if !ok {
+		return nil
+	}
+	_, err := l.deleteUnreferenced(ctx, key, packagePath)
+	return err
+}
+
+// deleteUnreferenced deletes the object when no storage points at it, the references are counted under the lock
+// a concurrent StoreContentAddressed takes, so an object it just reused is never deleted
+func (l *StorageLogic) deleteUnreferenced(ctx context.Context, key, packagePath string) (bool, error) {
+	unlock, err := l.lockContentAddressed(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	refs, err := l.storageRepo.CountPackageReferences(ctx, packagePath, 0)
+	if err != nil || refs > 0 {
+		return false, err
+	}
+	l.logger.Info("release content addressed package",
+		zap.String("key", key),
+	)
+	return true, l.Blob.Delete(ctx, key)
+}
+
+// measureContentAddressed bytes freed by purging the version, 0 while other versions share the content
+func (l *StorageLogic) measureContentAddressed(ctx context.Context, key, packagePath string, versionId int) int64 {
+	refs, err := l.storageRepo.CountPackageReferences(ctx, packagePath, versionId)
+	if err != nil || refs > 0 {
+		return 0
+	}
+	info, err := l.Blob.Stat(ctx, key)
+	if err != nil {
+		return 0
+	}
+	return info.Size
+}
+
+// LocalPackage a path on disk of the full package, content addressed packages are fetched into the
+// version directory on first use so the patcher can read them
+func (l *StorageLogic) LocalPackage(ctx context.Context, resourceId string, versionId int, system, arch, packagePath string, fileType types.FileType) (string, error) {
+	key, ok := l.isContentAddressed(packagePath)
+	if !ok {
+		return packagePath, nil
+	}
+	local := filepath.Join(
+		l.BuildVersionStorageDirPath(resourceId, versionId, system, arch),
+		misc.DefaultResourceName+types.GetFileSuffix(fileType),
+	)
+	if _, err := os.Stat(local); err == nil {
+		return local, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(local), os.ModePerm); err != nil {
+		return "", err
+	}
+	tmp := strings.Join([]string{local, ksuid.New().String(), "tmp"}, ".")
+	if err := l.FetchBlob(ctx, key, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	return local, os.Rename(tmp, local)
+}
+
+// shareFullPackage moves a legacy full package into the content addressed layout so another version can
+// point at it, the package of the version directory is left to the reconciler
+func (l *StorageLogic) shareFullPackage(ctx context.Context, s *ent.Storage) (string, error) {
+	if _, ok := l.isContentAddressed(s.PackagePath); ok {
+		return s.PackagePath, nil
+	}
+	if s.PackageHashSha256 == "" {
+		return "", errs.NewUnchecked("package hash of the storage is unknown")
+	}
+	var local string
+	if strings.HasPrefix(s.PackagePath, l.RootDir) {
+		local = s.PackagePath
+	}
+	packagePath, err := l.StoreContentAddressed(ctx, l.RelPath(s.PackagePath), local, s.PackageHashSha256, s.FileSize,
+		func(packagePath string) error {
+			return l.storageRepo.UpdateStoragePackagePath(ctx, s.ID, packagePath)
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	l.logger.Info("legacy package moved to content addressed storage",
+		zap.Int("storage id", s.ID),
+		zap.String("package path", s.PackagePath),
+		zap.String("content addressed path", packagePath),
+	)
+	return packagePath, nil
+}
@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCasKey(t *testing.T) {
+	const hash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"res/1/any/resource.zip", "cas/9f/" + hash + ".zip"},
+		{"upload/abc.tar.gz", "cas/9f/" + hash + ".tar.gz"},
+		{"upload/abc", "cas/9f/" + hash},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, casKey(hash, packageExt(tt.name)), tt.name)
+	}
+}
+
+func TestCanReuseObject(t *testing.T) {
+	info := blob.Info{Key: "cas/9f/9f86.zip", Size: 10}
+
+	reuse, err := canReuseObject(info, nil, 10, false)
+	require.NoError(t, err)
+	assert.True(t, reuse)
+
+	// uploading the package again after its storage was quarantined replaces the corrupt object
+	reuse, err = canReuseObject(info, nil, 10, true)
+	require.NoError(t, err)
+	assert.False(t, reuse)
+
+	reuse, err = canReuseObject(info, nil, 11, false)
+	require.NoError(t, err)
+	assert.False(t, reuse)
+
+	reuse, err = canReuseObject(blob.Info{}, blob.ErrNotFound, 10, false)
+	require.NoError(t, err)
+	assert.False(t, reuse)
+
+	_, err = canReuseObject(blob.Info{}, errors.New("boom"), 10, false)
+	require.Error(t, err)
+}
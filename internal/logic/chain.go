@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+)
+
+// clients apply every hop, keep chains short
+const maxPatchChainHops = 4
+
+func (l *VersionLogic) getPatchChainOrEmpty(ctx context.Context, resourceId string, target, current int, os, arch string, format int) (*PatchChain, error) {
+	cacheKey := l.cacheGroup.GetCacheKey(
+		resourceId,
+		strconv.Itoa(target),
+		strconv.Itoa(current),
+		os,
+		arch,
+		strconv.Itoa(format),
+	)
+	val, err := l.cacheGroup.PatchChainCache.ComputeIfAbsent(cacheKey, func() (*PatchChain, error) {
+		edges, err := l.rawQuery.GetPatchEdges(resourceId, os, arch, format)
+		if err != nil {
+			return nil, err
+		}
+		return findPatchChain(edges, current, target, maxPatchChainHops), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *val, nil
+}
+
+// findPatchChain the chain with the smallest total size using at most maxHops patches
+func findPatchChain(edges []PatchEdge, from, to, maxHops int) *PatchChain {
+	type step struct {
+		size int64
+		edge int
+		prev *step
+	}
+
+	best := map[int]*step{from: {edge: -1}}
+	for range maxHops {
+		next := make(map[int]*step, len(best))
+		for k, v := range best {
+			next[k] = v
+		}
+		for i, e := range edges {
+			s, ok := best[e.CurrentVersionId]
+			if !ok {
+				continue
+			}
+			size := s.size + e.FileSize
+			if cur, ok := next[e.TargetVersionId]; !ok || size < cur.size {
+				next[e.TargetVersionId] = &step{size: size, edge: i, prev: s}
+			}
+		}
+		best = next
+	}
+
+	s, ok := best[to]
+	if !ok || from == to {
+		return &PatchChain{}
+	}
+	chain := &PatchChain{Size: s.size}
+	for ; s.edge >= 0; s = s.prev {
+		chain.Edges = append(chain.Edges, edges[s.edge])
+	}
+	slices.Reverse(chain.Edges)
+	return chain
+}
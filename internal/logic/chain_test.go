@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"testing"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPatchChain(t *testing.T) {
+	edge := func(from, to int, size int64) PatchEdge {
+		return PatchEdge{CurrentVersionId: from, TargetVersionId: to, FileSize: size}
+	}
+	hops := func(c *PatchChain) [][2]int {
+		var r [][2]int
+		for _, e := range c.Edges {
+			r = append(r, [2]int{e.CurrentVersionId, e.TargetVersionId})
+		}
+		return r
+	}
+
+	edges := []PatchEdge{
+		edge(1, 2, 10),
+		edge(2, 3, 10),
+		edge(3, 4, 10),
+		edge(1, 3, 50),
+		edge(2, 4, 5),
+		edge(4, 1, 1),
+	}
+
+	testCases := []struct {
+		Name     string
+		From, To int
+		MaxHops  int
+		Expected [][2]int
+		Size     int64
+	}{
+		{Name: "cheapest", From: 1, To: 4, MaxHops: 4, Expected: [][2]int{{1, 2}, {2, 4}}, Size: 15},
+		{Name: "prefers smaller over shorter", From: 1, To: 3, MaxHops: 4, Expected: [][2]int{{1, 2}, {2, 3}}, Size: 20},
+		{Name: "hop limit", From: 1, To: 3, MaxHops: 1, Expected: [][2]int{{1, 3}}, Size: 50},
+		{Name: "unreachable", From: 3, To: 2, MaxHops: 1},
+		{Name: "same version", From: 2, To: 2, MaxHops: 4},
+		{Name: "unknown version", From: 9, To: 4, MaxHops: 4},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			c := findPatchChain(edges, tc.From, tc.To, tc.MaxHops)
+			require.Equal(t, tc.Expected, hops(c))
+			require.Equal(t, tc.Size, c.Size)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package logic
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+)
+
+// maxChangelogVersions the newest versions of a longer range are returned
+const maxChangelogVersions = 100
+
+// GetChangelog versions after from up to and including to which subscribers of the channel see, newest first,
+// empty from starts at the first version and empty to ends at the newest one
+func (l *VersionLogic) GetChangelog(ctx context.Context, resourceId, channel, from, to string) ([]ChangelogVersion, error) {
+	comparator, _, err := l.resourceLogic.ComparatorOf(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := l.loadChangelogIndex(ctx, resourceId, channel, comparator)
+	if err != nil {
+		return nil, err
+	}
+
+	// versions of other channels are placed by number when the names can't be compared
+	numberOf := func(name string) (uint64, bool) {
+		ver, err := l.versionRepo.GetVersionByName(ctx, resourceId, name)
+		if err != nil {
+			return 0, false
+		}
+		return ver.Number, true
+	}
+	start, end := 0, len(idx.Versions)
+	if from != "" {
+		// an unknown current version gets every release
+		if n, ok := releasePosition(comparator, idx, from, numberOf); ok {
+			start = n
+		}
+	}
+	if to != "" {
+		n, ok := releasePosition(comparator, idx, to, numberOf)
+		if !ok {
+			return nil, errs.ErrResourceVersionNotFound.WithDetails(to)
+		}
+		end = n
+	}
+	if start >= end {
+		return []ChangelogVersion{}, nil
+	}
+
+	result := slices.Clone(idx.Versions[max(start, end-maxChangelogVersions):end])
+	slices.Reverse(result)
+	return result, nil
+}
+
+func (l *VersionLogic) loadChangelogIndex(ctx context.Context, resourceId, channel string, comparator *vercomp.VersionComparator) (*ChangelogIndex, error) {
+	key := l.cacheGroup.GetCacheKey(resourceId, channel)
+	val, err := l.cacheGroup.ChangelogCache.ComputeIfAbsent(key, func() (*ChangelogIndex, error) {
+		channels, err := l.resourceLogic.ResolveChannelChain(ctx, resourceId, channel)
+		if err != nil {
+			return nil, err
+		}
+		list, err := l.versionRepo.ListPublishedVersions(ctx, resourceId, channels, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		result := make([]ChangelogVersion, 0, len(list))
+		for _, v := range list {
+			result = append(result, toChangelogVersion(v))
+		}
+		return newChangelogIndex(comparator, result), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *val, nil
+}
+
+func toChangelogVersion(v *ent.Version) ChangelogVersion {
+	return ChangelogVersion{
+		Name:         v.Name,
+		Number:       v.Number,
+		Channel:      v.Channel,
+		ReleaseNote:  v.ReleaseNote,
+		ReleaseNotes: v.ReleaseNotes,
+		CreatedAt:    v.CreatedAt,
+	}
+}
+
+// newChangelogIndex sorts the versions oldest first, by comparator order when every name can be compared,
+// otherwise by number
+func newChangelogIndex(c *vercomp.VersionComparator, list []ChangelogVersion) *ChangelogIndex {
+	idx := &ChangelogIndex{
+		Versions:   list,
+		Positions:  make(map[string]int, len(list)),
+		Comparable: comparableNames(c, list),
+	}
+	if idx.Comparable {
+		slices.SortStableFunc(list, func(a, b ChangelogVersion) int {
+			if r := c.Compare(a.Name, b.Name).Result; r != vercomp.Equal {
+				return r
+			}
+			return cmp.Compare(a.Number, b.Number)
+		})
+	} else {
+		slices.SortStableFunc(list, func(a, b ChangelogVersion) int {
+			return cmp.Compare(a.Number, b.Number)
+		})
+	}
+	for i, v := range list {
+		idx.Positions[v.Name] = i + 1
+	}
+	return idx
+}
+
+func comparableNames(c *vercomp.VersionComparator, list []ChangelogVersion) bool {
+	for i := 1; i < len(list); i++ {
+		if !c.Compare(list[0].Name, list[i].Name).Comparable {
+			return false
+		}
+	}
+	return true
+}
+
+// releasePosition how many versions of the index are released up to and including name,
+// a name outside the index is placed by comparison or by its number
+func releasePosition(c *vercomp.VersionComparator, idx *ChangelogIndex, name string, numberOf func(string) (uint64, bool)) (int, bool) {
+	if n, ok := idx.Positions[name]; ok {
+		return n, true
+	}
+	list := idx.Versions
+	if idx.Comparable && (len(list) == 0 || c.Compare(list[0].Name, name).Comparable) {
+		// names of one parser, the versions are in comparator order
+		n, _ := slices.BinarySearchFunc(list, name, func(v ChangelogVersion, name string) int {
+			if c.Compare(v.Name, name).Result == vercomp.Greater {
+				return 1
+			}
+			return -1
+		})
+		return n, true
+	}
+	number, ok := numberOf(name)
+	if !ok {
+		return 0, false
+	}
+	n := 0
+	for _, v := range list {
+		if v.Number <= number {
+			n++
+		}
+	}
+	return n, true
+}
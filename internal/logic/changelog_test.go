@@ -0,0 +1,80 @@
+package logic
+
+import (
+	"testing"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+	"github.com/stretchr/testify/assert"
+)
+
+func changelogNames(list []ChangelogVersion) []string {
+	names := make([]string, 0, len(list))
+	for _, v := range list {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+func TestNewChangelogIndex(t *testing.T) {
+	c := vercomp.NewComparator()
+
+	list := []ChangelogVersion{
+		{Name: "1.2.0", Number: 3},
+		{Name: "1.10.0", Number: 2},
+		{Name: "1.2.0-beta.1", Number: 1},
+		{Name: "1.0.0", Number: 4},
+	}
+	idx := newChangelogIndex(c, list)
+	assert.True(t, idx.Comparable)
+	assert.Equal(t, []string{"1.0.0", "1.2.0-beta.1", "1.2.0", "1.10.0"}, changelogNames(idx.Versions))
+	assert.Equal(t, map[string]int{"1.0.0": 1, "1.2.0-beta.1": 2, "1.2.0": 3, "1.10.0": 4}, idx.Positions)
+
+	// names which can't be compared fall back to the upload order
+	list = []ChangelogVersion{
+		{Name: "1.2.0", Number: 2},
+		{Name: "nightly", Number: 3},
+		{Name: "1.0.0", Number: 1},
+	}
+	idx = newChangelogIndex(c, list)
+	assert.False(t, idx.Comparable)
+	assert.Equal(t, []string{"1.0.0", "1.2.0", "nightly"}, changelogNames(idx.Versions))
+}
+
+func TestReleasePosition(t *testing.T) {
+	var (
+		c   = vercomp.NewComparator()
+		idx = newChangelogIndex(c, []ChangelogVersion{
+			{Name: "1.0.0", Number: 1},
+			{Name: "1.1.0", Number: 2},
+			{Name: "1.3.0", Number: 5},
+		})
+		numbers = map[string]uint64{"nightly": 3}
+	)
+	numberOf := func(name string) (uint64, bool) {
+		n, ok := numbers[name]
+		return n, ok
+	}
+	tests := []struct {
+		name    string
+		version string
+		want    int
+		ok      bool
+	}{
+		{"listed", "1.1.0", 2, true},
+		{"first", "1.0.0", 1, true},
+		{"between", "1.2.0", 2, true},
+		{"older than all", "0.9.0", 0, true},
+		{"newer than all", "2.0.0", 3, true},
+		{"equal to a listed one", "v1.1.0", 2, true},
+		{"by number", "nightly", 2, true},
+		{"unknown", "custom", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := releasePosition(c, idx, tt.version, numberOf)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"go.uber.org/zap"
+)
+
+// resolveChannels channels whose versions subscribers of name see, name itself last,
+// custom maps a channel of the resource to the channels it includes
+func resolveChannels(name string, custom map[string][]string) ([]string, bool) {
+	var (
+		result []string
+		seen   = make(map[string]bool)
+		visit  func(c string) bool
+	)
+	visit = func(c string) bool {
+		if seen[c] {
+			return true
+		}
+		seen[c] = true
+		if i := slices.Index(types.BuiltinChannels, types.Channel(c)); i >= 0 {
+			for _, b := range types.BuiltinChannels[:i] {
+				visit(b.String())
+			}
+		} else {
+			includes, ok := custom[c]
+			if !ok {
+				return false
+			}
+			for _, inc := range includes {
+				if !visit(inc) {
+					return false
+				}
+			}
+		}
+		result = append(result, c)
+		return true
+	}
+	ok := visit(name)
+	return result, ok
+}
+
+func isBuiltinChannel(name string) bool {
+	return slices.Contains(types.BuiltinChannels, types.Channel(name))
+}
+
+// FindChannelsById channel of the resource -> channels it includes
+func (l *ResourceLogic) FindChannelsById(ctx context.Context, id string) (map[string][]string, error) {
+	res, err := l.getResourceInfoByCache(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]string, len(res.Edges.Channels))
+	for _, c := range res.Edges.Channels {
+		m[c.Name] = c.Includes
+	}
+	return m, nil
+}
+
+// ResolveChannel normalizes a built-in channel name or checks the resource defines the channel
+func (l *ResourceLogic) ResolveChannel(ctx context.Context, id, channel string) (string, error) {
+	channel = strings.ToLower(channel)
+	if c, ok := misc.ChannelMap[channel]; ok {
+		return c, nil
+	}
+	custom, err := l.FindChannelsById(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", errs.ErrResourceNotFound
+		}
+		return "", err
+	}
+	if _, ok := custom[channel]; !ok {
+		return "", errs.ErrResourceInvalidChannel
+	}
+	return channel, nil
+}
+
+// ResolveChannelChain channels whose versions subscribers of the channel see, the channel itself last
+func (l *ResourceLogic) ResolveChannelChain(ctx context.Context, id, channel string) ([]string, error) {
+	custom, err := l.FindChannelsById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	chain, ok := resolveChannels(channel, custom)
+	if !ok {
+		return nil, errs.ErrResourceInvalidChannel
+	}
+	return chain, nil
+}
+
+// ListChannelNames built-in channels first
+func (l *ResourceLogic) ListChannelNames(ctx context.Context, id string) ([]string, error) {
+	custom, err := l.FindChannelsById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	names := slices.Clone(misc.TotalChannel)
+	for name := range custom {
+		names = append(names, name)
+	}
+	slices.Sort(names[len(misc.TotalChannel):])
+	return names, nil
+}
+
+func (l *ResourceLogic) ListChannels(ctx context.Context, id string) ([]ChannelItem, error) {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, errs.ErrResourceNotFound
+	}
+	custom, err := l.FindChannelsById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	names, err := l.ListChannelNames(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]ChannelItem, 0, len(names))
+	for _, name := range names {
+		sees, _ := resolveChannels(name, custom)
+		list = append(list, ChannelItem{
+			Name:     name,
+			Builtin:  isBuiltinChannel(name),
+			Includes: custom[name],
+			Sees:     sees,
+		})
+	}
+	return list, nil
+}
+
+// UpsertChannel every included channel has to exist and must not include the channel again
+func (l *ResourceLogic) UpsertChannel(ctx context.Context, id, name string, includes []string) error {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return err
+	} else if !exists {
+		return errs.ErrResourceNotFound
+	}
+	if isBuiltinChannel(name) {
+		return errs.ErrResourceInvalidChannel.WithDetails("built-in channels can't be changed")
+	}
+
+	custom, err := l.FindChannelsById(ctx, id)
+	if err != nil {
+		return err
+	}
+	custom[name] = includes
+	for _, inc := range includes {
+		sees, ok := resolveChannels(inc, custom)
+		if !ok {
+			return errs.ErrResourceInvalidChannel.WithDetails("unknown channel " + inc)
+		}
+		if slices.Contains(sees, name) {
+			return errs.ErrResourceInvalidChannel.WithDetails("channel " + inc + " already includes " + name)
+		}
+	}
+
+	if err := l.channelRepo.UpsertChannel(ctx, id, name, includes); err != nil {
+		l.logger.Error("failed to save channel",
+			zap.String("resource id", id),
+			zap.String("channel", name),
+			zap.Error(err),
+		)
+		return err
+	}
+	l.logger.Info("channel saved",
+		zap.String("resource id", id),
+		zap.String("channel", name),
+		zap.Strings("includes", includes),
+	)
+	// cached latest versions follow the includes
+	return l.evictResourceInfo(ctx, id)
+}
+
+// DeleteChannel only channels without versions which no other channel includes
+func (l *ResourceLogic) DeleteChannel(ctx context.Context, id, name string) error {
+	if isBuiltinChannel(name) {
+		return errs.ErrResourceInvalidChannel.WithDetails("built-in channels can't be deleted")
+	}
+	custom, err := l.FindChannelsById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, ok := custom[name]; !ok {
+		return errs.ErrResourceInvalidChannel
+	}
+	for c, includes := range custom {
+		if c != name && slices.Contains(includes, name) {
+			return errs.ErrResourceChannelInUse
+		}
+	}
+	n, err := l.channelRepo.CountChannelVersions(ctx, id, name)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return errs.ErrResourceChannelInUse
+	}
+
+	if _, err := l.channelRepo.DeleteChannel(ctx, id, name); err != nil {
+		return err
+	}
+	l.logger.Info("channel deleted",
+		zap.String("resource id", id),
+		zap.String("channel", name),
+	)
+	return l.evictResourceInfo(ctx, id)
+}
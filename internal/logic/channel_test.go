@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveChannels(t *testing.T) {
+	custom := map[string][]string{
+		"nightly":  {"alpha"},
+		"lts":      nil,
+		"customer": {"lts", "stable"},
+		"loop-a":   {"loop-b"},
+		"loop-b":   {"loop-a"},
+		"broken":   {"missing"},
+	}
+	tests := []struct {
+		channel string
+		want    []string
+		ok      bool
+	}{
+		{"stable", []string{"stable"}, true},
+		{"beta", []string{"stable", "beta"}, true},
+		{"alpha", []string{"stable", "beta", "alpha"}, true},
+		{"nightly", []string{"stable", "beta", "alpha", "nightly"}, true},
+		{"lts", []string{"lts"}, true},
+		{"customer", []string{"lts", "stable", "customer"}, true},
+		{"loop-a", []string{"loop-b", "loop-a"}, true},
+		{"broken", nil, false},
+		{"unknown", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := resolveChannels(tt.channel, custom)
+		assert.Equal(t, tt.ok, ok, tt.channel)
+		if tt.ok {
+			assert.Equal(t, tt.want, got, tt.channel)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package dispense
+
+import (
+	"context"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+)
+
+const (
+	BlobDistributorName = "blob"
+
+	defaultPresignTTL = 10 * time.Minute
+)
+
+// blobDistributor presigned urls straight from the object storage, e.g. a bucket without cdn in front
+type blobDistributor struct {
+	store blob.Store
+	ttl   time.Duration
+}
+
+func NewBlobDistributor(store blob.Store, ttl time.Duration) Distributor {
+	if ttl <= 0 {
+		ttl = defaultPresignTTL
+	}
+	return &blobDistributor{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+func (d *blobDistributor) Name() string {
+	return BlobDistributorName
+}
+
+func (d *blobDistributor) Distribute(info *model.DistributeInfo) (string, error) {
+	return d.store.Presign(context.Background(), info.RelPath, d.ttl)
+}
@@ -1,14 +1,20 @@
 package dispense
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/MirrorChyan/resource-backend/internal/config"
 	"github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 	"github.com/redis/go-redis/v9"
 	"github.com/segmentio/ksuid"
 	"go.uber.org/zap"
@@ -23,6 +29,8 @@ func NewDistributeLogic(
 	logger *zap.Logger,
 	rdb *redis.Client,
 ) *DistributeLogic {
+	reloadBalancer()
+	go mirrorHealth.Run(context.Background())
 	return &DistributeLogic{
 		logger: logger,
 		rdb:    rdb,
@@ -34,15 +42,202 @@ type Distributor interface {
 	Name() string
 }
 
-func (d *DistributeLogic) Name() string {
-	return "cdn"
+var (
+	ErrNoAvailableDistributor = errors.New("no available distributor")
+
+	errNoMirrorServer = errors.New("no mirror server")
+)
+
+// keys in the remote config which affect the distribution weights
+var balancerKeys = []string{
+	"extra.download_prefix_info",
+	"extra.distribute_cdn_ratio",
+	"extra.distribute_cdn_region",
+}
+
+var balancer atomic.Pointer[regionBalancer]
+
+func init() {
+	for _, key := range balancerKeys {
+		config.RegisterKeyListener(config.KeyListener{
+			Key: key,
+			Listener: func(any) {
+				reloadBalancer()
+			},
+		})
+	}
+}
+
+// regionBalancer splits traffic between the cdn and the mirrors of the current region
+type regionBalancer struct {
+	region string
+	target *Robin[Distributor]
+}
+
+func reloadBalancer() {
+	var (
+		cfg    = config.GConfig
+		region = strings.ToLower(cfg.Instance.RegionId)
+	)
+	if region == "" {
+		region = config.DefaultRegion
+	}
+
+	regional := make(map[string]Distributor)
+	for r, servers := range cfg.Extra.DownloadPrefixInfo {
+		regional[MirrorRegionName(r)] = newMirrorDistributor(servers)
+	}
+	local, ok := regional[MirrorRegionName(region)]
+	if !ok {
+		local = newMirrorDistributor(nil)
+	}
+	regional[MirrorDistributorName] = local
+	registry.replaceRegional(regional)
+
+	balancer.Store(newRegionBalancer(region, cfg.Extra, local.(*mirrorDistributor)))
+
+	zap.L().Info("distribute weights loaded",
+		zap.String("region", region),
+		zap.Int("mirrors", len(cfg.Extra.DownloadPrefixInfo[region])),
+		zap.Int("cdn ratio", cfg.Extra.DistributeCdnRatio),
+	)
+}
+
+func newRegionBalancer(region string, extra config.ExtraConfig, mirror *mirrorDistributor) *regionBalancer {
+	var ratio int
+	if slices.Contains(extra.DistributeCdnRegion, region) {
+		ratio = min(max(extra.DistributeCdnRatio, 0), 100)
+	}
+
+	target := NewRobin[Distributor]()
+	target.Add(&cdnDistributor{}, ratio)
+	if mirror.servers.Len() > 0 {
+		target.Add(mirror, 100-ratio)
+	}
+	if target.Len() == 0 {
+		// no mirror in the region and cdn disabled, cdn is still the last resort
+		target.Add(&cdnDistributor{}, 1)
+	}
+
+	return &regionBalancer{
+		region: region,
+		target: target,
+	}
+}
+
+// Distribute an empty policy uses the weighted split of the instance region,
+// otherwise the distributors of the policy are tried in order
+func (d *DistributeLogic) Distribute(info *model.DistributeInfo, policy types.DistributionPolicy) (string, error) {
+	b := balancer.Load()
+
+	if len(policy.Distributors) == 0 {
+		target, _ := b.target.Next()
+		url, err := target.Distribute(info)
+		if errors.Is(err, errNoMirrorServer) {
+			// every mirror of the region evicted
+			target = &cdnDistributor{}
+			url, err = target.Distribute(info)
+		}
+		if err != nil {
+			return "", err
+		}
+		d.record(target.Name(), b.region, info, url)
+		return url, nil
+	}
+
+	for _, name := range policy.Distributors {
+		target, ok := Lookup(name)
+		if !ok {
+			d.logger.Warn("distributor in policy not found",
+				zap.String("resource id", info.Resource),
+				zap.String("name", name),
+			)
+			continue
+		}
+		url, err := target.Distribute(info)
+		if err != nil {
+			d.logger.Warn("distributor unavailable try next",
+				zap.String("resource id", info.Resource),
+				zap.String("name", name),
+				zap.Error(err),
+			)
+			continue
+		}
+		d.record(name, b.region, info, url)
+		return url, nil
+	}
+
+	d.logger.Error("no distributor of the policy available",
+		zap.String("resource id", info.Resource),
+		zap.Strings("distributors", policy.Distributors),
+	)
+	return "", ErrNoAvailableDistributor
+}
+
+// ValidatePolicy every distributor of the policy must be known
+func (d *DistributeLogic) ValidatePolicy(policy types.DistributionPolicy) error {
+	for _, name := range policy.Distributors {
+		if _, ok := Lookup(name); !ok {
+			return fmt.Errorf("unknown distributor %s", name)
+		}
+	}
+	return nil
+}
+
+func (d *DistributeLogic) MirrorStatus() []model.MirrorStatus {
+	return mirrorHealth.Snapshot()
+}
+
+func (d *DistributeLogic) record(name, region string, info *model.DistributeInfo, url string) {
+	distributeCounter.WithLabelValues(name, region).Inc()
+	d.logger.Info("Distribute Use By",
+		zap.String("name", name),
+		zap.String("region", region),
+		zap.String("resource id", info.Resource),
+		zap.String("url", url),
+	)
+}
+
+type cdnDistributor struct {
 }
 
-func (d *DistributeLogic) Distribute(info *model.DistributeInfo) (string, error) {
-	d.logger.Info("Distribute Use By", zap.String("name", d.Name()))
+func (d *cdnDistributor) Name() string {
+	return CdnDistributorName
+}
+
+func (d *cdnDistributor) Distribute(info *model.DistributeInfo) (string, error) {
 	return getAuthURL(info), nil
 }
 
+type mirrorDistributor struct {
+	servers *Robin[config.RobinServer]
+}
+
+func newMirrorDistributor(servers []config.RobinServer) *mirrorDistributor {
+	r := NewRobin[config.RobinServer]()
+	for _, s := range servers {
+		r.Add(s, s.Weight)
+	}
+	return &mirrorDistributor{
+		servers: r,
+	}
+}
+
+func (d *mirrorDistributor) Name() string {
+	return MirrorDistributorName
+}
+
+// Distribute never falls back to the cdn, policies rely on it to keep resources off the cdn
+func (d *mirrorDistributor) Distribute(info *model.DistributeInfo) (string, error) {
+	s, ok := d.servers.NextMatch(func(s config.RobinServer) bool {
+		return mirrorHealth.Healthy(s.Url)
+	})
+	if !ok {
+		return "", errNoMirrorServer
+	}
+	return strings.TrimSuffix(s.Url, "/") + "/" + info.RelPath, nil
+}
+
 func getAuthURL(info *model.DistributeInfo) string {
 	prefix := config.GConfig.Extra.CdnPrefix
 	pk := config.GConfig.Auth.PrivateKey
@@ -0,0 +1,195 @@
+package dispense
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/config"
+	"github.com/MirrorChyan/resource-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultProbeInterval = 30 * time.Second
+	defaultProbeTimeout  = 5 * time.Second
+	defaultProbeFall     = 3
+	defaultProbeRise     = 2
+)
+
+// MirrorHealth keeps the probe state of every configured mirror,
+// mirrors never probed are treated as healthy
+type MirrorHealth struct {
+	mu     sync.RWMutex
+	status map[string]*model.MirrorStatus
+	client *http.Client
+}
+
+var mirrorHealth = NewMirrorHealth()
+
+func NewMirrorHealth() *MirrorHealth {
+	return &MirrorHealth{
+		status: make(map[string]*model.MirrorStatus),
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (h *MirrorHealth) Healthy(url string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	s, ok := h.status[url]
+	return !ok || s.Healthy
+}
+
+// Snapshot sorted by region and url
+func (h *MirrorHealth) Snapshot() []model.MirrorStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	list := make([]model.MirrorStatus, 0, len(h.status))
+	for _, s := range h.status {
+		list = append(list, *s)
+	}
+	slices.SortFunc(list, func(a, b model.MirrorStatus) int {
+		if c := strings.Compare(a.Region, b.Region); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Url, b.Url)
+	})
+	return list
+}
+
+// Run probes until ctx is done, the probe config is read every round so it follows remote updates
+func (h *MirrorHealth) Run(ctx context.Context) {
+	for {
+		conf := probeConfig()
+		if conf.Path != "" {
+			h.ProbeOnce(ctx, conf, config.GConfig.Extra.DownloadPrefixInfo)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(conf.Interval):
+		}
+	}
+}
+
+// ProbeOnce HEADs the probe object on every mirror concurrently
+func (h *MirrorHealth) ProbeOnce(ctx context.Context, conf config.MirrorProbeConfig, mirrors map[string][]config.RobinServer) {
+	var (
+		wg      sync.WaitGroup
+		present = make(map[string]struct{})
+	)
+	for region, servers := range mirrors {
+		for _, s := range servers {
+			present[s.Url] = struct{}{}
+			wg.Go(func() {
+				err := h.probe(ctx, conf, s.Url)
+				h.update(conf, region, s.Url, err)
+			})
+		}
+	}
+	wg.Wait()
+
+	// mirrors removed from the config
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for url, s := range h.status {
+		if _, ok := present[url]; !ok {
+			mirrorUpGauge.DeleteLabelValues(s.Region, url)
+			delete(h.status, url)
+		}
+	}
+}
+
+func (h *MirrorHealth) probe(ctx context.Context, conf config.MirrorProbeConfig, url string) error {
+	c, cancel := context.WithTimeout(ctx, conf.Timeout)
+	defer cancel()
+
+	target := strings.TrimSuffix(url, "/") + "/" + strings.TrimPrefix(conf.Path, "/")
+	req, err := http.NewRequestWithContext(c, http.MethodHead, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (h *MirrorHealth) update(conf config.MirrorProbeConfig, region, url string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.status[url]
+	if !ok {
+		s = &model.MirrorStatus{
+			Url:     url,
+			Healthy: true,
+		}
+		h.status[url] = s
+	}
+	s.Region = region
+	s.LastCheckedAt = time.Now()
+
+	if err != nil {
+		s.LastError = err.Error()
+		s.ConsecutiveSuccesses = 0
+		s.ConsecutiveFailures++
+		if s.Healthy && s.ConsecutiveFailures >= conf.Fall {
+			s.Healthy = false
+			zap.L().Warn("mirror evicted",
+				zap.String("region", region),
+				zap.String("url", url),
+				zap.Int("failures", s.ConsecutiveFailures),
+				zap.Error(err),
+			)
+		}
+	} else {
+		s.LastError = ""
+		s.ConsecutiveFailures = 0
+		s.ConsecutiveSuccesses++
+		if !s.Healthy && s.ConsecutiveSuccesses >= conf.Rise {
+			s.Healthy = true
+			zap.L().Info("mirror recovered",
+				zap.String("region", region),
+				zap.String("url", url),
+			)
+		}
+	}
+
+	var up float64
+	if s.Healthy {
+		up = 1
+	}
+	mirrorUpGauge.WithLabelValues(region, url).Set(up)
+}
+
+func probeConfig() config.MirrorProbeConfig {
+	conf := config.GConfig.Extra.MirrorProbe
+	if conf.Interval <= 0 {
+		conf.Interval = defaultProbeInterval
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultProbeTimeout
+	}
+	if conf.Fall <= 0 {
+		conf.Fall = defaultProbeFall
+	}
+	if conf.Rise <= 0 {
+		conf.Rise = defaultProbeRise
+	}
+	return conf
+}
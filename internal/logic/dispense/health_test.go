@@ -0,0 +1,87 @@
+package dispense
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorHealthEvictAndRecover(t *testing.T) {
+	var down atomic.Bool
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodHead, r.Method)
+		require.Equal(t, "/probe.bin", r.URL.Path)
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mirror.Close()
+
+	var (
+		ctx  = context.Background()
+		h    = NewMirrorHealth()
+		conf = config.MirrorProbeConfig{
+			Path:    "probe.bin",
+			Timeout: time.Second,
+			Fall:    2,
+			Rise:    3,
+		}
+		mirrors = map[string][]config.RobinServer{
+			"default": {{Url: mirror.URL, Weight: 1}},
+		}
+	)
+
+	h.ProbeOnce(ctx, conf, mirrors)
+	require.True(t, h.Healthy(mirror.URL))
+
+	down.Store(true)
+	h.ProbeOnce(ctx, conf, mirrors)
+	require.True(t, h.Healthy(mirror.URL), "a single failure must not evict")
+	h.ProbeOnce(ctx, conf, mirrors)
+	require.False(t, h.Healthy(mirror.URL))
+
+	snapshot := h.Snapshot()
+	require.Len(t, snapshot, 1)
+	require.Equal(t, "default", snapshot[0].Region)
+	require.Equal(t, 2, snapshot[0].ConsecutiveFailures)
+	require.NotEmpty(t, snapshot[0].LastError)
+
+	down.Store(false)
+	for range conf.Rise - 1 {
+		h.ProbeOnce(ctx, conf, mirrors)
+		require.False(t, h.Healthy(mirror.URL))
+	}
+	h.ProbeOnce(ctx, conf, mirrors)
+	require.True(t, h.Healthy(mirror.URL))
+
+	// removed from the config
+	h.ProbeOnce(ctx, conf, map[string][]config.RobinServer{})
+	require.Empty(t, h.Snapshot())
+}
+
+func TestRobinSkipsRejectedPeers(t *testing.T) {
+	r := NewRobin[string]()
+	r.Add("a", 1)
+	r.Add("b", 1)
+
+	for range 4 {
+		v, ok := r.NextMatch(func(s string) bool {
+			return s != "a"
+		})
+		require.True(t, ok)
+		require.Equal(t, "b", v)
+	}
+
+	_, ok := r.NextMatch(func(string) bool {
+		return false
+	})
+	require.False(t, ok)
+}
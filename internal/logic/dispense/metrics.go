@@ -0,0 +1,18 @@
+package dispense
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var distributeCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "resource_backend",
+	Name:      "distribute_total",
+	Help:      "Number of download urls handed out, by distributor and region",
+}, []string{"distributor", "region"})
+
+var mirrorUpGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "resource_backend",
+	Name:      "mirror_up",
+	Help:      "Whether a download mirror passes the health probe (1) or is evicted (0)",
+}, []string{"region", "url"})
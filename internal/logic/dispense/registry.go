@@ -0,0 +1,58 @@
+package dispense
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	CdnDistributorName    = "cdn"
+	MirrorDistributorName = "mirror"
+
+	// regionSeparator mirror:<region> pins the mirrors of another region
+	regionSeparator = ":"
+)
+
+// Registry named distributors which can be referenced by a resource distribution policy
+type Registry struct {
+	mu    sync.RWMutex
+	named map[string]Distributor
+	// rebuilt on every config reload
+	regional map[string]Distributor
+}
+
+var registry = &Registry{
+	named:    make(map[string]Distributor),
+	regional: make(map[string]Distributor),
+}
+
+// Register custom distributor, use in init method
+func Register(d Distributor) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.named[d.Name()] = d
+}
+
+func Lookup(name string) (Distributor, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	if d, ok := registry.regional[name]; ok {
+		return d, true
+	}
+	d, ok := registry.named[name]
+	return d, ok
+}
+
+func MirrorRegionName(region string) string {
+	return strings.Join([]string{MirrorDistributorName, region}, regionSeparator)
+}
+
+func (r *Registry) replaceRegional(m map[string]Distributor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.regional = m
+}
+
+func init() {
+	Register(&cdnDistributor{})
+}
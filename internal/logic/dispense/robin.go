@@ -0,0 +1,72 @@
+package dispense
+
+import "sync"
+
+// Robin smooth weighted round-robin (the nginx upstream algorithm),
+// peers with a higher weight are picked more often but never in long bursts
+type Robin[T any] struct {
+	mu    sync.Mutex
+	peers []*peer[T]
+}
+
+type peer[T any] struct {
+	val     T
+	weight  int
+	current int
+}
+
+func NewRobin[T any]() *Robin[T] {
+	return &Robin[T]{}
+}
+
+// Add non-positive weights are ignored
+func (r *Robin[T]) Add(val T, weight int) {
+	if weight <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.peers = append(r.peers, &peer[T]{
+		val:    val,
+		weight: weight,
+	})
+}
+
+func (r *Robin[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.peers)
+}
+
+// Next returns false when no peer is available
+func (r *Robin[T]) Next() (T, bool) {
+	return r.NextMatch(nil)
+}
+
+// NextMatch peers rejected by accept are skipped without gaining weight,
+// so they rejoin the rotation smoothly once accepted again
+func (r *Robin[T]) NextMatch(accept func(T) bool) (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		best  *peer[T]
+		total int
+	)
+	for _, p := range r.peers {
+		if accept != nil && !accept(p.val) {
+			continue
+		}
+		p.current += p.weight
+		total += p.weight
+		if best == nil || p.current > best.current {
+			best = p
+		}
+	}
+	if best == nil {
+		var zero T
+		return zero, false
+	}
+	best.current -= total
+	return best.val, true
+}
@@ -0,0 +1,60 @@
+package dispense
+
+import (
+	"testing"
+
+	"github.com/MirrorChyan/resource-backend/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRobinSmoothOrder(t *testing.T) {
+	r := NewRobin[string]()
+	r.Add("a", 5)
+	r.Add("b", 1)
+	r.Add("c", 1)
+	r.Add("ignored", 0)
+
+	var got []string
+	for range 7 {
+		v, ok := r.Next()
+		require.True(t, ok)
+		got = append(got, v)
+	}
+	require.Equal(t, []string{"a", "a", "b", "a", "c", "a", "a"}, got)
+}
+
+func TestRobinEmpty(t *testing.T) {
+	r := NewRobin[string]()
+	_, ok := r.Next()
+	require.False(t, ok)
+}
+
+func TestRegionBalancerRatio(t *testing.T) {
+	extra := config.ExtraConfig{
+		DistributeCdnRatio:  70,
+		DistributeCdnRegion: []string{"default"},
+		DownloadPrefixInfo: map[string][]config.RobinServer{
+			"default": {{Url: "https://m1.example", Weight: 1}},
+			"hk":      {{Url: "https://m2.example", Weight: 1}},
+		},
+	}
+
+	count := func(b *regionBalancer) map[string]int {
+		m := make(map[string]int)
+		for range 100 {
+			d, _ := b.target.Next()
+			m[d.Name()]++
+		}
+		return m
+	}
+
+	balance := func(region string) *regionBalancer {
+		return newRegionBalancer(region, extra, newMirrorDistributor(extra.DownloadPrefixInfo[region]))
+	}
+
+	require.Equal(t, map[string]int{"cdn": 70, "mirror": 30}, count(balance("default")))
+	// region not in distribute_cdn_region only uses its mirrors
+	require.Equal(t, map[string]int{"mirror": 100}, count(balance("hk")))
+	// region without mirrors falls back to cdn
+	require.Equal(t, map[string]int{"cdn": 100}, count(balance("kr")))
+}
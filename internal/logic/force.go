@@ -0,0 +1,112 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUnsupportedReason = "current version is no longer supported"
+	defaultCriticalReason    = "critical update"
+)
+
+// SetCritical clients on older versions of the channel are forced to update past a critical version
+func (l *VersionLogic) SetCritical(ctx context.Context, resourceId, versionName string, critical bool, reason string) error {
+	ver, err := l.versionRepo.GetVersionByName(ctx, resourceId, versionName)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errs.ErrResourceVersionNotFound
+		}
+		return err
+	}
+	if !critical {
+		reason = ""
+	}
+	if err := l.versionRepo.SetCritical(ctx, ver.ID, critical, reason); err != nil {
+		return err
+	}
+	l.logger.Info("version critical changed",
+		zap.String("resource id", resourceId),
+		zap.String("version name", versionName),
+		zap.Bool("critical", critical),
+		zap.String("reason", reason),
+	)
+	l.doEvictVersionInfo(ctx, resourceId, "critical")
+	return nil
+}
+
+func (l *VersionLogic) doListCriticalVersions(ctx context.Context, resourceId, channel string) ([]CriticalVersion, error) {
+	channels, err := l.resourceLogic.ResolveChannelChain(ctx, resourceId, channel)
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.versionRepo.ListCriticalVersions(ctx, resourceId, channels)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CriticalVersion, 0, len(list))
+	for _, v := range list {
+		result = append(result, CriticalVersion{Name: v.Name, Reason: v.CriticalReason})
+	}
+	return result, nil
+}
+
+// GetForceUpdate whether a client on currentVersion has to update to target and why
+func (l *VersionLogic) GetForceUpdate(ctx context.Context, resourceId, os, arch, channel, currentVersion, target string) (bool, string, error) {
+	if currentVersion == "" || currentVersion == target {
+		return false, "", nil
+	}
+	policy, err := l.resourceLogic.FindSupportPolicyById(ctx, resourceId)
+	if err != nil {
+		return false, "", err
+	}
+	val, err := l.loadMultiVersionInfo(resourceId, os, arch, channel)
+	if err != nil {
+		return false, "", err
+	}
+	var minVersion *types.MinVersion
+	if m, ok := policy.MinVersions[channel]; ok {
+		minVersion = &m
+	}
+	comparator, _, err := l.resourceLogic.ComparatorOf(ctx, resourceId)
+	if err != nil {
+		return false, "", err
+	}
+	reason, force := findForceReason(comparator, currentVersion, target, minVersion, val.Critical)
+	return force, reason, nil
+}
+
+// findForceReason current is below the minimum version or below a critical version the target includes,
+// names which can't be compared never force an update
+func findForceReason(c *vercomp.VersionComparator, current, target string, minVersion *types.MinVersion, critical []CriticalVersion) (string, bool) {
+	less := func(a, b string) bool {
+		r := c.Compare(a, b)
+		return r.Comparable && r.Result == vercomp.Less
+	}
+	if minVersion != nil && less(current, minVersion.Version) {
+		if minVersion.Reason == "" {
+			return defaultUnsupportedReason, true
+		}
+		return minVersion.Reason, true
+	}
+	for _, v := range critical {
+		if !less(current, v.Name) {
+			continue
+		}
+		// a critical version which isn't offered yet can't be updated to
+		if r := c.Compare(v.Name, target); !r.Comparable || r.Result == vercomp.Greater {
+			continue
+		}
+		if v.Reason == "" {
+			return defaultCriticalReason + " " + v.Name, true
+		}
+		return v.Reason, true
+	}
+	return "", false
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"testing"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindForceReason(t *testing.T) {
+	var (
+		c        = vercomp.NewComparator()
+		minVer   = &types.MinVersion{Version: "1.1.0", Reason: "security fix"}
+		critical = []CriticalVersion{
+			{Name: "1.3.0", Reason: "data loss"},
+			{Name: "1.5.0"},
+		}
+	)
+	tests := []struct {
+		name    string
+		current string
+		target  string
+		min     *types.MinVersion
+		want    string
+		force   bool
+	}{
+		{"below minimum", "1.0.0", "1.4.0", minVer, "security fix", true},
+		{"minimum without reason", "1.0.0", "1.4.0", &types.MinVersion{Version: "1.1.0"}, defaultUnsupportedReason, true},
+		{"below critical", "1.2.0", "1.4.0", minVer, "data loss", true},
+		{"critical not offered", "1.3.0", "1.4.0", minVer, "", false},
+		{"critical without reason", "1.4.0", "1.5.0", nil, defaultCriticalReason + " 1.5.0", true},
+		{"on critical", "1.5.0", "1.6.0", nil, "", false},
+		{"not comparable", "nightly", "1.4.0", minVer, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, force := findForceReason(c, tt.current, tt.target, tt.min, critical)
+			assert.Equal(t, tt.force, force)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
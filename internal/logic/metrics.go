@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	decisionFull          = "full"
+	decisionIncremental   = "incremental"
+	decisionPatchTooLarge = "patch_too_large"
+	decisionChain         = "chain"
+)
+
+var updateDecisionCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "resource_backend",
+	Name:      "update_decision_total",
+	Help:      "Number of update requests answered, by resource and served package decision",
+}, []string{"resource", "decision"})
+
+const (
+	scrubVerified = "verified"
+	scrubMismatch = "mismatch"
+	scrubMissing  = "missing"
+	scrubError    = "error"
+)
+
+var scrubCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "resource_backend",
+	Name:      "scrub_total",
+	Help:      "Number of packages re-hashed by the integrity scrubber, by result",
+}, []string{"result"})
+
+var casDedupCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "resource_backend",
+	Name:      "package_dedup_total",
+	Help:      "Number of uploaded full packages whose content was already stored",
+})
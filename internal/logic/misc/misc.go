@@ -28,12 +28,23 @@ const (
 	GenerateTagKey           = "generate"
 	LoadStoreNewVersionKey   = "LoadStoreNewVersionTx"
 	ProcessStoragePendingKey = "ProcessStoragePending"
+	UploadSessionKey         = "upload:session"
+	ContentAddressedLockKey  = "cas:lock"
+)
+
+// PurgeReportKey <key>:<id> a purge report, PurgeReportListKey ids of the recent reports, newest first
+const (
+	PurgeReportKey     = "purge:report"
+	PurgeReportListKey = "purge:reports"
 )
 
 const (
 	ProcessFlag = "1"
 )
 
+// VersionRequestKeyPrefix <prefix><resourceId>:<date> sorted set, current version -> requests that day
+const VersionRequestKeyPrefix = "sort:resources:version:"
+
 // StatusPollingPrefix status polling
 const StatusPollingPrefix = "status:polling"
 
@@ -69,12 +80,21 @@ const (
 	ProcessStorageTask = "storage"
 	DiffTask           = "diff"
 	PurgeTask          = "purge"
+	ReconcileTask      = "reconcile"
+	ScrubTask          = "scrub"
+	PublishTask        = "publish"
 )
 
 const (
 	VersionPrefix = "ver"
 )
 
+// event of the new version webhook
+const (
+	VersionCreatedEvent  = "created"
+	VersionPromotedEvent = "promoted"
+)
+
 const SniffLen = 4
 
 var (
@@ -88,6 +108,8 @@ var (
 	NotAllowedFileTypeError = errs.NewUnchecked("not allowed file type")
 
 	ResourceLimitError = errs.NewUnchecked("your cdkey has reached the most downloads today").WithHttpCode(fiber.StatusForbidden)
+
+	DistributeKeyNotFoundError = errs.NewUnchecked("resource not found").WithHttpCode(fiber.StatusNotFound)
 )
 
 var (
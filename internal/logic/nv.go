@@ -10,15 +10,41 @@ import (
 	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
 	. "github.com/MirrorChyan/resource-backend/internal/model"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/patcher"
 	"github.com/bytedance/sonic"
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
 )
 
+func (l *VersionLogic) getVersionIdByName(ctx context.Context, resourceId, name string) (int, error) {
+	cg := l.GetCacheGroup()
+	vid, err := cg.VersionNameIdCache.ComputeIfAbsent(cg.GetCacheKey(resourceId, name), func() (int, error) {
+		v, err := l.versionRepo.GetVersionByName(ctx, resourceId, name)
+		if err != nil {
+			return 0, err
+		}
+		return v.ID, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return *vid, nil
+}
+
+// IsKnownVersion whether the resource has a version of the name, client supplied names are checked before they are counted
+func (l *VersionLogic) IsKnownVersion(ctx context.Context, resourceId, name string) (bool, error) {
+	_, err := l.getVersionIdByName(ctx, resourceId, name)
+	switch {
+	case err == nil:
+		return true, nil
+	case ent.IsNotFound(err):
+		return false, nil
+	}
+	return false, err
+}
+
 func (l *VersionLogic) doProcessUpdateRequest(ctx context.Context, param UpdateRequestParam) (*UpdateInfoTuple, error) {
 	var (
-		cg = l.GetCacheGroup()
-
 		resourceId         = param.ResourceId
 		currentVersionName = param.CurrentVersionName
 		currentVersionId   int
@@ -36,28 +62,23 @@ func (l *VersionLogic) doProcessUpdateRequest(ctx context.Context, param UpdateR
 		return full, nil
 	}
 
-	var ck = cg.GetCacheKey(resourceId, currentVersionName)
-	vid, err := cg.VersionNameIdCache.ComputeIfAbsent(ck, func() (int, error) {
-		v, err := l.versionRepo.GetVersionByName(ctx, resourceId, currentVersionName)
-		if err != nil {
-			return 0, err
-		}
-		return v.ID, nil
-	})
+	vid, err := l.getVersionIdByName(ctx, resourceId, currentVersionName)
 	switch {
 	case err == nil:
-		currentVersionId = *vid
+		currentVersionId = vid
 	case !ent.IsNotFound(err):
 		return nil, err
 	default:
 		return full, nil
 	}
 
+	format := patcher.NormalizeFormat(param.PatchFormat)
 	incremental, err := l.getIncrementalInfoOrEmpty(ctx,
 		targetInfo.VersionId,
 		currentVersionId,
 		targetInfo.OS,
 		targetInfo.Arch,
+		format,
 	)
 	if err != nil {
 		l.logger.Error("failed to get incremental update info",
@@ -68,70 +89,160 @@ func (l *VersionLogic) doProcessUpdateRequest(ctx context.Context, param UpdateR
 		)
 		return nil, err
 	}
+	policy, err := l.resourceLogic.FindPatchPolicyById(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := full
 	if incremental.Storage != nil {
 		s := incremental.Storage
-		return &UpdateInfoTuple{
+		tuple := &UpdateInfoTuple{
 			PackageHash: s.PackageHashSha256,
 			PackagePath: s.PackagePath,
 			UpdateType:  types.UpdateIncremental.String(),
 			Filesize:    s.FileSize,
-		}, nil
+		}
+
+		ratio := patchSizeRatio(s.FileSize, targetInfo.FileSize)
+		tooLarge := ratio > policy.SizeRatio()
+		if tooLarge {
+			l.logger.Info("incremental package too large serve full update",
+				zap.String("resource id", resourceId),
+				zap.Int("target version id", targetInfo.VersionId),
+				zap.Int("current version id", currentVersionId),
+				zap.Int("patch format", s.PatchFormat),
+				zap.Int64("patch size", s.FileSize),
+				zap.Int64("full size", targetInfo.FileSize),
+				zap.Float64("ratio", ratio),
+				zap.Float64("threshold", policy.SizeRatio()),
+			)
+		}
+
+		if s.PatchFormat >= format {
+			// no new diff work either, a newer patch of the same pair and format would not be smaller
+			if tooLarge {
+				updateDecisionCounter.WithLabelValues(resourceId, decisionPatchTooLarge).Inc()
+				return full, nil
+			}
+			updateDecisionCounter.WithLabelValues(resourceId, decisionIncremental).Inc()
+			return tuple, nil
+		}
+
+		// an older format still beats the full package, generate the newer one meanwhile,
+		// the newer format may be small enough even when the older one isn't
+		if !tooLarge {
+			fallback = tuple
+		}
 	}
 
+	if fallback == full && param.PatchChain {
+		chain, err := l.getPatchChainOrEmpty(ctx, resourceId,
+			targetInfo.VersionId, currentVersionId,
+			targetInfo.OS, targetInfo.Arch,
+			format,
+		)
+		if err != nil {
+			l.logger.Error("failed to find patch chain",
+				zap.String("resource id", resourceId),
+				zap.Int("target version id", targetInfo.VersionId),
+				zap.Int("current version id", currentVersionId),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		if len(chain.Edges) > 0 && patchSizeRatio(chain.Size, targetInfo.FileSize) <= policy.SizeRatio() {
+			// the direct patch is still generated below
+			fallback = &UpdateInfoTuple{
+				UpdateType: types.UpdateChain.String(),
+				Filesize:   chain.Size,
+				Chain:      chain.Edges,
+			}
+		}
+	}
+
+	decision := decisionIncremental
+	if fallback.Chain != nil {
+		decision = decisionChain
+	}
+	if fallback == full {
+		l.logger.Info("incremental fallback to full update",
+			zap.String("resourceId", resourceId),
+			zap.Int("currentVersionId", currentVersionId),
+			zap.Int("targetVersionId", targetInfo.VersionId),
+		)
+		decision = decisionFull
+	}
+	updateDecisionCounter.WithLabelValues(resourceId, decision).Inc()
+
+	if _, err := l.submitDiffTask(ctx, PatchTaskPayload{
+		ResourceId:       resourceId,
+		CurrentVersionId: currentVersionId,
+		TargetVersionId:  targetInfo.VersionId,
+		OS:               targetInfo.OS,
+		Arch:             targetInfo.Arch,
+		PatchFormat:      format,
+	}); err != nil {
+		return nil, err
+	}
+
+	return fallback, nil
+}
+
+// submitDiffTask enqueues the generation of an incremental package once per version pair and format
+func (l *VersionLogic) submitDiffTask(ctx context.Context, payload PatchTaskPayload) (bool, error) {
 	var (
-		targetVersion  = strconv.Itoa(targetInfo.VersionId)
-		currentVersion = strconv.Itoa(currentVersionId)
+		targetVersion  = strconv.Itoa(payload.TargetVersionId)
+		currentVersion = strconv.Itoa(payload.CurrentVersionId)
 		key            = strings.Join([]string{misc.GenerateTagKey,
-			strings.Join([]string{resourceId, targetInfo.OS, targetInfo.Arch}, "-"),
+			strings.Join([]string{payload.ResourceId, payload.OS, payload.Arch}, "-"),
 			targetVersion, currentVersion,
 		}, ":")
 	)
-
-	l.logger.Info("incremental fallback to full update",
-		zap.String("resourceId", resourceId),
-		zap.Int("currentVersionId", currentVersionId),
-		zap.Int("targetVersionId", targetInfo.VersionId),
-	)
+	if payload.PatchFormat > patcher.FormatFull {
+		key = strings.Join([]string{key, strconv.Itoa(payload.PatchFormat)}, ":")
+	}
 
 	result := l.rdb.SetNX(ctx, key, 1, time.Hour*168)
 	if err := result.Err(); err != nil {
-		return nil, err
+		return false, err
 	}
 	if !result.Val() {
-		return full, nil
+		return false, nil
 	}
 
 	rollback := func() {
 		l.rdb.Del(ctx, key)
 	}
 
-	payload, err := sonic.Marshal(PatchTaskPayload{
-		ResourceId:       resourceId,
-		CurrentVersionId: currentVersionId,
-		TargetVersionId:  targetInfo.VersionId,
-		OS:               targetInfo.OS,
-		Arch:             targetInfo.Arch,
-	})
-
+	buf, err := sonic.Marshal(payload)
 	if err != nil {
 		rollback()
-		return nil, err
+		return false, err
 	}
 
-	task := asynq.NewTask(misc.DiffTask, payload, asynq.MaxRetry(5))
+	task := asynq.NewTask(misc.DiffTask, buf, asynq.MaxRetry(5))
 	submitted, err := l.taskQueue.Enqueue(task)
 	if err != nil {
 		rollback()
-		return nil, err
+		return false, err
 	}
 	l.logger.Info("submit generate incremental update package task success",
-		zap.String("resource id", resourceId),
+		zap.String("resource id", payload.ResourceId),
 		zap.String("target version", targetVersion),
 		zap.String("current version", currentVersion),
 		zap.String("task id", submitted.ID),
+		zap.Int("patch format", payload.PatchFormat),
 	)
+	return true, nil
+}
 
-	return full, nil
+// patchSizeRatio 0 when the full package size is unknown
+func patchSizeRatio(patch, full int64) float64 {
+	if full <= 0 {
+		return 0
+	}
+	return float64(patch) / float64(full)
 }
 
 func (l *VersionLogic) GetUpdateInfo(ctx context.Context, param UpdateRequestParam) (*UpdateInfo, error) {
@@ -140,6 +251,17 @@ func (l *VersionLogic) GetUpdateInfo(ctx context.Context, param UpdateRequestPar
 		return nil, err
 	}
 
+	var chain []PatchHop
+	for _, e := range result.Chain {
+		chain = append(chain, PatchHop{
+			FromVersion: e.CurrentVersionName,
+			ToVersion:   e.TargetVersionName,
+			RelPath:     l.cleanTwiceStoragePath(e.PackagePath),
+			SHA256:      e.PackageHash,
+			Filesize:    e.FileSize,
+		})
+	}
+
 	rel := l.cleanTwiceStoragePath(result.PackagePath)
 
 	return &UpdateInfo{
@@ -147,5 +269,6 @@ func (l *VersionLogic) GetUpdateInfo(ctx context.Context, param UpdateRequestPar
 		SHA256:     result.PackageHash,
 		UpdateType: result.UpdateType,
 		Filesize:   result.Filesize,
+		Chain:      chain,
 	}, nil
 }
@@ -0,0 +1,154 @@
+package logic
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/patcher"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// telemetry window used to rank the versions clients are on
+	popularVersionDays = 7
+	// members read from each daily ranking
+	popularVersionScan = 256
+)
+
+// doPregeneratePatches enqueues patches to a freshly stored platform package from the versions most clients run,
+// so the first wave after a release doesn't fall back to full packages, only versions with a full package
+// of the same platform can be diffed against
+func (l *VersionLogic) doPregeneratePatches(ctx context.Context, resourceId string, target int, system, arch string) {
+	ut, err := l.resourceLogic.FindUpdateTypeById(ctx, resourceId)
+	if err != nil || ut != types.UpdateIncremental {
+		return
+	}
+	policy, err := l.resourceLogic.FindPatchPolicyById(ctx, resourceId)
+	if err != nil || policy.PregenerateCount <= 0 {
+		return
+	}
+	n := policy.PregenerateCount
+
+	var (
+		source     = "telemetry"
+		candidates []*ent.Version
+	)
+	names, err := l.rankPopularVersions(ctx, resourceId)
+	if err != nil {
+		l.logger.Warn("failed to rank popular versions, fallback to recent versions",
+			zap.String("resource id", resourceId),
+			zap.Error(err),
+		)
+	}
+	for _, name := range names {
+		if len(candidates) == n {
+			break
+		}
+		v, err := l.versionRepo.GetVersionByName(ctx, resourceId, name)
+		// versions created after the target are never updated from
+		if err != nil || v.ID >= target {
+			continue
+		}
+		if _, err := l.storageLogic.storageRepo.GetFullUpdateStorage(ctx, v.ID, system, arch); err != nil {
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+	if len(candidates) == 0 {
+		source = "recent"
+		recent, err := l.versionRepo.ListRecentPlatformVersions(ctx, resourceId, system, arch, target, n)
+		if err != nil {
+			l.logger.Error("failed to list recent versions",
+				zap.String("resource id", resourceId),
+				zap.String("os", system),
+				zap.String("arch", arch),
+				zap.Error(err),
+			)
+			return
+		}
+		candidates = recent
+	}
+
+	var submitted []string
+	for _, v := range candidates {
+		// the format every client understands, newer formats are still generated on demand
+		ok, err := l.submitDiffTask(ctx, PatchTaskPayload{
+			ResourceId:       resourceId,
+			CurrentVersionId: v.ID,
+			TargetVersionId:  target,
+			OS:               system,
+			Arch:             arch,
+			PatchFormat:      patcher.FormatFull,
+		})
+		if err != nil {
+			l.logger.Error("failed to submit pre-generate patch task",
+				zap.String("resource id", resourceId),
+				zap.Int("target version id", target),
+				zap.Int("current version id", v.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if ok {
+			submitted = append(submitted, v.Name)
+		}
+	}
+
+	l.logger.Info("pre-generate patches submitted",
+		zap.String("resource id", resourceId),
+		zap.Int("target version id", target),
+		zap.String("os", system),
+		zap.String("arch", arch),
+		zap.String("source", source),
+		zap.Strings("from versions", submitted),
+	)
+}
+
+// rankPopularVersions version names by requests over the telemetry window, most requested first
+func (l *VersionLogic) rankPopularVersions(ctx context.Context, resourceId string) ([]string, error) {
+	scores, err := countVersionRequests(ctx, l.rdb, resourceId, popularVersionScan)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if c := cmp.Compare(scores[b], scores[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return names, nil
+}
+
+// countVersionRequests update checks per current version name over the telemetry window,
+// scan limits the members read from each daily ranking, 0 reads all of them
+func countVersionRequests(ctx context.Context, rdb *redis.Client, resourceId string, scan int64) (map[string]float64, error) {
+	var (
+		now    = time.Now()
+		scores = make(map[string]float64)
+	)
+	for d := range popularVersionDays {
+		key := misc.VersionRequestKeyPrefix + resourceId + ":" + now.AddDate(0, 0, -d).Format("20060102")
+		zs, err := rdb.ZRevRangeWithScores(ctx, key, 0, scan-1).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range zs {
+			if name, ok := z.Member.(string); ok {
+				scores[name] += z.Score
+			}
+		}
+	}
+	return scores, nil
+}
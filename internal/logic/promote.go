@@ -0,0 +1,134 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"go.uber.org/zap"
+)
+
+// PromoteVersion moves the version into another channel, or clones it there under a new name,
+// the packages already stored for it are reused
+func (l *VersionLogic) PromoteVersion(ctx context.Context, param PromoteVersionParam) (*ent.Version, error) {
+	channel, err := l.resourceLogic.ResolveChannel(ctx, param.ResourceId, param.Channel)
+	if err != nil {
+		return nil, err
+	}
+	ver, err := l.versionRepo.GetVersionByName(ctx, param.ResourceId, param.VersionName)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errs.ErrResourceVersionNotFound
+		}
+		return nil, err
+	}
+
+	list, err := l.storageLogic.storageRepo.ListServedStorages(ctx, ver.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoted *ent.Version
+	if param.CloneName == "" {
+		promoted, err = l.doMoveVersion(ctx, param.ResourceId, ver, channel)
+	} else {
+		promoted, err = l.doCloneVersion(ctx, param.ResourceId, ver, channel, param.CloneName, list)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l.logger.Info("version promoted",
+		zap.String("resource id", param.ResourceId),
+		zap.String("version name", ver.Name),
+		zap.String("from", ver.Channel),
+		zap.String("to", channel),
+		zap.String("promoted version name", promoted.Name),
+		zap.Int("storages", len(list)),
+	)
+
+	l.doEvictVersionInfo(ctx, param.ResourceId, "promote")
+	for _, s := range list {
+		if s.UpdateType == storage.UpdateTypeFull {
+			go l.doWebhookNotify(misc.VersionPromotedEvent, param.ResourceId, promoted.Name, channel, s.Os, s.Arch)
+		}
+	}
+	return promoted, nil
+}
+
+func (l *VersionLogic) doMoveVersion(ctx context.Context, resourceId string, ver *ent.Version, channel string) (*ent.Version, error) {
+	if ver.Channel == channel {
+		return nil, errs.ErrInvalidParams.WithDetails("version is already in the channel")
+	}
+	if err := l.versionRepo.SetVersionChannel(ctx, ver.ID, channel); err != nil {
+		l.logger.Error("failed to move version",
+			zap.String("resource id", resourceId),
+			zap.Int("version id", ver.ID),
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	ver.Channel = channel
+	return ver, nil
+}
+
+// doCloneVersion the clone shares the full packages by content and the patches leading to the version
+func (l *VersionLogic) doCloneVersion(ctx context.Context, resourceId string, ver *ent.Version, channel, name string, list []*ent.Storage) (*ent.Version, error) {
+	_, err := l.versionRepo.GetVersionByName(ctx, resourceId, name)
+	switch {
+	case err == nil:
+		return nil, errs.ErrResourceVersionNameConflict
+	case !ent.IsNotFound(err):
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errs.ErrStorageNotFound
+	}
+	if err := l.doValidateVersionName(ctx, resourceId, channel, name); err != nil {
+		return nil, err
+	}
+
+	for _, s := range list {
+		if s.UpdateType != storage.UpdateTypeFull {
+			continue
+		}
+		// purging the version removes its directory, the clone must not depend on it
+		s.PackagePath, err = l.storageLogic.shareFullPackage(ctx, s)
+		if err != nil {
+			l.logger.Error("failed to share full package",
+				zap.String("resource id", resourceId),
+				zap.Int("storage id", s.ID),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	number, err := l.GetVersionNumber(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	var clone *ent.Version
+	err = l.repo.WithTx(ctx, func(tx *ent.Tx) (err error) {
+		clone, err = l.versionRepo.CloneVersion(ctx, tx, resourceId, ver, channel, name, number)
+		if err != nil {
+			return err
+		}
+		return l.storageLogic.storageRepo.CloneStorages(ctx, tx, list, clone.ID)
+	})
+	if err != nil {
+		l.logger.Error("failed to clone version",
+			zap.String("resource id", resourceId),
+			zap.Int("version id", ver.ID),
+			zap.String("channel", channel),
+			zap.String("clone name", name),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return clone, nil
+}
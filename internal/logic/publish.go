@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/bytedance/sonic"
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+// SchedulePublish keeps the version out of the latest version until at, nil publishes it now
+func (l *VersionLogic) SchedulePublish(ctx context.Context, resourceId, versionName string, at *time.Time) error {
+	ver, err := l.versionRepo.GetVersionByName(ctx, resourceId, versionName)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errs.ErrResourceVersionNotFound
+		}
+		return err
+	}
+	return l.doSchedulePublish(ctx, resourceId, ver.ID, at)
+}
+
+func (l *VersionLogic) doSchedulePublish(ctx context.Context, resourceId string, versionId int, at *time.Time) error {
+	if err := l.versionRepo.SetPublishAt(ctx, versionId, at); err != nil {
+		return err
+	}
+	l.logger.Info("version publish time changed",
+		zap.String("resource id", resourceId),
+		zap.Int("version id", versionId),
+		zap.Timep("publish at", at),
+	)
+	// a published version may have been pushed back
+	l.doEvictVersionInfo(ctx, resourceId, "publish")
+
+	if at == nil || !at.After(time.Now()) {
+		return nil
+	}
+	// cached latest versions don't expire in time on their own, a rescheduled release leaves a harmless extra evict
+	buf, err := sonic.Marshal(PublishTaskPayload{
+		ResourceId: resourceId,
+		VersionId:  versionId,
+	})
+	if err != nil {
+		return err
+	}
+	task := asynq.NewTask(misc.PublishTask, buf, asynq.ProcessAt(*at), asynq.MaxRetry(5))
+	submitted, err := l.taskQueue.Enqueue(task)
+	if err != nil {
+		l.logger.Error("failed to schedule release",
+			zap.String("resource id", resourceId),
+			zap.Int("version id", versionId),
+			zap.Error(err),
+		)
+		return err
+	}
+	l.logger.Info("release scheduled",
+		zap.String("resource id", resourceId),
+		zap.Int("version id", versionId),
+		zap.Time("publish at", *at),
+		zap.String("task id", submitted.ID),
+	)
+	return nil
+}
+
+// ListScheduledReleases versions waiting for their publish time
+func (l *VersionLogic) ListScheduledReleases(ctx context.Context) ([]*ent.Version, error) {
+	return l.versionRepo.ListScheduledVersions(ctx, time.Now())
+}
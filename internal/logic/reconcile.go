@@ -0,0 +1,272 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/cache"
+	"github.com/MirrorChyan/resource-backend/internal/config"
+	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultOrphanGracePeriod = 72 * time.Hour
+
+	orphanTreeOSS   = "oss"
+	orphanTreeLocal = "local"
+)
+
+// ReconcileStorage compares the oss and local package trees with the storages table,
+// orphan files older than the grace period are deleted and storages without a package are marked broken
+func (l *StorageLogic) ReconcileStorage(ctx context.Context, dryRun bool, grace time.Duration) (*ReconcileReport, error) {
+	if grace <= 0 {
+		grace = config.GConfig.Extra.OrphanGracePeriod
+	}
+	if grace <= 0 {
+		grace = defaultOrphanGracePeriod
+	}
+	// never race an upload which is still resumable
+	grace = max(grace, uploadSessionTTL)
+
+	var (
+		now    = time.Now()
+		report = &ReconcileReport{
+			DryRun:    dryRun,
+			StartedAt: now,
+			Grace:     grace.String(),
+		}
+	)
+
+	rows, err := l.storageRepo.ListPackageStorages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		referenced = make(map[string]struct{}, len(rows))
+		// <version>/<platform> of content addressed full packages, their local copies are kept for diffing
+		working = make(map[string]struct{})
+	)
+	for _, r := range rows {
+		referenced[l.RelPath(r.PackagePath)] = struct{}{}
+		if _, ok := l.isContentAddressed(r.PackagePath); ok && r.UpdateType == storage.UpdateTypeFull {
+			working[path.Join(strconv.Itoa(r.VersionStorages), l.getPlatformDirName(r.Os, r.Arch))] = struct{}{}
+		}
+	}
+
+	objects, err := l.listOwnedObjects(ctx)
+	if err != nil {
+		l.logger.Error("failed to list blob store",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	locals, err := listLocalFiles(l.RootDir)
+	if err != nil {
+		l.logger.Error("failed to walk local storage",
+			zap.String("dir", l.RootDir),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	for _, o := range findOrphans(objects, referenced, grace, now) {
+		f := OrphanFile{Tree: orphanTreeOSS, Path: o.Key, Size: o.Size, ModTime: o.ModTime}
+		if !dryRun {
+			deleted, err := l.deleteOrphanObject(ctx, o.Key)
+			switch {
+			case err != nil:
+				f.Error = err.Error()
+			case !deleted:
+				// reused by an upload since it was listed
+				continue
+			default:
+				f.Deleted = true
+				report.FreedBytes += o.Size
+			}
+		}
+		report.OrphanFiles = append(report.OrphanFiles, f)
+	}
+	locals = slices.DeleteFunc(locals, func(f blob.Info) bool {
+		// <resource>/<version>/<platform>/resource<suffix>
+		parts := strings.Split(f.Key, "/")
+		if len(parts) != 4 || !strings.HasPrefix(parts[3], misc.DefaultResourceName) {
+			return false
+		}
+		_, ok := working[path.Join(parts[1], parts[2])]
+		return ok
+	})
+	for _, o := range findOrphans(locals, referenced, grace, now) {
+		p := filepath.Join(l.RootDir, filepath.FromSlash(o.Key))
+		f := OrphanFile{Tree: orphanTreeLocal, Path: p, Size: o.Size, ModTime: o.ModTime}
+		if !dryRun {
+			if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				f.Error = err.Error()
+			} else {
+				f.Deleted = true
+				report.FreedBytes += o.Size
+			}
+		}
+		report.OrphanFiles = append(report.OrphanFiles, f)
+	}
+
+	var (
+		inOSS   = keySet(objects)
+		inLocal = keySet(locals)
+		broken  []int
+	)
+	for _, r := range rows {
+		var (
+			rel     = l.RelPath(r.PackagePath)
+			missing string
+		)
+		switch {
+		case !inOSS[rel]:
+			missing = orphanTreeOSS
+		case strings.HasPrefix(r.PackagePath, l.RootDir) && !inLocal[rel]:
+			// incremental resources diff against the local copy
+			missing = orphanTreeLocal
+		}
+		switch {
+		case missing != "":
+			report.BrokenStorages = append(report.BrokenStorages, BrokenStorage{
+				StorageId:   r.ID,
+				PackagePath: r.PackagePath,
+				Missing:     missing,
+			})
+			if r.BrokenAt == nil {
+				broken = append(broken, r.ID)
+			}
+		case r.BrokenAt != nil:
+			report.RestoredStorages = append(report.RestoredStorages, r.ID)
+		}
+	}
+
+	if !dryRun && (len(broken) > 0 || len(report.RestoredStorages) > 0) {
+		if err := l.storageRepo.MarkStoragesBroken(ctx, broken, &now); err != nil {
+			return nil, err
+		}
+		if err := l.storageRepo.MarkStoragesBroken(ctx, report.RestoredStorages, nil); err != nil {
+			return nil, err
+		}
+		if err := cache.PublishEvict(ctx, l.rdb, "reconcile"); err != nil {
+			l.logger.Warn("failed to publish cache evict",
+				zap.Error(err),
+			)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	l.logger.Info("storage reconciled",
+		zap.Bool("dry run", dryRun),
+		zap.Int("orphan files", len(report.OrphanFiles)),
+		zap.Int("broken storages", len(report.BrokenStorages)),
+		zap.Int("newly broken", len(broken)),
+		zap.Int("restored storages", len(report.RestoredStorages)),
+		zap.Int64("freed bytes", report.FreedBytes),
+	)
+	return report, nil
+}
+
+// deleteOrphanObject content addressed objects are recounted under their lock, an upload may reuse one at any time
+func (l *StorageLogic) deleteOrphanObject(ctx context.Context, key string) (bool, error) {
+	packagePath := l.BlobPath(key)
+	if _, ok := l.isContentAddressed(packagePath); ok {
+		return l.deleteUnreferenced(ctx, key, packagePath)
+	}
+	return true, l.Blob.Delete(ctx, key)
+}
+
+// listOwnedObjects objects below the prefixes this service writes to, <resource>/ for the packages,
+// patches and upload parts of a resource and cas/ for content addressed packages,
+// the bucket may be shared so anything else is left alone
+func (l *StorageLogic) listOwnedObjects(ctx context.Context) ([]blob.Info, error) {
+	resources, err := l.resourceRepo.GetFullResource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(resources))
+	for _, r := range resources {
+		ids = append(ids, r.ID)
+	}
+	var list []blob.Info
+	for _, prefix := range ownedPrefixes(ids) {
+		objects, err := l.Blob.List(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, objects...)
+	}
+	return list, nil
+}
+
+func ownedPrefixes(resourceIds []string) []string {
+	prefixes := []string{casPrefix + "/"}
+	for _, id := range resourceIds {
+		if id == "" || id == casPrefix || strings.ContainsAny(id, "/\\") {
+			continue
+		}
+		prefixes = append(prefixes, id+"/")
+	}
+	return prefixes
+}
+
+// findOrphans files no storage points to which are older than the grace period
+func findOrphans(files []blob.Info, referenced map[string]struct{}, grace time.Duration, now time.Time) []blob.Info {
+	var result []blob.Info
+	for _, f := range files {
+		if _, ok := referenced[f.Key]; ok {
+			continue
+		}
+		if now.Sub(f.ModTime) < grace {
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+func listLocalFiles(root string) ([]blob.Info, error) {
+	var list []blob.Info
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		list = append(list, blob.Info{Key: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return list, err
+}
+
+func keySet(files []blob.Info) map[string]bool {
+	m := make(map[string]bool, len(files))
+	for _, f := range files {
+		m[f.Key] = true
+	}
+	return m
+}
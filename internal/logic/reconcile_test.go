@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOrphans(t *testing.T) {
+	var (
+		now   = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		files = []blob.Info{
+			{Key: "res/1/any/resource.zip", ModTime: now.AddDate(0, -1, 0)},
+			{Key: "res/1/any/patch/0.zip", ModTime: now.AddDate(0, -1, 0)},
+			{Key: "res/2/any/resource.zip", ModTime: now.Add(-time.Hour)},
+		}
+		referenced = map[string]struct{}{"res/1/any/resource.zip": {}}
+	)
+	got := findOrphans(files, referenced, 72*time.Hour, now)
+	assert.Equal(t, []blob.Info{files[1]}, got)
+}
+
+func TestOwnedPrefixes(t *testing.T) {
+	assert.Equal(t,
+		[]string{"cas/", "M9A/", "res-1/"},
+		ownedPrefixes([]string{"M9A", "", "../x", "a/b", "cas", "res-1"}),
+	)
+}
@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"context"
+	"slices"
+
+	"github.com/MirrorChyan/resource-backend/internal/config"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/locale"
+)
+
+const fallbackLocale = "zh-CN"
+
+// DefaultLocale language of the release_note column
+func DefaultLocale() string {
+	if l, ok := locale.Normalize(config.GConfig.Extra.DefaultLocale); ok {
+		return l
+	}
+	return fallbackLocale
+}
+
+// UpdateReleaseNote the note of the default locale is kept in release_note, the others in release_notes
+func (l *VersionLogic) UpdateReleaseNote(ctx context.Context, param UpdateReleaseNoteDetailParam) error {
+	if param.Locale == "" || param.Locale == DefaultLocale() {
+		return l.versionRepo.UpdateVersionReleaseNote(ctx, param.VersionID, param.ReleaseNoteDetail)
+	}
+	return l.versionRepo.SetLocalizedReleaseNote(ctx, param.VersionID, param.Locale, param.ReleaseNoteDetail)
+}
+
+// PickReleaseNote the note in the locale closest to prefs and its locale, the default one when nothing matches
+func PickReleaseNote(note string, notes types.ReleaseNotes, prefs []string) (string, string) {
+	return pickReleaseNote(DefaultLocale(), note, notes, prefs)
+}
+
+func pickReleaseNote(defaultLocale, note string, notes types.ReleaseNotes, prefs []string) (string, string) {
+	if m, ok := locale.Match(prefs, releaseNoteLocales(defaultLocale, note, notes)); ok && m != defaultLocale {
+		return notes[m], m
+	}
+	return note, defaultLocale
+}
+
+// ReleaseNoteLocales locales a version has a release note in
+func ReleaseNoteLocales(note string, notes types.ReleaseNotes) []string {
+	return releaseNoteLocales(DefaultLocale(), note, notes)
+}
+
+func releaseNoteLocales(defaultLocale, note string, notes types.ReleaseNotes) []string {
+	list := make([]string, 0, len(notes))
+	for k, v := range notes {
+		if v != "" && k != defaultLocale {
+			list = append(list, k)
+		}
+	}
+	slices.Sort(list)
+	if note == "" {
+		return list
+	}
+	return append([]string{defaultLocale}, list...)
+}
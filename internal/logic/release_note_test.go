@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickReleaseNote(t *testing.T) {
+	notes := types.ReleaseNotes{"en": "fixes", "ja": "修正", "ko": ""}
+	tests := []struct {
+		name   string
+		note   string
+		prefs  []string
+		want   string
+		locale string
+	}{
+		{"no preference", "修复", nil, "修复", "zh-CN"},
+		{"default locale", "修复", []string{"zh-CN", "en"}, "修复", "zh-CN"},
+		{"other locale", "修复", []string{"en-US", "zh-CN"}, "fixes", "en"},
+		{"empty note skipped", "修复", []string{"ko", "ja"}, "修正", "ja"},
+		{"fallback", "修复", []string{"fr"}, "修复", "zh-CN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, locale := pickReleaseNote("zh-CN", tt.note, notes, tt.prefs)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.locale, locale)
+		})
+	}
+}
+
+func TestReleaseNoteLocales(t *testing.T) {
+	notes := types.ReleaseNotes{"ko": "k", "en": "e", "ja": ""}
+	assert.Equal(t, []string{"zh-CN", "en", "ko"}, releaseNoteLocales("zh-CN", "z", notes))
+	assert.Equal(t, []string{"en", "ko"}, releaseNoteLocales("zh-CN", "", notes))
+	assert.Empty(t, releaseNoteLocales("zh-CN", "", nil))
+}
@@ -4,44 +4,211 @@ import (
 	"context"
 
 	"github.com/MirrorChyan/resource-backend/internal/cache"
+	"github.com/MirrorChyan/resource-backend/internal/logic/dispense"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
 
 	. "github.com/MirrorChyan/resource-backend/internal/model"
 	"github.com/MirrorChyan/resource-backend/internal/repo"
 
 	"github.com/MirrorChyan/resource-backend/internal/ent"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
 type ResourceLogic struct {
-	logger       *zap.Logger
-	resourceRepo *repo.Resource
-	cg           *cache.MultiCacheGroup
+	logger          *zap.Logger
+	resourceRepo    *repo.Resource
+	channelRepo     *repo.Channel
+	distributeLogic *dispense.DistributeLogic
+	rdb             *redis.Client
+	cg              *cache.MultiCacheGroup
+	comparator      *vercomp.VersionComparator
 }
 
 func NewResourceLogic(
 	logger *zap.Logger,
 	resourceRepo *repo.Resource,
+	channelRepo *repo.Channel,
+	distributeLogic *dispense.DistributeLogic,
+	rdb *redis.Client,
 	cg *cache.MultiCacheGroup,
+	comparator *vercomp.VersionComparator,
 ) *ResourceLogic {
 	return &ResourceLogic{
-		logger:       logger,
-		resourceRepo: resourceRepo,
-		cg:           cg,
+		logger:          logger,
+		resourceRepo:    resourceRepo,
+		channelRepo:     channelRepo,
+		distributeLogic: distributeLogic,
+		rdb:             rdb,
+		cg:              cg,
+		comparator:      comparator,
 	}
 }
 
-func (l *ResourceLogic) FindUpdateTypeById(ctx context.Context, id string) (types.Update, error) {
+func (l *ResourceLogic) getResourceInfoByCache(ctx context.Context, id string) (*ent.Resource, error) {
 	key := l.cg.GetCacheKey(id)
 	val, err := l.cg.ResourceInfoCache.ComputeIfAbsent(key, func() (*ent.Resource, error) {
-		return l.resourceRepo.FindUpdateTypeById(ctx, id)
+		return l.resourceRepo.FindResourceInfoById(ctx, id)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return *val, nil
+}
+
+func (l *ResourceLogic) FindUpdateTypeById(ctx context.Context, id string) (types.Update, error) {
+	res, err := l.getResourceInfoByCache(ctx, id)
 	if err != nil {
 		return "", err
 	}
-	return types.Update((*val).UpdateType), err
+	return types.Update(res.UpdateType), nil
+}
+
+func (l *ResourceLogic) FindDistributionPolicyById(ctx context.Context, id string) (types.DistributionPolicy, error) {
+	res, err := l.getResourceInfoByCache(ctx, id)
+	if err != nil {
+		return types.DistributionPolicy{}, err
+	}
+	return res.DistributionPolicy, nil
+}
+
+func (l *ResourceLogic) UpdateDistributionPolicy(ctx context.Context, id string, policy types.DistributionPolicy) error {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return err
+	} else if !exists {
+		return errs.ErrResourceNotFound
+	}
+
+	if err := l.distributeLogic.ValidatePolicy(policy); err != nil {
+		return errs.ErrInvalidParams.Wrap(err).WithDetails(err.Error())
+	}
+
+	if err := l.resourceRepo.UpdateDistributionPolicy(ctx, id, policy); err != nil {
+		l.logger.Error("failed to update distribution policy",
+			zap.String("resource id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	l.logger.Info("distribution policy updated",
+		zap.String("resource id", id),
+		zap.Strings("distributors", policy.Distributors),
+	)
+	return l.evictResourceInfo(ctx, id)
+}
+
+func (l *ResourceLogic) FindPatchPolicyById(ctx context.Context, id string) (types.PatchPolicy, error) {
+	res, err := l.getResourceInfoByCache(ctx, id)
+	if err != nil {
+		return types.PatchPolicy{}, err
+	}
+	return res.PatchPolicy, nil
+}
+
+func (l *ResourceLogic) UpdatePatchPolicy(ctx context.Context, id string, policy types.PatchPolicy) error {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return err
+	} else if !exists {
+		return errs.ErrResourceNotFound
+	}
+
+	if err := l.resourceRepo.UpdatePatchPolicy(ctx, id, policy); err != nil {
+		l.logger.Error("failed to update patch policy",
+			zap.String("resource id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	l.logger.Info("patch policy updated",
+		zap.String("resource id", id),
+		zap.Float64("max size ratio", policy.SizeRatio()),
+		zap.Int("pregenerate count", policy.PregenerateCount),
+	)
+	return l.evictResourceInfo(ctx, id)
+}
+
+func (l *ResourceLogic) FindSupportPolicyById(ctx context.Context, id string) (types.SupportPolicy, error) {
+	res, err := l.getResourceInfoByCache(ctx, id)
+	if err != nil {
+		return types.SupportPolicy{}, err
+	}
+	return res.SupportPolicy, nil
+}
+
+// UpdateSupportPolicy channels of the policy are normalized, they must exist for the resource
+func (l *ResourceLogic) UpdateSupportPolicy(ctx context.Context, id string, policy types.SupportPolicy) error {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return err
+	} else if !exists {
+		return errs.ErrResourceNotFound
+	}
+
+	comparator, _, err := l.ComparatorOf(ctx, id)
+	if err != nil {
+		return err
+	}
+	versions := make(map[string]types.MinVersion, len(policy.MinVersions))
+	for c, v := range policy.MinVersions {
+		channel, err := l.ResolveChannel(ctx, id, c)
+		if err != nil {
+			return err
+		}
+		if !comparator.IsVersionParsable(v.Version) {
+			return errs.ErrResourceVersionNameUnparsable.WithDetails(v.Version)
+		}
+		versions[channel] = v
+	}
+	policy.MinVersions = versions
+
+	if err := l.resourceRepo.UpdateSupportPolicy(ctx, id, policy); err != nil {
+		l.logger.Error("failed to update support policy",
+			zap.String("resource id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	l.logger.Info("support policy updated",
+		zap.String("resource id", id),
+		zap.Any("min versions", policy.MinVersions),
+	)
+	return l.evictResourceInfo(ctx, id)
+}
+
+func (l *ResourceLogic) UpdateRetentionPolicy(ctx context.Context, id string, policy types.RetentionPolicy) error {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return err
+	} else if !exists {
+		return errs.ErrResourceNotFound
+	}
+
+	if err := l.resourceRepo.UpdateRetentionPolicy(ctx, id, policy); err != nil {
+		l.logger.Error("failed to update retention policy",
+			zap.String("resource id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	l.logger.Info("retention policy updated",
+		zap.String("resource id", id),
+		zap.Int("keep latest", policy.Latest()),
+		zap.Int("keep days", policy.KeepDays),
+		zap.Strings("pinned versions", policy.PinnedVersions),
+		zap.Int64("min active requests", policy.MinActiveRequests),
+	)
+	return l.evictResourceInfo(ctx, id)
+}
+
+// evictResourceInfo drops the cached resource info here and on the other instances
+func (l *ResourceLogic) evictResourceInfo(ctx context.Context, id string) error {
+	l.cg.ResourceInfoCache.Delete(l.cg.GetCacheKey(id))
+	return cache.PublishEvict(ctx, l.rdb, id)
 }
 
 func (l *ResourceLogic) Create(ctx context.Context, param CreateResourceParam) (*ent.Resource, error) {
@@ -57,7 +224,7 @@ func (l *ResourceLogic) Create(ctx context.Context, param CreateResourceParam) (
 	return l.resourceRepo.CreateResource(ctx,
 		param.ID,
 		param.Name, param.Description,
-		param.UpdateType,
+		param.UpdateType, param.VersionScheme,
 	)
 }
 
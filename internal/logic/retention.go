@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"go.uber.org/zap"
+)
+
+// planPurge full storages of the resource which the retention policy no longer keeps
+func (l *StorageLogic) planPurge(ctx context.Context, resourceId string, policy types.RetentionPolicy) ([]ResourcePurgeInfo, error) {
+	candidates, err := l.rawQuery.GetPurgeCandidates(resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	var active map[string]float64
+	if policy.MinActiveRequests > 0 {
+		active, err = countVersionRequests(ctx, l.rdb, resourceId, 0)
+		if err != nil {
+			// purging a version still in use is worse than keeping it one more day
+			l.logger.Error("failed to count version requests",
+				zap.String("resource id", resourceId),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	return selectPurgeable(candidates, policy, active, time.Now()), nil
+}
+
+// PreviewPurge what the next purge job would remove
+func (l *StorageLogic) PreviewPurge(ctx context.Context, resourceId string) (types.RetentionPolicy, []ResourcePurgeInfo, error) {
+	res, err := l.resourceRepo.FindResourceInfoById(ctx, resourceId)
+	if err != nil {
+		return types.RetentionPolicy{}, nil, err
+	}
+	list, err := l.planPurge(ctx, resourceId, res.RetentionPolicy)
+	if err != nil {
+		return types.RetentionPolicy{}, nil, err
+	}
+	return res.RetentionPolicy, list, nil
+}
+
+// selectPurgeable candidates served to every client are ranked per channel/os/arch newest first,
+// the newest served package of a platform and rollouts in progress are never purged
+func selectPurgeable(candidates []ResourcePurgeInfo, policy types.RetentionPolicy, active map[string]float64, now time.Time) []ResourcePurgeInfo {
+	var (
+		keepLatest = max(policy.Latest(), 1)
+		since      time.Time
+		result     []ResourcePurgeInfo
+	)
+	if policy.KeepDays > 0 {
+		since = now.AddDate(0, 0, -policy.KeepDays)
+	}
+	for _, c := range candidates {
+		switch {
+		case c.Pending:
+		case c.VersionSerial > 0 && c.VersionSerial <= keepLatest:
+		case !since.IsZero() && c.CreatedAt.After(since):
+		case slices.Contains(policy.PinnedVersions, c.VersionName):
+		case policy.MinActiveRequests > 0 && active[c.VersionName] >= float64(policy.MinActiveRequests):
+		default:
+			result = append(result, c)
+		}
+	}
+	return result
+}
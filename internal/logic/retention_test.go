@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPurgeable(t *testing.T) {
+	var (
+		now        = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		candidates = []ResourcePurgeInfo{
+			{VersionName: "v5", VersionSerial: 1, CreatedAt: now.AddDate(0, 0, -1)},
+			{VersionName: "v4", VersionSerial: 2, CreatedAt: now.AddDate(0, 0, -5)},
+			{VersionName: "v3", VersionSerial: 3, CreatedAt: now.AddDate(0, 0, -10)},
+			{VersionName: "v2", VersionSerial: 4, CreatedAt: now.AddDate(0, 0, -40)},
+			{VersionName: "v1", VersionSerial: 5, CreatedAt: now.AddDate(0, 0, -90)},
+		}
+	)
+	names := func(list []ResourcePurgeInfo) []string {
+		var r []string
+		for _, v := range list {
+			r = append(r, v.VersionName)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		policy types.RetentionPolicy
+		active map[string]float64
+		want   []string
+	}{
+		{"default keeps two", types.RetentionPolicy{}, nil, []string{"v3", "v2", "v1"}},
+		{"keep latest", types.RetentionPolicy{KeepLatest: 4}, nil, []string{"v1"}},
+		{"keep days", types.RetentionPolicy{KeepLatest: 1, KeepDays: 30}, nil, []string{"v2", "v1"}},
+		{"pinned", types.RetentionPolicy{PinnedVersions: []string{"v1"}}, nil, []string{"v3", "v2"}},
+		{
+			"active users",
+			types.RetentionPolicy{MinActiveRequests: 100},
+			map[string]float64{"v2": 100, "v1": 99},
+			[]string{"v3", "v1"},
+		},
+		{"active disabled", types.RetentionPolicy{}, map[string]float64{"v1": 1000}, []string{"v3", "v2", "v1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectPurgeable(candidates, tt.policy, tt.active, now)
+			assert.Equal(t, tt.want, names(got))
+		})
+	}
+}
+
+func TestSelectPurgeableRankedByServed(t *testing.T) {
+	var (
+		now = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		// a scheduled release, a 10% rollout and a yanked storage on top of the served one don't take its slot
+		candidates = []ResourcePurgeInfo{
+			{VersionName: "v6", Pending: true, CreatedAt: now},
+			{VersionName: "v5", Pending: true, CreatedAt: now},
+			{VersionName: "v4", Pending: true, CreatedAt: now},
+			{VersionName: "v3", CreatedAt: now.AddDate(0, 0, -1)},
+			{VersionName: "v2", VersionSerial: 1, CreatedAt: now.AddDate(0, 0, -40)},
+			{VersionName: "v1", VersionSerial: 2, CreatedAt: now.AddDate(0, 0, -90)},
+		}
+	)
+	got := selectPurgeable(candidates, types.RetentionPolicy{KeepLatest: 1}, nil, now)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "v3", got[0].VersionName)
+	assert.Equal(t, "v1", got[1].VersionName)
+}
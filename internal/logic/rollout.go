@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"context"
+	"hash/fnv"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"go.uber.org/zap"
+)
+
+// SetRollout changes the share of clients offered the version, raising it to 100 completes the rollout
+func (l *VersionLogic) SetRollout(ctx context.Context, param RolloutVersionParam) error {
+	ver, err := l.versionRepo.GetVersionByName(ctx, param.ResourceId, param.VersionName)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errs.ErrResourceVersionNotFound
+		}
+		return err
+	}
+
+	n, err := l.storageLogic.storageRepo.SetRolloutPercent(ctx, ver.ID, param.OS, param.Arch, param.Percent)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.ErrStorageNotFound
+	}
+
+	l.logger.Info("version rollout changed",
+		zap.String("resource id", param.ResourceId),
+		zap.String("version name", param.VersionName),
+		zap.Stringp("os", param.OS),
+		zap.Stringp("arch", param.Arch),
+		zap.Int("percent", param.Percent),
+		zap.Int("storages", n),
+	)
+
+	l.doEvictVersionInfo(ctx, param.ResourceId, "rollout")
+	return nil
+}
+
+// rolloutBucket stable bucket in [0, 100) of a client, the resource is mixed in so a client
+// isn't always among the first to receive every resource
+func rolloutBucket(resourceId, key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(resourceId))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
+
+// pickRollout the newest rollout covering the bucket, otherwise the version offered to every client
+func pickRollout(latest *LatestVersionInfo, rollouts []*LatestVersionInfo, bucket int, currentVersion string) *LatestVersionInfo {
+	for _, r := range rollouts {
+		if bucket < r.RolloutPercent || (currentVersion != "" && r.VersionName == currentVersion) {
+			return r
+		}
+	}
+	return latest
+}
@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolloutBucket(t *testing.T) {
+	b := rolloutBucket("res", "cdk")
+	assert.GreaterOrEqual(t, b, 0)
+	assert.Less(t, b, 100)
+	assert.Equal(t, b, rolloutBucket("res", "cdk"))
+
+	// roughly uniform over many clients
+	var hits int
+	for i := 0; i < 10000; i++ {
+		if rolloutBucket("res", strconv.Itoa(i)) < 25 {
+			hits++
+		}
+	}
+	assert.InDelta(t, 2500, hits, 300)
+}
+
+func TestPickRollout(t *testing.T) {
+	var (
+		latest = &LatestVersionInfo{VersionName: "1.0.0", RolloutPercent: 100}
+		v12    = &LatestVersionInfo{VersionName: "1.2.0", RolloutPercent: 5}
+		v11    = &LatestVersionInfo{VersionName: "1.1.0", RolloutPercent: 50}
+		list   = []*LatestVersionInfo{v12, v11}
+	)
+	tests := []struct {
+		name    string
+		bucket  int
+		current string
+		want    *LatestVersionInfo
+	}{
+		{"newest rollout", 3, "", v12},
+		{"older rollout", 30, "", v11},
+		{"outside every rollout", 80, "1.0.0", latest},
+		{"stays on its rollout", 80, "1.2.0", v12},
+	}
+	for _, tt := range tests {
+		assert.Same(t, tt.want, pickRollout(latest, list, tt.bucket, tt.current), tt.name)
+	}
+	assert.Nil(t, pickRollout(nil, list, 99, ""))
+}
@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+	"go.uber.org/zap"
+)
+
+// schemeComparators the auto scheme uses the comparator passed at startup
+var schemeComparators = map[types.VersionScheme]*vercomp.VersionComparator{
+	types.VersionSchemeSemVer:      vercomp.NewComparator(&vercomp.SemVerParser{}),
+	types.VersionSchemeLooseSemVer: vercomp.NewComparator(&vercomp.LooseSemVerParser{}),
+	types.VersionSchemeCalVer:      vercomp.NewComparator(&vercomp.CalVerParser{}),
+	types.VersionSchemePEP440:      vercomp.NewComparator(&vercomp.PEP440Parser{}),
+	types.VersionSchemeDateTime:    vercomp.NewComparator(vercomp.NewDateTimeParser()),
+	types.VersionSchemeInteger:     vercomp.NewComparator(&vercomp.IntegerParser{}),
+}
+
+func (l *ResourceLogic) FindVersionSchemeById(ctx context.Context, id string) (types.VersionScheme, error) {
+	res, err := l.getResourceInfoByCache(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return types.VersionScheme(res.VersionScheme), nil
+}
+
+// UpdateVersionScheme every existing version name has to parse under the new scheme
+func (l *ResourceLogic) UpdateVersionScheme(ctx context.Context, id string, scheme types.VersionScheme) error {
+	if exists, err := l.Exists(ctx, id); err != nil {
+		return err
+	} else if !exists {
+		return errs.ErrResourceNotFound
+	}
+
+	if c, ok := schemeComparators[scheme]; ok {
+		names, err := l.resourceRepo.ListVersionNames(ctx, id)
+		if err != nil {
+			return err
+		}
+		var invalid []string
+		for _, name := range names {
+			if !c.IsVersionParsable(name) {
+				invalid = append(invalid, name)
+			}
+		}
+		if len(invalid) > 0 {
+			return errs.ErrResourceVersionNameUnparsable.WithDetails(strings.Join(invalid, ", "))
+		}
+	}
+
+	if err := l.resourceRepo.UpdateVersionScheme(ctx, id, scheme.String()); err != nil {
+		l.logger.Error("failed to update version scheme",
+			zap.String("resource id", id),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	l.logger.Info("version scheme updated",
+		zap.String("resource id", id),
+		zap.String("scheme", scheme.String()),
+	)
+	return l.evictResourceInfo(ctx, id)
+}
+
+// ComparatorOf the comparator of the version scheme the resource declares
+func (l *ResourceLogic) ComparatorOf(ctx context.Context, id string) (*vercomp.VersionComparator, types.VersionScheme, error) {
+	scheme, err := l.FindVersionSchemeById(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if c, ok := schemeComparators[scheme]; ok {
+		return c, scheme, nil
+	}
+	return l.comparator, scheme, nil
+}
+
+// doValidateVersionName a new version name has to parse under the scheme of the resource and sort after
+// the latest version of the channel, the auto scheme only requires names outside stable to parse
+func (l *VersionLogic) doValidateVersionName(ctx context.Context, resourceId, channel, name string) error {
+	c, scheme, err := l.resourceLogic.ComparatorOf(ctx, resourceId)
+	if err != nil {
+		return err
+	}
+	if !c.IsVersionParsable(name) {
+		if scheme == types.VersionSchemeAuto && channel == types.ChannelStable.String() {
+			return nil
+		}
+		return errs.ErrResourceVersionNameUnparsable.WithDetails(name)
+	}
+	if scheme == types.VersionSchemeAuto {
+		return nil
+	}
+
+	latest, err := l.versionRepo.GetMaxNumberChannelVersion(ctx, resourceId, channel)
+	switch {
+	case ent.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	case latest.Name == name:
+		// created before without a package
+		return nil
+	}
+	if r := c.Compare(name, latest.Name); r.Comparable && r.Result != vercomp.Greater {
+		return errs.ErrResourceVersionNotNewer.WithDetails(latest.Name)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/cache"
+	"github.com/MirrorChyan/resource-backend/internal/config"
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/filehash"
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultScrubBytesPerRun = 10 << 30
+	// storages considered by one run, the byte budget usually ends it first
+	scrubScanLimit = 1000
+
+	scrubWebhookTimeout = 10 * time.Second
+)
+
+var scrubWebhookClient = &http.Client{Timeout: scrubWebhookTimeout}
+
+type scrubResult struct {
+	verified, mismatched, missing, failed int
+	bytesRead                             int64
+}
+
+// ScrubStorages re-hashes the packages verified longest ago until the byte budget of the run is spent
+func (l *StorageLogic) ScrubStorages(ctx context.Context) error {
+	var (
+		cfg    = config.GConfig.Extra.Scrub
+		budget = cfg.BytesPerRun
+		result scrubResult
+	)
+	if budget <= 0 {
+		budget = defaultScrubBytesPerRun
+	}
+
+	rows, err := l.storageRepo.ListStoragesToVerify(ctx, scrubScanLimit)
+	if err != nil {
+		return err
+	}
+	for _, s := range rows {
+		if result.bytesRead >= budget || ctx.Err() != nil {
+			break
+		}
+		if s.PackageHashSha256 == "" {
+			continue
+		}
+
+		key := l.RelPath(s.PackagePath)
+		actual, n, err := l.hashBlob(ctx, key, cfg.BytesPerSecond)
+		result.bytesRead += n
+		switch {
+		case errors.Is(err, blob.ErrNotFound):
+			// left to the reconciler
+			result.missing++
+			scrubCounter.WithLabelValues(scrubMissing).Inc()
+			continue
+		case err != nil:
+			result.failed++
+			scrubCounter.WithLabelValues(scrubError).Inc()
+			l.logger.Warn("failed to scrub storage",
+				zap.Int("storage id", s.ID),
+				zap.String("key", key),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		now := time.Now()
+		if actual == s.PackageHashSha256 {
+			result.verified++
+			scrubCounter.WithLabelValues(scrubVerified).Inc()
+			if err := l.storageRepo.MarkStorageVerified(ctx, s.ID, now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result.mismatched++
+		scrubCounter.WithLabelValues(scrubMismatch).Inc()
+		l.logger.Error("package hash mismatch",
+			zap.Int("storage id", s.ID),
+			zap.String("package path", s.PackagePath),
+			zap.String("expected", s.PackageHashSha256),
+			zap.String("actual", actual),
+			zap.Bool("quarantine", cfg.Quarantine),
+		)
+		// without quarantine it is still served, it moves to the back of the queue
+		// so it doesn't hold back the other storages and the next pass reports it again
+		if cfg.Quarantine {
+			if err := l.storageRepo.QuarantineStorage(ctx, s.ID, &now); err != nil {
+				return err
+			}
+			if err := cache.PublishEvict(ctx, l.rdb, "quarantine"); err != nil {
+				l.logger.Warn("failed to publish cache evict",
+					zap.Error(err),
+				)
+			}
+		} else if err := l.storageRepo.MarkStorageVerified(ctx, s.ID, now); err != nil {
+			return err
+		}
+		go doScrubNotify(l.logger, cfg.Webhook, s, actual, cfg.Quarantine)
+	}
+
+	l.logger.Info("scrub storages finished",
+		zap.Int("verified", result.verified),
+		zap.Int("mismatched", result.mismatched),
+		zap.Int("missing", result.missing),
+		zap.Int("failed", result.failed),
+		zap.Int64("bytes read", result.bytesRead),
+		zap.Int64("budget", budget),
+	)
+	return nil
+}
+
+// ReleaseQuarantine serves the storage again, e.g. after the package was uploaded again
+func (l *StorageLogic) ReleaseQuarantine(ctx context.Context, id int) error {
+	if err := l.storageRepo.QuarantineStorage(ctx, id, nil); err != nil {
+		if ent.IsNotFound(err) {
+			return errs.ErrStorageNotFound
+		}
+		return err
+	}
+	l.logger.Info("storage released from quarantine",
+		zap.Int("storage id", id),
+	)
+	return cache.PublishEvict(ctx, l.rdb, "quarantine")
+}
+
+// hashBlob sha256 of an object, read at no more than rate bytes per second
+func (l *StorageLogic) hashBlob(ctx context.Context, key string, rate int64) (string, int64, error) {
+	r, err := l.Blob.Get(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	tr := &throttledReader{ctx: ctx, r: r, rate: rate, start: time.Now()}
+	hash, err := filehash.Sum(tr)
+	return hash, tr.n, err
+}
+
+type throttledReader struct {
+	ctx   context.Context
+	r     io.Reader
+	rate  int64
+	start time.Time
+	n     int64
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if t.rate > 0 && int64(len(p)) > t.rate {
+		p = p[:t.rate]
+	}
+	n, err := t.r.Read(p)
+	t.n += int64(n)
+	if t.rate <= 0 || n == 0 {
+		return n, err
+	}
+	due := time.Duration(float64(t.n) / float64(t.rate) * float64(time.Second))
+	if wait := due - time.Since(t.start); wait > 0 {
+		select {
+		case <-t.ctx.Done():
+			return n, t.ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return n, err
+}
+
+func doScrubNotify(l *zap.Logger, webhook string, s *ent.Storage, actual string, quarantined bool) {
+	if webhook == "" {
+		return
+	}
+	buf, e := sonic.Marshal(map[string]string{
+		"storage_id":   strconv.Itoa(s.ID),
+		"package_path": s.PackagePath,
+		"expected":     s.PackageHashSha256,
+		"actual":       actual,
+		"quarantined":  strconv.FormatBool(quarantined),
+	})
+	if e != nil {
+		l.Warn("Failed to marshal scrub mismatch callback")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), scrubWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewBuffer(buf))
+	if err != nil {
+		l.Warn("Failed to create scrub mismatch callback",
+			zap.Error(err),
+		)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := scrubWebhookClient.Do(req)
+	if err != nil {
+		l.Warn("Failed to send scrub mismatch callback",
+			zap.Error(err),
+		)
+		return
+	}
+	_ = resp.Body.Close()
+}
@@ -2,20 +2,33 @@ package logic
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/MirrorChyan/resource-backend/internal/config"
 	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/logic/dispense"
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
 	"github.com/MirrorChyan/resource-backend/internal/repo"
 	"github.com/bytedance/sonic"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/ksuid"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -25,8 +38,12 @@ type StorageLogic struct {
 	storageRepo  *repo.Storage
 	resourceRepo *repo.Resource
 	rawQuery     *repo.RawQuery
+	rdb          *redis.Client
+	sync         *redsync.Redsync
 	RootDir      string
 	OSSDir       string
+	// Blob published packages, keys are relative to OSSDir
+	Blob blob.Store
 }
 
 func NewStorageLogic(
@@ -34,20 +51,122 @@ func NewStorageLogic(
 	storageRepo *repo.Storage,
 	resourceRepo *repo.Resource,
 	rawQuery *repo.RawQuery,
+	rdb *redis.Client,
+	sync *redsync.Redsync,
 ) *StorageLogic {
-	// change to configurable, this is only a temporary solution
 	dir, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
+	var (
+		cfg     = config.GConfig.Blob
+		rootDir = cmp.Or(cfg.WorkDir, filepath.Join(dir, "storage"))
+		ossDir  = cmp.Or(cfg.Root, filepath.Join(dir, "oss"))
+	)
+	store, err := newBlobStore(cfg, ossDir)
+	if err != nil {
+		panic(err)
+	}
+	dispense.Register(dispense.NewBlobDistributor(store, cfg.PresignTTL))
+
 	return &StorageLogic{
 		logger:       logger,
 		resourceRepo: resourceRepo,
 		storageRepo:  storageRepo,
 		rawQuery:     rawQuery,
-		RootDir:      filepath.Join(dir, "storage"),
-		OSSDir:       filepath.Join(dir, "oss"),
+		rdb:          rdb,
+		sync:         sync,
+		RootDir:      rootDir,
+		OSSDir:       ossDir,
+		Blob:         store,
+	}
+}
+
+func newBlobStore(cfg config.BlobConfig, root string) (blob.Store, error) {
+	switch cfg.Driver {
+	case "", BlobDriverLocal:
+		return blob.NewLocal(root, cfg.BaseURL)
+	case BlobDriverS3:
+		return blob.NewS3(blob.S3Options{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			PathStyle: cfg.S3.PathStyle,
+			PartSize:  cfg.S3.PartSize,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob driver %q", cfg.Driver)
+	}
+}
+
+const (
+	BlobDriverLocal = "local"
+	BlobDriverS3    = "s3"
+)
+
+// BlobKey key of a path below OSSDir, package paths in the database keep the OSSDir prefix
+func (l *StorageLogic) BlobKey(p string) (string, bool) {
+	rel, err := filepath.Rel(l.OSSDir, p)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
 	}
+	return filepath.ToSlash(rel), true
+}
+
+// RelPath path below RootDir or OSSDir relative to its root, the same key in both trees
+func (l *StorageLogic) RelPath(p string) string {
+	var rel string
+	switch {
+	case strings.HasPrefix(p, l.RootDir):
+		rel = strings.TrimPrefix(p, l.RootDir)
+	case strings.HasPrefix(p, l.OSSDir):
+		rel = strings.TrimPrefix(p, l.OSSDir)
+	}
+	rel = strings.TrimPrefix(rel, string(os.PathSeparator))
+	return strings.ReplaceAll(rel, string(os.PathSeparator), "/")
+}
+
+func (l *StorageLogic) BlobPath(key string) string {
+	return filepath.Join(l.OSSDir, filepath.FromSlash(key))
+}
+
+// FetchBlob download an object into a local file
+func (l *StorageLogic) FetchBlob(ctx context.Context, key, dest string) error {
+	r, err := l.Blob.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// PushBlob upload a local file
+func (l *StorageLogic) PushBlob(ctx context.Context, src, key string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return l.Blob.Put(ctx, key, f, stat.Size())
 }
 
 func (l *StorageLogic) CreateFullUpdateStorage(ctx context.Context,
@@ -55,11 +174,12 @@ func (l *StorageLogic) CreateFullUpdateStorage(ctx context.Context,
 	fileType types.FileType, hash string,
 	size int64,
 	fileHashes map[string]string,
+	rolloutPercent int,
 ) (*ent.Storage, error) {
 	storage, err := l.storageRepo.CreateFullUpdateStorage(ctx, verID,
 		os, arch, path, hash,
 		fileType, size,
-		fileHashes,
+		fileHashes, rolloutPercent,
 	)
 	if err != nil {
 		l.logger.Error("create full update storage failed",
@@ -73,11 +193,11 @@ func (l *StorageLogic) CreateFullUpdateStorage(ctx context.Context,
 
 func (l *StorageLogic) CreateIncrementalUpdateStorage(ctx context.Context, tx *ent.Tx,
 	target, current int, filetype string, filesize int64,
-	os, arch, path, hashes string,
+	os, arch, path, hashes string, patchFormat int,
 ) (*ent.Storage, error) {
 	storage, err := l.storageRepo.CreateIncrementalUpdateStorage(ctx, tx, target, current,
 		filetype, filesize,
-		os, arch, path, hashes,
+		os, arch, path, hashes, patchFormat,
 	)
 	if err != nil {
 		l.logger.Error("create incremental update storage failed",
@@ -105,8 +225,8 @@ func (l *StorageLogic) GetFullUpdateStorage(ctx context.Context, versionId int,
 	return l.storageRepo.GetFullUpdateStorage(ctx, versionId, os, arch)
 }
 
-func (l *StorageLogic) GetIncrementalUpdateStorage(ctx context.Context, targerVerID, currentVerID int, os, arch string) (*ent.Storage, error) {
-	return l.storageRepo.GetIncrementalUpdateStorage(ctx, targerVerID, currentVerID, os, arch)
+func (l *StorageLogic) GetIncrementalUpdateStorage(ctx context.Context, targerVerID, currentVerID int, os, arch string, patchFormat int) (*ent.Storage, error) {
+	return l.storageRepo.GetIncrementalUpdateStorage(ctx, targerVerID, currentVerID, os, arch, patchFormat)
 }
 
 func (l *StorageLogic) BuildVersionStorageDirPath(resID string, verID int, os, arch string) string {
@@ -144,47 +264,100 @@ func (l *StorageLogic) UpdateStoragePackageHash(ctx context.Context, id int, has
 	return l.storageRepo.UpdateStoragePackageHash(ctx, id, hash)
 }
 
-func (l *StorageLogic) ClearOldStorages(ctx context.Context) error {
+// ClearOldStorages purges the full packages every retention policy gives up, a dry run only measures them
+func (l *StorageLogic) ClearOldStorages(ctx context.Context, dryRun bool) (*PurgeReport, error) {
 	resource, err := l.resourceRepo.GetFullResource(ctx)
 	if err != nil {
 		l.logger.Error("failed to get resource",
 			zap.Error(err),
 		)
-		return err
+		return nil, err
 	}
-	var wg errgroup.Group
-	for _, val := range resource {
-		wg.Go(func() error {
+	var (
+		wg     errgroup.Group
+		report = &PurgeReport{
+			Id:        ksuid.New().String(),
+			DryRun:    dryRun,
+			StartedAt: time.Now(),
+			Resources: make([]PurgeResourceReport, len(resource)),
+		}
+	)
+	for i, val := range resource {
+		wg.Go(func() (err error) {
+			rr := &report.Resources[i]
+			rr.ResourceId = val.ID
 			defer func() {
 				if e := recover(); e != nil {
 					l.logger.Error("failed to purge resource",
 						zap.String("resource id", val.ID),
 						zap.Any("panic error", e),
 					)
+					err = fmt.Errorf("purge resource %s: %v", val.ID, e)
+					rr.Errors = append(rr.Errors, err.Error())
 				}
 			}()
-			if err := l.doPurgeResource(ctx, val.ID); len(err) > 0 {
-				je := errors.Join(err...)
+			if el := l.doPurgeResource(ctx, rr, val.RetentionPolicy, dryRun); len(el) > 0 {
+				je := errors.Join(el...)
 				l.logger.Error("failed to purge resource",
 					zap.String("resource id", val.ID),
 					zap.Error(je),
 				)
-				doErrorNotify(l.logger, strings.Join([]string{val.ID, je.Error()}, ","))
+				if !dryRun {
+					doErrorNotify(l.logger, strings.Join([]string{val.ID, je.Error()}, ","))
+				}
 				return je
 			}
 			return nil
 		})
 	}
+	err = wg.Wait()
 
-	return wg.Wait()
+	// resources without anything to purge only add noise
+	report.Resources = slices.DeleteFunc(report.Resources, func(r PurgeResourceReport) bool {
+		return len(r.Items) == 0 && len(r.Errors) == 0
+	})
+	for _, r := range report.Resources {
+		report.FreedBytes += r.FreedBytes
+		for _, it := range r.Items {
+			if it.Error != "" {
+				report.Failures++
+			} else {
+				report.Purged++
+			}
+		}
+		if len(r.Errors) > 0 && len(r.Items) == 0 {
+			report.Failures++
+		}
+	}
+	report.FinishedAt = time.Now()
+
+	if !dryRun {
+		if e := l.savePurgeReport(ctx, report); e != nil {
+			l.logger.Warn("failed to save purge report",
+				zap.String("report id", report.Id),
+				zap.Error(e),
+			)
+		}
+	}
+	return report, err
 }
 
-func (l *StorageLogic) doPurgeResource(ctx context.Context, resourceId string) []error {
-	info, err := l.rawQuery.GetReadyToPurgeInfo(resourceId)
-	var el []error
+func (l *StorageLogic) doPurgeResource(ctx context.Context, rr *PurgeResourceReport, policy types.RetentionPolicy, dryRun bool) []error {
+	var (
+		resourceId = rr.ResourceId
+		el         []error
+	)
+	defer func() {
+		for _, e := range el {
+			rr.Errors = append(rr.Errors, e.Error())
+		}
+	}()
+
+	info, err := l.planPurge(ctx, resourceId, policy)
 	switch {
 	case err != nil:
-		return append(el, err)
+		el = append(el, err)
+		return el
 	case len(info) == 0:
 		return nil
 	}
@@ -192,38 +365,172 @@ func (l *StorageLogic) doPurgeResource(ctx context.Context, resourceId string) [
 	for _, val := range info {
 		var (
 			key = filepath.Join(val.ResourceId, strconv.Itoa(val.VersionId), l.getPlatformDirName(val.OS, val.Arch))
-			od  = filepath.Join(l.OSSDir, key)
+			od  = filepath.ToSlash(key) + "/"
 			ld  = filepath.Join(l.RootDir, key)
 		)
+		item := PurgeReportItem{
+			StorageId:   val.StorageId,
+			VersionId:   val.VersionId,
+			VersionName: val.VersionName,
+			Channel:     val.Channel,
+			OS:          val.OS,
+			Arch:        val.Arch,
+		}
+		// patches of a promoted clone stay below the directory of the version it was cloned from
+		shared, err := l.storageRepo.CountDirReferences(ctx, []string{l.BlobPath(od), ld + string(os.PathSeparator)}, val.VersionId)
+		if err != nil {
+			el = append(el, err)
+			return el
+		}
+		if shared == 0 {
+			item.Paths = []string{od, ld}
+			item.Bytes = l.measurePurge(ctx, od, ld)
+		}
+		if key, ok := l.isContentAddressed(val.PackagePath); ok {
+			item.Paths = append(item.Paths, key)
+			item.Bytes += l.measureContentAddressed(ctx, key, val.PackagePath, val.VersionId)
+		}
+		if dryRun {
+			rr.Items = append(rr.Items, item)
+			continue
+		}
+
 		l.logger.Info("clear old storage",
-			zap.String("oss dir", od),
+			zap.String("oss prefix", od),
 			zap.String("local dir", ld),
+			zap.Int("shared storages", shared),
+			zap.Int64("bytes", item.Bytes),
 		)
-		if err := os.RemoveAll(od); err != nil {
-			l.logger.Error("failed to remove old storage",
-				zap.String("oss dir", od),
+		var ie []error
+		if shared == 0 {
+			if err := blob.DeletePrefix(ctx, l.Blob, od); err != nil {
+				l.logger.Error("failed to remove old storage",
+					zap.String("oss prefix", od),
+					zap.Error(err),
+				)
+				ie = append(ie, err)
+			}
+			if err := os.RemoveAll(ld); err != nil {
+				l.logger.Error("failed to remove local storage",
+					zap.String("local dir", ld),
+					zap.Error(err),
+				)
+				ie = append(ie, err)
+			}
+		}
+		if len(ie) > 0 {
+			el = append(el, ie...)
+			item.Error = errors.Join(ie...).Error()
+		} else {
+			rr.FreedBytes += item.Bytes
+		}
+
+		err = l.storageRepo.PurgeStorageInfo(ctx, val.StorageId)
+		if err != nil {
+			l.logger.Error("failed to purge storage info",
+				zap.Int("storage id", val.StorageId),
 				zap.Error(err),
 			)
+			if item.Error == "" {
+				item.Error = err.Error()
+			}
+			rr.Items = append(rr.Items, item)
 			el = append(el, err)
+			return el
 		}
-		if err := os.RemoveAll(ld); err != nil {
-			l.logger.Error("failed to remove local storage",
-				zap.String("local dir", ld),
+		// shared content survives until its last storage is purged
+		if err := l.releaseContentAddressed(ctx, val.PackagePath); err != nil {
+			l.logger.Error("failed to release content addressed package",
+				zap.String("package path", val.PackagePath),
 				zap.Error(err),
 			)
+			if item.Error == "" {
+				item.Error = err.Error()
+			}
 			el = append(el, err)
 		}
+		rr.Items = append(rr.Items, item)
+	}
+	return el
+}
 
-		err := l.storageRepo.PurgeStorageInfo(ctx, val.StorageId)
+// measurePurge bytes held below the oss prefix and the local directory, best effort
+func (l *StorageLogic) measurePurge(ctx context.Context, prefix, dir string) int64 {
+	var size int64
+	if list, err := l.Blob.List(ctx, prefix); err == nil {
+		for _, o := range list {
+			size += o.Size
+		}
+	}
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+const (
+	purgeReportTTL  = 90 * 24 * time.Hour
+	purgeReportKeep = 100
+)
+
+func (l *StorageLogic) savePurgeReport(ctx context.Context, report *PurgeReport) error {
+	buf, err := sonic.Marshal(report)
+	if err != nil {
+		return err
+	}
+	_, err = l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, purgeReportKey(report.Id), buf, purgeReportTTL)
+		pipe.LPush(ctx, misc.PurgeReportListKey, report.Id)
+		pipe.LTrim(ctx, misc.PurgeReportListKey, 0, purgeReportKeep-1)
+		return nil
+	})
+	return err
+}
+
+func (l *StorageLogic) GetPurgeReport(ctx context.Context, id string) (*PurgeReport, error) {
+	val, err := l.rdb.Get(ctx, purgeReportKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errs.ErrPurgeReportNotFound
+		}
+		return nil, err
+	}
+	var report PurgeReport
+	if err := sonic.Unmarshal(val, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListPurgeReports the recent real runs newest first, without the per resource details
+func (l *StorageLogic) ListPurgeReports(ctx context.Context, limit int) ([]PurgeReport, error) {
+	ids, err := l.rdb.LRange(ctx, misc.PurgeReportListKey, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]PurgeReport, 0, len(ids))
+	for _, id := range ids {
+		report, err := l.GetPurgeReport(ctx, id)
+		if errors.Is(err, errs.ErrPurgeReportNotFound) {
+			continue
+		}
 		if err != nil {
-			l.logger.Error("failed to purge storage info",
-				zap.Int("storage id", val.StorageId),
-				zap.Error(err),
-			)
-			return append(el, err)
+			return nil, err
 		}
+		report.Resources = nil
+		list = append(list, *report)
 	}
-	return el
+	return list, nil
+}
+
+func purgeReportKey(id string) string {
+	return strings.Join([]string{misc.PurgeReportKey, id}, ":")
 }
 
 func doErrorNotify(l *zap.Logger, msg string) {
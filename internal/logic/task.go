@@ -2,6 +2,7 @@ package logic
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -14,7 +15,6 @@ import (
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/archiver"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/filehash"
-	"github.com/MirrorChyan/resource-backend/internal/pkg/fileops"
 	"github.com/bytedance/sonic"
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
@@ -59,6 +59,9 @@ func InitAsynqServer(l *zap.Logger, v *VersionLogic) *asynq.Server {
 	mux.HandleFunc(misc.DiffTask, doHandleGeneratePackage(l, v))
 	mux.HandleFunc(misc.ProcessStorageTask, doHandleCalculatePackageHash(l, v))
 	mux.HandleFunc(misc.PurgeTask, doHandlePurge(l, v))
+	mux.HandleFunc(misc.ReconcileTask, doHandleReconcile(l, v))
+	mux.HandleFunc(misc.ScrubTask, doHandleScrub(l, v))
+	mux.HandleFunc(misc.PublishTask, doHandlePublish(l, v))
 
 	if err := server.Start(mux); err != nil {
 		panic(err)
@@ -88,16 +91,30 @@ func initScheduler(l *zap.Logger) {
 	l.Info("scheduler starting",
 		zap.String("location", location.String()),
 	)
-	id, err := scheduler.Register("0 5 * * ?", asynq.NewTask(misc.PurgeTask, nil))
-	if err != nil {
-		l.Error("failed to register scheduler",
-			zap.Error(err),
+	scrubCron := conf.Extra.Scrub.Cron
+	if scrubCron == "" {
+		scrubCron = defaultScrubCron
+	}
+	for _, entry := range [][2]string{
+		{"0 5 * * ?", misc.PurgeTask},
+		// after the purge so its leftovers are collected the same day
+		{"30 5 * * ?", misc.ReconcileTask},
+		{scrubCron, misc.ScrubTask},
+	} {
+		spec, task := entry[0], entry[1]
+		id, err := scheduler.Register(spec, asynq.NewTask(task, nil))
+		if err != nil {
+			l.Error("failed to register scheduler",
+				zap.String("task", task),
+				zap.Error(err),
+			)
+			panic(err)
+		}
+		l.Info("scheduler registered",
+			zap.String("id", id),
+			zap.String("task", task),
 		)
-		panic(err)
 	}
-	l.Info("scheduler registered",
-		zap.String("id", id),
-	)
 
 	if err := scheduler.Start(); err != nil {
 		panic(err)
@@ -109,7 +126,15 @@ func doHandlePurge(l *zap.Logger, v *VersionLogic) func(context.Context, *asynq.
 	return func(ctx context.Context, task *asynq.Task) error {
 		cnt, _ := asynq.GetRetryCount(ctx)
 		l.Warn("start purge old storages with", zap.Int("retry cnt", cnt))
-		err := v.storageLogic.ClearOldStorages(ctx)
+		report, err := v.storageLogic.ClearOldStorages(ctx, false)
+		if report != nil {
+			l.Warn("purge report",
+				zap.String("report id", report.Id),
+				zap.Int("purged", report.Purged),
+				zap.Int("failures", report.Failures),
+				zap.Int64("freed bytes", report.FreedBytes),
+			)
+		}
 		if err != nil {
 			l.Error("failed to purge old storages",
 				zap.Error(err),
@@ -121,6 +146,51 @@ func doHandlePurge(l *zap.Logger, v *VersionLogic) func(context.Context, *asynq.
 	}
 }
 
+func doHandleReconcile(l *zap.Logger, v *VersionLogic) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		l.Warn("start reconcile storages")
+		if _, err := v.storageLogic.ReconcileStorage(ctx, false, 0); err != nil {
+			l.Error("failed to reconcile storages",
+				zap.Error(err),
+			)
+			return err
+		}
+		l.Warn("end reconcile storages")
+		return nil
+	}
+}
+
+const defaultScrubCron = "0 3 * * ?"
+
+func doHandleScrub(l *zap.Logger, v *VersionLogic) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		l.Warn("start scrub storages")
+		if err := v.storageLogic.ScrubStorages(ctx); err != nil {
+			l.Error("failed to scrub storages",
+				zap.Error(err),
+			)
+			return err
+		}
+		l.Warn("end scrub storages")
+		return nil
+	}
+}
+
+func doHandlePublish(l *zap.Logger, v *VersionLogic) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, task *asynq.Task) error {
+		var payload model.PublishTaskPayload
+		if err := sonic.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+		l.Info("scheduled release is due",
+			zap.String("resource id", payload.ResourceId),
+			zap.Int("version id", payload.VersionId),
+		)
+		v.doEvictVersionInfo(ctx, payload.ResourceId, "publish")
+		return nil
+	}
+}
+
 func doHandleCalculatePackageHash(l *zap.Logger, v *VersionLogic) func(ctx context.Context, task *asynq.Task) error {
 	return func(ctx context.Context, task *asynq.Task) (retErr error) {
 		c, ok := asynq.GetRetryCount(ctx)
@@ -153,7 +223,11 @@ func doHandleCalculatePackageHash(l *zap.Logger, v *VersionLogic) func(ctx conte
 			channel     = payload.Channel
 			versionName = payload.VersionName
 			fileType    = payload.IncrementalType
+			rollout     = types.RolloutFull
 		)
+		if payload.RolloutPercent != nil {
+			rollout = *payload.RolloutPercent
+		}
 
 		var (
 			dest   string
@@ -170,7 +244,11 @@ func doHandleCalculatePackageHash(l *zap.Logger, v *VersionLogic) func(ctx conte
 			filename := misc.DefaultResourceName + types.GetFileSuffix(fileType)
 			dest = filepath.Join(destDir, filename)
 
-			if err := fileops.CopyFile(source, dest); err != nil {
+			key, ok := v.storageLogic.BlobKey(source)
+			if !ok {
+				return fmt.Errorf("source %s is not in the oss dir", source)
+			}
+			if err := v.storageLogic.FetchBlob(ctx, key, dest); err != nil {
 				l.Error("failed to copy source to local storage",
 					zap.String("source", source),
 					zap.String("dest", dest),
@@ -229,9 +307,10 @@ func doHandleCalculatePackageHash(l *zap.Logger, v *VersionLogic) func(ctx conte
 		err := v.DoProcessStorage(ctx,
 			resourceId,
 			versionId, versionName,
-			channel, system, arch, dest,
+			channel, system, arch, source, dest,
 			fileType,
 			hashes,
+			rollout,
 		)
 		if err != nil {
 			l.Error("failed to CreateFullUpdateStorage",
@@ -277,7 +356,7 @@ func doHandleGeneratePackage(l *zap.Logger, v *VersionLogic) func(ctx context.Co
 			arch       = payload.Arch
 			resourceId = payload.ResourceId
 		)
-		err := v.GenerateIncrementalPackage(ctx, resourceId, target, current, system, arch)
+		err := v.GenerateIncrementalPackage(ctx, resourceId, target, current, system, arch, payload.PatchFormat)
 		if err != nil {
 			l.Sugar().Error("generate incremental update package task failed: ", string(task.Payload()))
 			return err
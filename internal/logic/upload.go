@@ -0,0 +1,306 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/filehash"
+	"github.com/bytedance/sonic"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/ksuid"
+	"go.uber.org/zap"
+)
+
+// sessions are refreshed by every chunk, abandoned ones expire
+const uploadSessionTTL = 24 * time.Hour
+
+// partSuffix chunks are stored as <key>.part/<index> in the blob store so any instance can continue a session
+const partSuffix = ".part"
+
+func uploadSessionKey(id string) string {
+	return strings.Join([]string{misc.UploadSessionKey, id}, ":")
+}
+
+// CreateUploadSession a native alternative of the oss post policy, the package is streamed into OSSDir
+func (l *VersionLogic) CreateUploadSession(ctx context.Context, param CreateVersionParam, size int64) (*UploadSession, error) {
+	filename := filepath.Base(filepath.Clean(param.Filename))
+	if filename == "." || filename == ".." || filename == string(filepath.Separator) {
+		return nil, errs.ErrInvalidParams.WithDetails("invalid filename")
+	}
+	param.Filename = filename
+
+	dir, filename, err := l.prepareVersionUpload(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &UploadSession{
+		Id:         ksuid.New().String(),
+		ResourceId: param.ResourceID,
+		Name:       param.Name,
+		OS:         param.OS,
+		Arch:       param.Arch,
+		Channel:    param.Channel,
+		Key:        path.Join(dir, filename),
+		Size:       size,
+		CreatedAt:  time.Now(),
+
+		RolloutPercent: param.RolloutPercent,
+	}
+	if err := l.saveUploadSession(ctx, s); err != nil {
+		return nil, err
+	}
+
+	l.logger.Info("upload session created",
+		zap.String("resource id", s.ResourceId),
+		zap.String("version name", s.Name),
+		zap.String("session id", s.Id),
+		zap.String("key", s.Key),
+		zap.Int64("size", s.Size),
+	)
+	return s, nil
+}
+
+func (l *VersionLogic) GetUploadSession(ctx context.Context, resourceId, id string) (*UploadSession, error) {
+	return l.loadUploadSession(ctx, resourceId, id)
+}
+
+// WriteUploadChunk chunks must be sent in order, a mismatched offset returns the session so the client can resume
+func (l *VersionLogic) WriteUploadChunk(ctx context.Context, resourceId, id string, offset int64, chunk []byte) (*UploadSession, error) {
+	unlock, err := l.lockUploadSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s, err := l.loadUploadSession(ctx, resourceId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.uploadParts(s).write(ctx, s, offset, chunk); err != nil {
+		var biz *errs.Error
+		if !errors.As(err, &biz) {
+			l.logger.Error("failed to write upload chunk",
+				zap.String("session id", id),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	if err := l.saveUploadSession(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CompleteUpload verifies the package and hands it to the same flow as the oss callback, returns the status key
+func (l *VersionLogic) CompleteUpload(ctx context.Context, resourceId, id, sha256 string) (string, error) {
+	unlock, err := l.lockUploadSession(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	s, err := l.loadUploadSession(ctx, resourceId, id)
+	if err != nil {
+		return "", err
+	}
+	if err := l.uploadParts(s).complete(ctx, s, sha256); err != nil {
+		if errors.Is(err, errs.ErrUploadChecksumInvalid) {
+			l.logger.Warn("upload checksum mismatch",
+				zap.String("session id", id),
+				zap.String("expected", sha256),
+			)
+			if e := l.saveUploadSession(ctx, s); e != nil {
+				return "", e
+			}
+		}
+		return "", err
+	}
+	// the session is kept until the callback succeeds so complete can be retried
+	if err := l.saveUploadSession(ctx, s); err != nil {
+		return "", err
+	}
+
+	key, err := l.ProcessCreateVersionCallback(ctx, CreateVersionCallBackParam{
+		ResourceID: s.ResourceId,
+		Name:       s.Name,
+		OS:         s.OS,
+		Arch:       s.Arch,
+		Channel:    s.Channel,
+		Key:        s.Key,
+
+		RolloutPercent: s.RolloutPercent,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := l.rdb.Del(ctx, uploadSessionKey(id)).Err(); err != nil {
+		l.logger.Warn("failed to delete upload session",
+			zap.String("session id", id),
+			zap.Error(err),
+		)
+	}
+	return key, nil
+}
+
+// lockUploadSession the lock is extended until it is released, hashing a large package outlasts its expiry
+func (l *VersionLogic) lockUploadSession(ctx context.Context, id string) (func(), error) {
+	mutex := l.sync.NewMutex(uploadSessionKey(id)+":lock", redsync.WithExpiry(time.Minute))
+	if err := mutex.LockContext(ctx); err != nil {
+		return nil, errs.ErrUploadBusy.Wrap(err)
+	}
+	c, cancel := context.WithCancel(context.Background())
+	go renewMutex(c, mutex)
+	return func() {
+		cancel()
+		_, _ = mutex.Unlock()
+	}, nil
+}
+
+func (l *VersionLogic) uploadParts(s *UploadSession) *uploadParts {
+	return &uploadParts{
+		store:   l.storageLogic.Blob,
+		prefix:  s.Key + partSuffix + "/",
+		workDir: l.storageLogic.RootDir,
+	}
+}
+
+// uploadParts the chunks of a session as objects below prefix, workDir holds the package while it is assembled
+type uploadParts struct {
+	store   blob.Store
+	prefix  string
+	workDir string
+}
+
+func (u *uploadParts) key(index int) string {
+	return fmt.Sprintf("%s%08d", u.prefix, index)
+}
+
+// write stores the chunk at offset and advances the session, a chunk of an interrupted request is overwritten
+func (u *uploadParts) write(ctx context.Context, s *UploadSession, offset int64, chunk []byte) error {
+	if s.Pushed {
+		return errs.ErrInvalidParams.WithDetails("upload is already complete")
+	}
+	if offset != s.Offset {
+		return errs.ErrUploadOffsetMismatch.WithDetails(s)
+	}
+	if offset+int64(len(chunk)) > s.Size {
+		return errs.ErrInvalidParams.WithDetails("chunk exceeds the declared size")
+	}
+	if err := u.store.Put(ctx, u.key(s.Parts), bytes.NewReader(chunk), int64(len(chunk))); err != nil {
+		return err
+	}
+	s.Parts++
+	s.Offset += int64(len(chunk))
+	return nil
+}
+
+// complete assembles the chunks, verifies the checksum and pushes the package to the session key,
+// a mismatch resets the session, a session pushed before is left alone
+func (u *uploadParts) complete(ctx context.Context, s *UploadSession, sha256 string) error {
+	if s.Pushed {
+		return nil
+	}
+	if s.Offset != s.Size {
+		return errs.ErrUploadIncomplete.WithDetails(s)
+	}
+	if err := os.MkdirAll(u.workDir, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(u.workDir, "upload-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	for i := range s.Parts {
+		if err := u.copyPart(ctx, f, i); err != nil {
+			return err
+		}
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	hash, err := filehash.Sum(f)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(hash, sha256) {
+		if err := u.clear(ctx); err != nil {
+			return err
+		}
+		s.Offset, s.Parts = 0, 0
+		return errs.ErrUploadChecksumInvalid.WithDetails(s)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := u.store.Put(ctx, s.Key, f, s.Size); err != nil {
+		return err
+	}
+	s.Pushed = true
+	// leftovers are removed by the reconciler
+	_ = u.clear(ctx)
+	return nil
+}
+
+func (u *uploadParts) copyPart(ctx context.Context, w io.Writer, index int) error {
+	r, err := u.store.Get(ctx, u.key(index))
+	if err != nil {
+		return err
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func (u *uploadParts) clear(ctx context.Context) error {
+	return blob.DeletePrefix(ctx, u.store, u.prefix)
+}
+
+func (l *VersionLogic) loadUploadSession(ctx context.Context, resourceId, id string) (*UploadSession, error) {
+	val, err := l.rdb.Get(ctx, uploadSessionKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errs.ErrUploadSessionNotFound
+		}
+		return nil, err
+	}
+	var s UploadSession
+	if err := sonic.Unmarshal(val, &s); err != nil {
+		return nil, err
+	}
+	if s.ResourceId != resourceId {
+		return nil, errs.ErrUploadSessionNotFound
+	}
+	return &s, nil
+}
+
+func (l *VersionLogic) saveUploadSession(ctx context.Context, s *UploadSession) error {
+	s.ExpiresAt = time.Now().Add(uploadSessionTTL)
+	buf, err := sonic.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return l.rdb.Set(ctx, uploadSessionKey(s.Id), buf, uploadSessionTTL).Err()
+}
@@ -0,0 +1,96 @@
+package logic
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/blob"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/filehash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUpload(t *testing.T, payload string) (*uploadParts, *UploadSession, blob.Store) {
+	store, err := blob.NewLocal(t.TempDir(), "")
+	require.NoError(t, err)
+	s := &UploadSession{Key: "res/1/any/pkg.zip", Size: int64(len(payload))}
+	return &uploadParts{store: store, prefix: s.Key + partSuffix + "/", workDir: t.TempDir()}, s, store
+}
+
+func sha256Of(t *testing.T, payload string) string {
+	hash, err := filehash.Sum(strings.NewReader(payload))
+	require.NoError(t, err)
+	return hash
+}
+
+func TestUploadPartsResume(t *testing.T) {
+	const payload = "hello resumable world"
+	u, s, store := newTestUpload(t, payload)
+	ctx := context.Background()
+
+	require.NoError(t, u.write(ctx, s, 0, []byte(payload[:6])))
+	assert.Equal(t, int64(6), s.Offset)
+
+	// another instance continues from the session alone
+	u = &uploadParts{store: store, prefix: u.prefix, workDir: t.TempDir()}
+	require.ErrorIs(t, u.write(ctx, s, 0, []byte(payload[:6])), errs.ErrUploadOffsetMismatch)
+	require.ErrorIs(t, u.write(ctx, s, 6, []byte(payload[6:]+"!")), errs.ErrInvalidParams)
+	require.ErrorIs(t, u.complete(ctx, s, sha256Of(t, payload)), errs.ErrUploadIncomplete)
+
+	// a chunk of an interrupted request is overwritten on retry
+	require.NoError(t, store.Put(ctx, u.key(s.Parts), strings.NewReader("garbage"), 7))
+	require.NoError(t, u.write(ctx, s, 6, []byte(payload[6:])))
+	assert.Equal(t, 2, s.Parts)
+
+	require.NoError(t, u.complete(ctx, s, sha256Of(t, payload)))
+	r, err := store.Get(ctx, s.Key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, payload, string(got))
+}
+
+func TestUploadPartsChecksumMismatch(t *testing.T) {
+	const payload = "package content"
+	u, s, store := newTestUpload(t, payload)
+	ctx := context.Background()
+
+	require.NoError(t, u.write(ctx, s, 0, []byte(payload)))
+	require.ErrorIs(t, u.complete(ctx, s, sha256Of(t, "other content")), errs.ErrUploadChecksumInvalid)
+	assert.Zero(t, s.Offset)
+	assert.Zero(t, s.Parts)
+	assert.False(t, s.Pushed)
+
+	list, err := store.List(ctx, "res/")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// the upload starts over
+	require.NoError(t, u.write(ctx, s, 0, []byte(payload)))
+	require.NoError(t, u.complete(ctx, s, sha256Of(t, payload)))
+}
+
+func TestUploadPartsComplete(t *testing.T) {
+	const payload = "package content"
+	u, s, store := newTestUpload(t, payload)
+	ctx := context.Background()
+
+	require.NoError(t, u.write(ctx, s, 0, []byte(payload[:3])))
+	require.NoError(t, u.write(ctx, s, 3, []byte(payload[3:])))
+	require.NoError(t, u.complete(ctx, s, strings.ToUpper(sha256Of(t, payload))))
+	assert.True(t, s.Pushed)
+
+	list, err := store.List(ctx, "res/")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, s.Key, list[0].Key)
+
+	// retrying complete after a failed callback doesn't need the parts anymore
+	require.NoError(t, u.complete(ctx, s, sha256Of(t, payload)))
+	require.ErrorIs(t, u.write(ctx, s, s.Offset, []byte("x")), errs.ErrInvalidParams)
+}
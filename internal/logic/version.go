@@ -5,12 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
@@ -22,14 +25,13 @@ import (
 	"github.com/MirrorChyan/resource-backend/internal/cache"
 	. "github.com/MirrorChyan/resource-backend/internal/config"
 	"github.com/MirrorChyan/resource-backend/internal/ent"
-	"github.com/MirrorChyan/resource-backend/internal/ent/version"
 	"github.com/MirrorChyan/resource-backend/internal/logic/dispense"
 	"github.com/MirrorChyan/resource-backend/internal/logic/misc"
 	. "github.com/MirrorChyan/resource-backend/internal/model"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
 	"github.com/MirrorChyan/resource-backend/internal/oss"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/dltoken"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/filehash"
-	"github.com/MirrorChyan/resource-backend/internal/pkg/fileops"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/patcher"
 	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
 	"github.com/MirrorChyan/resource-backend/internal/repo"
@@ -84,6 +86,11 @@ func NewVersionLogic(
 		sync:            sync,
 		cacheGroup:      cacheGroup,
 	}
+	if err := reloadDownloadSigner(); err != nil {
+		logger.Fatal("invalid download token keys please check auth config",
+			zap.Error(err),
+		)
+	}
 	InitAsynqServer(logger, l)
 	return l
 }
@@ -96,19 +103,6 @@ func (l *VersionLogic) GetCacheGroup() *cache.MultiCacheGroup {
 	return l.cacheGroup
 }
 
-func (l *VersionLogic) GetVersionChannel(channel string) version.Channel {
-	switch channel {
-	case types.ChannelStable.String():
-		return version.ChannelStable
-	case types.ChannelBeta.String():
-		return version.ChannelBeta
-	case types.ChannelAlpha.String():
-		return version.ChannelAlpha
-	default:
-		return version.ChannelStable
-	}
-}
-
 func (l *VersionLogic) GetVersionByName(ctx context.Context, param GetVersionByNameParam) (*ent.Version, error) {
 	return l.versionRepo.GetVersionByName(ctx, param.ResourceID, param.VersionName)
 
@@ -152,12 +146,24 @@ func (l *VersionLogic) CreateVersion(ctx context.Context, resourceId, channel, n
 		return nil, err
 	}
 
-	verChannel := l.GetVersionChannel(channel)
-
-	return l.versionRepo.CreateVersion(ctx, resourceId, verChannel, name, number)
+	return l.versionRepo.CreateVersion(ctx, resourceId, channel, name, number)
 }
 
 func (l *VersionLogic) CreatePreSignedUrl(ctx context.Context, param CreateVersionParam) (*oss.SignaturePolicyToken, error) {
+	dir, filename, err := l.prepareVersionUpload(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oss.AcquirePolicyToken(dir, filename)
+	if err != nil {
+		return nil, err
+	}
+	return token, err
+}
+
+// prepareVersionUpload creates the version and returns where its package has to be uploaded, relative to OSSDir
+func (l *VersionLogic) prepareVersionUpload(ctx context.Context, param CreateVersionParam) (string, string, error) {
 	var (
 		resourceId  = param.ResourceID
 		versionName = param.Name
@@ -168,9 +174,9 @@ func (l *VersionLogic) CreatePreSignedUrl(ctx context.Context, param CreateVersi
 	)
 
 	if exists, err := l.resourceLogic.Exists(ctx, resourceId); err != nil {
-		return nil, err
+		return "", "", err
 	} else if !exists {
-		return nil, errs.ErrResourceNotFound
+		return "", "", errs.ErrResourceNotFound
 	}
 
 	if exists, err := l.ExistNameWithOSAndArch(ctx, ExistVersionNameWithOSAndArchParam{
@@ -179,14 +185,35 @@ func (l *VersionLogic) CreatePreSignedUrl(ctx context.Context, param CreateVersi
 		OS:          system,
 		Arch:        arch,
 	}); err != nil {
-		return nil, err
+		return "", "", err
 	} else if exists {
-		return nil, errs.ErrResourceVersionNameConflict
+		return "", "", errs.ErrResourceVersionNameConflict
+	}
+
+	// new versions are validated when they are created, a version created before by a release note or
+	// custom data update is validated with its first package
+	if ver, err := l.versionRepo.GetVersionByName(ctx, resourceId, versionName); err == nil {
+		stored, err := l.versionRepo.HasStorages(ctx, ver.ID)
+		if err != nil {
+			return "", "", err
+		}
+		if !stored {
+			if err := l.doValidateVersionName(ctx, resourceId, ver.Channel, versionName); err != nil {
+				return "", "", err
+			}
+		}
+	} else if !ent.IsNotFound(err) {
+		return "", "", err
 	}
 
 	ver, err := l.LoadStoreNewVersionTx(ctx, resourceId, versionName, channel)
 	if err != nil {
-		return nil, err
+		return "", "", err
+	}
+	if param.PublishAt != nil {
+		if err := l.doSchedulePublish(ctx, resourceId, ver.ID, param.PublishAt); err != nil {
+			return "", "", err
+		}
 	}
 
 	mk := strings.Join([]string{misc.ProcessStoragePendingKey,
@@ -195,9 +222,9 @@ func (l *VersionLogic) CreatePreSignedUrl(ctx context.Context, param CreateVersi
 
 	val, err := l.rdb.Get(ctx, mk).Result()
 	if err != nil && !errors.Is(err, redis.Nil) {
-		return nil, err
+		return "", "", err
 	} else if err == nil || val == misc.ProcessFlag {
-		return nil, errs.ErrResourceVersionStorageProcessing
+		return "", "", errs.ErrResourceVersionStorageProcessing
 	}
 
 	dest := l.storageLogic.BuildVersionStorageDirPath(resourceId, ver.ID, system, arch)
@@ -208,7 +235,7 @@ func (l *VersionLogic) CreatePreSignedUrl(ctx context.Context, param CreateVersi
 			zap.String("resource id", resourceId),
 			zap.Error(err),
 		)
-		return nil, err
+		return "", "", err
 	}
 
 	if ut == types.UpdateIncremental {
@@ -219,42 +246,38 @@ func (l *VersionLogic) CreatePreSignedUrl(ctx context.Context, param CreateVersi
 			filename = strings.Join([]string{misc.DefaultResourceName, misc.TgzSuffix}, "")
 		default:
 			text := fmt.Sprintf("incremental resource %s file ext not supported", filename)
-			return nil, errs.NewUnchecked(text)
+			return "", "", errs.NewUnchecked(text)
 		}
 	}
 
-	token, err := oss.AcquirePolicyToken(l.cleanRootStoragePath(dest), filename)
-	if err != nil {
-		return nil, err
-	}
-	return token, err
+	return l.cleanRootStoragePath(dest), filename, nil
 }
 
 // doVerifyRequiredFileType The file must be in zip or tgz format
-func (l *VersionLogic) doVerifyRequiredFileType(dest string) FileDetectResult {
-	f, err := os.Open(dest)
+func (l *VersionLogic) doVerifyRequiredFileType(ctx context.Context, key string) FileDetectResult {
+	r, err := l.storageLogic.Blob.Get(ctx, key)
 	if err != nil {
 		l.logger.Error("Failed to open file please check file",
-			zap.String("file", dest),
+			zap.String("file", key),
 			zap.Error(err),
 		)
 		return FileDetectResult{
 			Valid: false,
 		}
 	}
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
 	sniff := make([]byte, misc.SniffLen)
-	_, _ = f.Read(sniff)
-	if strings.HasSuffix(dest, misc.ZipSuffix) && bytes.HasPrefix(sniff, misc.ZipMagicHeader) {
+	_, _ = io.ReadFull(r, sniff)
+	if strings.HasSuffix(key, misc.ZipSuffix) && bytes.HasPrefix(sniff, misc.ZipMagicHeader) {
 		return FileDetectResult{
 			Valid:    true,
 			FileType: types.Zip,
 		}
 	}
 
-	if strings.HasSuffix(dest, misc.TgzSuffix) && bytes.HasPrefix(sniff, misc.TgzMagicHeader) {
+	if strings.HasSuffix(key, misc.TgzSuffix) && bytes.HasPrefix(sniff, misc.TgzMagicHeader) {
 		return FileDetectResult{
 			Valid:    true,
 			FileType: types.Tgz,
@@ -285,8 +308,8 @@ func (l *VersionLogic) ProcessCreateVersionCallback(ctx context.Context, param C
 		versionId = ver.ID
 	)
 
-	source := filepath.Join(l.storageLogic.OSSDir, key)
-	_, err = os.Stat(source)
+	source := l.storageLogic.BlobPath(key)
+	_, err = l.storageLogic.Blob.Stat(ctx, key)
 	if err != nil {
 		l.logger.Error("Failed to stat archive file pleas check the oss upload",
 			zap.String("archive path", source),
@@ -342,7 +365,7 @@ func (l *VersionLogic) ProcessCreateVersionCallback(ctx context.Context, param C
 	)
 
 	if isIncremental {
-		val := l.doVerifyRequiredFileType(source)
+		val := l.doVerifyRequiredFileType(ctx, key)
 		if !val.Valid {
 			rollback()
 			return "", misc.NotAllowedFileTypeError
@@ -366,6 +389,7 @@ func (l *VersionLogic) ProcessCreateVersionCallback(ctx context.Context, param C
 		Arch:            arch,
 		Channel:         channel,
 		StatusKey:       statusKey,
+		RolloutPercent:  param.RolloutPercent,
 		IncrementalType: fileType,
 	}
 
@@ -405,45 +429,101 @@ func (l *VersionLogic) ProcessCreateVersionCallback(ctx context.Context, param C
 
 func (l *VersionLogic) DoProcessStorage(ctx context.Context,
 	resourceId string, versionId int,
-	versionName, channel, system, arch, dest string,
+	versionName, channel, system, arch, source, dest string,
 	fileType types.FileType,
 	hashes map[string]string,
+	rolloutPercent int,
 ) error {
 
-	ph, size, err := l.doCalculatePackageHash(dest, resourceId, system, arch)
+	ph, size, err := l.doCalculatePackageHash(ctx, dest, resourceId, system, arch)
 	if err != nil {
 		return err
 	}
 
-	_, err = l.storageLogic.CreateFullUpdateStorage(ctx, versionId,
-		system, arch, dest, fileType,
-		ph, size, hashes,
-	)
-	if err != nil {
-		l.logger.Error("Failed to create storage",
-			zap.Error(err),
+	create := func(packagePath string) error {
+		_, err := l.storageLogic.CreateFullUpdateStorage(ctx, versionId,
+			system, arch, packagePath, fileType,
+			ph, size, hashes, rolloutPercent,
 		)
+		if err != nil {
+			l.logger.Error("Failed to create storage",
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+
+	packagePath := dest
+	sourceKey, uploaded := l.storageLogic.BlobKey(source)
+	if uploaded {
+		var local string
+		if dest != source {
+			local = dest
+		}
+		// the storage is created while the object is locked, a concurrent purge can't delete it in between
+		packagePath, err = l.storageLogic.StoreContentAddressed(ctx, sourceKey, local, ph, size, create)
+	} else {
+		err = create(packagePath)
+	}
+	if err != nil {
 		return err
 	}
-	l.doPostCreateResources(resourceId)
+	if uploaded && packagePath != source {
+		if err := l.storageLogic.Blob.Delete(ctx, sourceKey); err != nil {
+			l.logger.Warn("failed to remove uploaded package",
+				zap.String("key", sourceKey),
+				zap.Error(err),
+			)
+		}
+	}
+	l.EvictLatestVersionInfo(resourceId)
+	l.doPregeneratePatches(ctx, resourceId, versionId, system, arch)
 
-	go l.doWebhookNotify(resourceId, versionName, channel, system, arch)
+	go l.doWebhookNotify(misc.VersionCreatedEvent, resourceId, versionName, channel, system, arch)
 
 	return nil
 }
 
-func (l *VersionLogic) doCalculatePackageHash(dest, resourceId, system, arch string) (string, int64, error) {
-	stat, err := os.Stat(dest)
-	if err != nil {
-		return "", 0, err
+// doCalculatePackageHash dest is either a local package or a package in the blob store
+func (l *VersionLogic) doCalculatePackageHash(ctx context.Context, dest, resourceId, system, arch string) (string, int64, error) {
+	var (
+		size int64
+		open func() (io.ReadCloser, error)
+	)
+	if key, ok := l.storageLogic.BlobKey(dest); ok {
+		info, err := l.storageLogic.Blob.Stat(ctx, key)
+		if err != nil {
+			return "", 0, err
+		}
+		size = info.Size
+		open = func() (io.ReadCloser, error) {
+			return l.storageLogic.Blob.Get(ctx, key)
+		}
+	} else {
+		stat, err := os.Stat(dest)
+		if err != nil {
+			return "", 0, err
+		}
+		size = stat.Size()
+		open = func() (io.ReadCloser, error) {
+			return os.Open(dest)
+		}
 	}
 
 	l.logger.Debug("start calculate package hash",
 		zap.String("package path", dest),
-		zap.Int64("size", stat.Size()),
+		zap.Int64("size", size),
 	)
 
-	hash, err := filehash.Calculate(dest)
+	r, err := open()
+	if err != nil {
+		return "", 0, err
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	hash, err := filehash.Sum(r)
 	if err != nil {
 		l.logger.Error("Failed to calculate full update package hash",
 			zap.String("resource id", resourceId),
@@ -458,7 +538,7 @@ func (l *VersionLogic) doCalculatePackageHash(dest, resourceId, system, arch str
 		zap.String("package path", dest),
 	)
 
-	return hash, stat.Size(), nil
+	return hash, size, nil
 }
 
 func (l *VersionLogic) LoadStoreNewVersionTx(ctx context.Context, resourceId, versionName, channel string) (*ent.Version, error) {
@@ -491,6 +571,9 @@ func (l *VersionLogic) LoadStoreNewVersionTx(ctx context.Context, resourceId, ve
 		return nil, err
 	}
 
+	if err := l.doValidateVersionName(ctx, resourceId, channel, versionName); err != nil {
+		return nil, err
+	}
 	ver, err = l.CreateVersion(ctx, resourceId, channel, versionName)
 	if err != nil {
 		l.logger.Error("Failed to create new version",
@@ -504,7 +587,8 @@ func (l *VersionLogic) LoadStoreNewVersionTx(ctx context.Context, resourceId, ve
 	return ver, err
 }
 
-func (l *VersionLogic) doWebhookNotify(resourceId, versionName, channel, os, arch string) {
+// doWebhookNotify event is created for an upload or promoted when a version is made available in another channel
+func (l *VersionLogic) doWebhookNotify(event, resourceId, versionName, channel, os, arch string) {
 	var (
 		cfg     = GConfig
 		webhook = cfg.Extra.CreateNewVersionWebhook
@@ -518,6 +602,7 @@ func (l *VersionLogic) doWebhookNotify(resourceId, versionName, channel, os, arc
 	}
 
 	buf, e := sonic.Marshal(map[string]string{
+		"event":        event,
 		"resource_id":  resourceId,
 		"version_name": versionName,
 		"channel":      channel,
@@ -537,16 +622,28 @@ func (l *VersionLogic) doWebhookNotify(resourceId, versionName, channel, os, arc
 	_ = resp.Body.Close()
 }
 
-func (l *VersionLogic) doPostCreateResources(resourceId string) {
+// EvictLatestVersionInfo drops the cached latest versions of every channel of the resource on this instance
+func (l *VersionLogic) EvictLatestVersionInfo(resourceId string) {
+	channels, err := l.resourceLogic.ListChannelNames(context.Background(), resourceId)
+	if err != nil {
+		l.logger.Warn("failed to list channels, evict built-in channels only",
+			zap.String("resource id", resourceId),
+			zap.Error(err),
+		)
+		channels = misc.TotalChannel
+	}
 	cg := l.GetCacheGroup()
 	for _, system := range misc.TotalOs {
 		for _, arch := range misc.TotalArch {
-			for _, channel := range misc.TotalChannel {
+			for _, channel := range channels {
 				key := cg.GetCacheKey(resourceId, system, arch, channel)
 				cg.MultiVersionInfoCache.Delete(key)
 			}
 		}
 	}
+	for _, channel := range channels {
+		cg.ChangelogCache.Delete(cg.GetCacheKey(resourceId, channel))
+	}
 }
 
 func (l *VersionLogic) GetProcessingStatus(ctx context.Context, key string) (misc.PollingStatus, error) {
@@ -563,7 +660,7 @@ func (l *VersionLogic) GetProcessingStatus(ctx context.Context, key string) (mis
 	return misc.StatusNotFound, nil
 }
 
-func (l *VersionLogic) GenerateIncrementalPackage(ctx context.Context, resourceId string, target, current int, system, arch string) error {
+func (l *VersionLogic) GenerateIncrementalPackage(ctx context.Context, resourceId string, target, current int, system, arch string, format int) error {
 	// only use package hash and file hash
 	targetInfo, currentInfo, err := l.fetchStorageInfoTuple(ctx, target, current, system, arch)
 	if err != nil {
@@ -583,29 +680,49 @@ func (l *VersionLogic) GenerateIncrementalPackage(ctx context.Context, resourceI
 		return err
 	}
 
+	targetPackage, err := l.storageLogic.LocalPackage(ctx, resourceId, target, system, arch,
+		targetInfo.PackagePath, types.FileType(targetInfo.FileType),
+	)
+	if err != nil {
+		return err
+	}
+	currentPackage, err := l.storageLogic.LocalPackage(ctx, resourceId, current, system, arch,
+		currentInfo.PackagePath, types.FileType(currentInfo.FileType),
+	)
+	if err != nil {
+		return err
+	}
+
 	err = l.doCreateIncrementalUpdatePackage(ctx, PatchTaskExecuteParam{
 		ResourceId:           resourceId,
-		TargetOriginPackage:  targetInfo.PackagePath,
+		TargetOriginPackage:  targetPackage,
 		TargetVersionId:      target,
 		CurrentVersionId:     current,
 		TargetFileType:       targetInfo.FileType,
 		CurrentFileType:      currentInfo.FileType,
 		TargetStorageHashes:  targetInfo.FileHashes,
 		CurrentStorageHashes: currentInfo.FileHashes,
+		CurrentOriginPackage: currentPackage,
 		OS:                   system,
 		Arch:                 arch,
+		PatchFormat:          patcher.NormalizeFormat(format),
 	})
 	if err != nil {
 		return err
 	}
 
-	cacheKey := l.cacheGroup.GetCacheKey(
-		strconv.Itoa(target),
-		strconv.Itoa(current),
-		system,
-		arch,
-	)
-	l.cacheGroup.IncrementalUpdateInfoCache.Delete(cacheKey)
+	// every format able to use the new package
+	for f := patcher.NormalizeFormat(format); f <= patcher.LatestFormat; f++ {
+		cacheKey := l.cacheGroup.GetCacheKey(
+			strconv.Itoa(target),
+			strconv.Itoa(current),
+			system,
+			arch,
+			strconv.Itoa(f),
+		)
+		l.cacheGroup.IncrementalUpdateInfoCache.Delete(cacheKey)
+	}
+	l.cacheGroup.PatchChainCache.EvictAll()
 
 	return nil
 }
@@ -637,15 +754,24 @@ func (l *VersionLogic) doCreateIncrementalUpdatePackage(ctx context.Context, par
 		return fmt.Errorf("failed to create target directory: %w", err)
 	}
 
+	name := strconv.Itoa(current)
+	if param.PatchFormat > patcher.FormatFull {
+		name = strings.Join([]string{name, "v" + strconv.Itoa(param.PatchFormat)}, ".")
+	}
 	destPackage := filepath.Join(dir, strings.Join([]string{
-		strconv.Itoa(current),
+		name,
 		types.GetFileSuffix(types.FileType(param.TargetFileType)),
 	}, ""))
 
 	tuple := PatchInfoTuple{
-		SrcPackage:  originPackage,
-		DestPackage: destPackage,
-		FileType:    param.TargetFileType,
+		SrcPackage:    originPackage,
+		DestPackage:   destPackage,
+		FileType:      param.TargetFileType,
+		FormatVersion: param.PatchFormat,
+		BasePackage:   param.CurrentOriginPackage,
+		BaseFileType:  param.CurrentFileType,
+		SrcHashes:     param.TargetStorageHashes,
+		BaseHashes:    param.CurrentStorageHashes,
 	}
 	cleanupLocal := func() {
 		if err := os.Remove(destPackage); err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -664,10 +790,10 @@ func (l *VersionLogic) doCreateIncrementalUpdatePackage(ctx context.Context, par
 		)
 		return err
 	}
-	cleanupOSS := func(path string) {
-		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+	cleanupOSS := func(key string) {
+		if err := l.storageLogic.Blob.Delete(ctx, key); err != nil {
 			l.logger.Warn("failed to remove oss patch package",
-				zap.String("path", path),
+				zap.String("key", key),
 				zap.Error(err),
 			)
 		}
@@ -695,19 +821,18 @@ func (l *VersionLogic) doCreateIncrementalUpdatePackage(ctx context.Context, par
 		)
 		return err
 	}
-	ossPackage := filepath.Join(l.storageLogic.OSSDir, l.cleanRootStoragePath(destPackage))
-	_, statErr := os.Stat(ossPackage)
+	var (
+		ossKey     = l.cleanRootStoragePath(destPackage)
+		ossPackage = l.storageLogic.BlobPath(ossKey)
+	)
+	_, statErr := l.storageLogic.Blob.Stat(ctx, ossKey)
 	ossExisted := statErr == nil
-	if err := os.MkdirAll(filepath.Dir(ossPackage), os.ModePerm); err != nil {
-		cleanupLocal()
-		return err
-	}
-	err = fileops.CopyFile(destPackage, ossPackage)
+	err = l.storageLogic.PushBlob(ctx, destPackage, ossKey)
 	if err != nil {
 		cleanupLocal()
 		l.logger.Error("failed to copy local to oss",
 			zap.String("source", destPackage),
-			zap.String("destination", ossPackage),
+			zap.String("destination", ossKey),
 			zap.Error(err),
 		)
 		return err
@@ -716,7 +841,7 @@ func (l *VersionLogic) doCreateIncrementalUpdatePackage(ctx context.Context, par
 	err = l.repo.WithTx(ctx, func(tx *ent.Tx) (err error) {
 		_, err = l.storageLogic.CreateIncrementalUpdateStorage(ctx, tx,
 			target, current, tuple.FileType, stat.Size(),
-			system, arch, ossPackage, hashes,
+			system, arch, ossPackage, hashes, tuple.FormatVersion,
 		)
 		if err != nil {
 			l.logger.Error("Failed to create incremental update storage",
@@ -730,7 +855,7 @@ func (l *VersionLogic) doCreateIncrementalUpdatePackage(ctx context.Context, par
 
 	if err != nil {
 		if !ossExisted {
-			cleanupOSS(ossPackage)
+			cleanupOSS(ossKey)
 		}
 		cleanupLocal()
 		l.logger.Error("Failed to commit transaction",
@@ -742,25 +867,44 @@ func (l *VersionLogic) doCreateIncrementalUpdatePackage(ctx context.Context, par
 	return nil
 }
 
-func (l *VersionLogic) GetMultiLatestVersionInfo(resourceId, os, arch, channel string) (*LatestVersionInfo, error) {
+func (l *VersionLogic) loadMultiVersionInfo(resourceId, os, arch, channel string) (*MultiVersionInfo, error) {
 	var (
 		key = l.cacheGroup.GetCacheKey(resourceId, os, arch, channel)
 	)
 	val, err := l.cacheGroup.MultiVersionInfoCache.ComputeIfAbsent(key, func() (*MultiVersionInfo, error) {
-		info, err := l.doGetLatestVersionInfo(resourceId, os, arch, channel)
+		yanked, err := l.storageLogic.storageRepo.ListYankedVersions(context.Background(), resourceId, os, arch)
+		if err != nil {
+			return nil, err
+		}
+		critical, err := l.doListCriticalVersions(context.Background(), resourceId, channel)
+		if err != nil {
+			return nil, err
+		}
+		info, rollouts, err := l.doGetLatestVersionInfo(resourceId, os, arch, channel)
 		switch {
 		case err == nil:
-			return &MultiVersionInfo{LatestVersionInfo: info}, nil
+			return &MultiVersionInfo{LatestVersionInfo: info, Rollouts: rollouts, Yanked: yanked, Critical: critical}, nil
 		case errors.Is(err, errs.ErrResourceNotFound):
-			return &MultiVersionInfo{}, nil
+			return &MultiVersionInfo{Yanked: yanked, Critical: critical}, nil
 		}
 		return nil, err
 	})
 	if err != nil {
 		return nil, err
 	}
+	return *val, nil
+}
 
-	if info := (*val).LatestVersionInfo; info != nil {
+// GetMultiLatestVersionInfo rolloutKey (cdk or ip) puts the client into a rollout bucket,
+// clients already on a version still rolling out keep being offered it
+func (l *VersionLogic) GetMultiLatestVersionInfo(resourceId, os, arch, channel, rolloutKey, currentVersion string) (*LatestVersionInfo, error) {
+	val, err := l.loadMultiVersionInfo(resourceId, os, arch, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := rolloutBucket(resourceId, rolloutKey)
+	if info := pickRollout(val.LatestVersionInfo, val.Rollouts, bucket, currentVersion); info != nil {
 		if !info.PackagePath.Valid || info.PackagePath.String == "" {
 			l.logger.Error("latest resource version storage not found please check storage path",
 				zap.String("resource id", resourceId),
@@ -782,65 +926,82 @@ func (l *VersionLogic) GetMultiLatestVersionInfo(resourceId, os, arch, channel s
 	return nil, errs.ErrResourceNotFound
 }
 
-func (l *VersionLogic) doGetLatestVersionInfo(resourceId, os, arch, channel string) (*LatestVersionInfo, error) {
+// doGetLatestVersionInfo the newest version offered to every client and the newer ones still rolling out, newest first
+func (l *VersionLogic) doGetLatestVersionInfo(resourceId, os, arch, channel string) (*LatestVersionInfo, []*LatestVersionInfo, error) {
 	info, err := l.rawQuery.GetSpecifiedLatestVersion(resourceId, os, arch)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(info) == 0 {
-		return nil, errs.ErrResourceNotFound
+		return nil, nil, errs.ErrResourceNotFound
 	}
 
-	var stable, beta, alpha *LatestVersionInfo
+	channels, err := l.resourceLogic.ResolveChannelChain(context.Background(), resourceId, channel)
+	if err != nil {
+		return nil, nil, err
+	}
+	comparator, _, err := l.resourceLogic.ComparatorOf(context.Background(), resourceId)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	var (
+		full    = make(map[string]*LatestVersionInfo)
+		partial = make(map[string][]*LatestVersionInfo)
+	)
 	for i := range info {
-		data := info[i]
-		switch data.Channel {
-		case types.ChannelStable.String():
-			stable = &data
-		case types.ChannelBeta.String():
-			beta = &data
-		case types.ChannelAlpha.String():
-			alpha = &data
+		data := &info[i]
+		if data.RolloutPercent < types.RolloutFull {
+			partial[data.Channel] = append(partial[data.Channel], data)
+			continue
 		}
+		full[data.Channel] = data
 	}
 
-	switch channel {
-	case types.ChannelStable.String():
-		if stable != nil {
-			return stable, nil
-		}
-	case types.ChannelBeta.String():
-		v, err := l.doCompare(stable, beta)
-		if err != nil {
-			l.logger.Error("failed to compare stable version",
-				zap.Any("stable", stable),
-				zap.Any("beta", beta),
-			)
-			v = beta
-		}
-		if v != nil {
-			return v, nil
-		}
-	case types.ChannelAlpha.String():
-		v, err := l.doCompare(stable, beta, alpha)
-		if err != nil {
-			l.logger.Error("failed to compare stable version",
-				zap.Any("stable", stable),
-				zap.Any("beta", beta),
-				zap.Any("alpha", alpha),
-			)
-			v = alpha
-		}
-		if v != nil {
-			return v, nil
+	candidates := make([]*LatestVersionInfo, len(channels))
+	for i, c := range channels {
+		candidates[i] = full[c]
+	}
+	latest, err := l.doCompare(comparator, candidates...)
+	if err != nil {
+		l.logger.Error("failed to compare channel versions",
+			zap.Strings("channels", channels),
+			zap.Any("candidates", candidates),
+		)
+		latest = full[channel]
+	}
+
+	var rollouts []*LatestVersionInfo
+	for _, c := range channels {
+		for _, r := range partial[c] {
+			if l.isNewerVersion(comparator, r, latest) {
+				rollouts = append(rollouts, r)
+			}
 		}
 	}
+	slices.SortStableFunc(rollouts, func(a, b *LatestVersionInfo) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
 
-	return nil, errs.ErrResourceNotFound
+	if latest == nil && len(rollouts) == 0 {
+		return nil, nil, errs.ErrResourceNotFound
+	}
+	return latest, rollouts, nil
 }
 
-func (l *VersionLogic) doCompare(args ...*LatestVersionInfo) (*LatestVersionInfo, error) {
+// isNewerVersion names which can't be compared fall back to the creation time
+func (l *VersionLogic) isNewerVersion(c *vercomp.VersionComparator, v, than *LatestVersionInfo) bool {
+	if than == nil {
+		return true
+	}
+	result := c.Compare(than.VersionName, v.VersionName)
+	if !result.Comparable {
+		return v.CreatedAt.After(than.CreatedAt)
+	}
+	return result.Result == vercomp.Less
+}
+
+func (l *VersionLogic) doCompare(c *vercomp.VersionComparator, args ...*LatestVersionInfo) (*LatestVersionInfo, error) {
 	var r *LatestVersionInfo
 	for i := range args {
 		info := args[i]
@@ -850,7 +1011,7 @@ func (l *VersionLogic) doCompare(args ...*LatestVersionInfo) (*LatestVersionInfo
 		if r == nil {
 			r = info
 		} else {
-			result := l.comparator.Compare(r.VersionName, info.VersionName)
+			result := c.Compare(r.VersionName, info.VersionName)
 			if !result.Comparable {
 				err := errors.New("failed to compare versions")
 				l.logger.Error("Failed to compare versions",
@@ -875,26 +1036,73 @@ func (l *VersionLogic) cleanRootStoragePath(p string) string {
 }
 
 func (l *VersionLogic) cleanTwiceStoragePath(p string) string {
-	var rel string
-	switch {
-	case strings.HasPrefix(p, l.storageLogic.RootDir):
-		rel = strings.TrimPrefix(p, l.storageLogic.RootDir)
-	case strings.HasPrefix(p, l.storageLogic.OSSDir):
-		rel = strings.TrimPrefix(p, l.storageLogic.OSSDir)
+	return l.storageLogic.RelPath(p)
+}
+
+// legacy kid of Auth.SignSecret
+const defaultSignKeyId = "0"
+
+// keys in the remote config which affect the download signer
+var signerKeys = []string{
+	"auth.sign_secret",
+	"auth.sign_keys",
+	"auth.sign_key_id",
+}
+
+var downloadSigner atomic.Pointer[dltoken.Signer]
+
+func init() {
+	for _, key := range signerKeys {
+		RegisterKeyListener(KeyListener{
+			Key: key,
+			Listener: func(any) {
+				// a broken update keeps the previous keys
+				if err := reloadDownloadSigner(); err != nil {
+					zap.L().Error("failed to reload download signer", zap.Error(err))
+				}
+			},
+		})
 	}
-	rel = strings.TrimPrefix(rel, string(os.PathSeparator))
-	return strings.ReplaceAll(rel, string(os.PathSeparator), "/")
 }
 
+func reloadDownloadSigner() error {
+	signer, err := newDownloadSigner()
+	if err != nil {
+		return err
+	}
+	downloadSigner.Store(signer)
+	return nil
+}
+
+func newDownloadSigner() (*dltoken.Signer, error) {
+	var (
+		auth = GConfig.Auth
+		keys = maps.Clone(auth.SignKeys)
+		kid  = auth.SignKeyId
+	)
+	if keys == nil {
+		keys = make(map[string]string)
+	}
+	if _, ok := keys[defaultSignKeyId]; !ok && auth.SignSecret != "" {
+		keys[defaultSignKeyId] = auth.SignSecret
+	}
+	if kid == "" {
+		kid = defaultSignKeyId
+	}
+	return dltoken.NewSigner(keys, kid)
+}
+
+// GetDistributeURL the download key is a signed token, any instance can verify it without redis
 func (l *VersionLogic) GetDistributeURL(info *DistributeInfo) (string, error) {
-	// 可以改成无状态的
 	var (
-		ctx    = context.Background()
 		prefix = GConfig.Extra.DownloadRedirectPrefix
-		rk     = ksuid.New().String()
+		ttl    = GConfig.Extra.DownloadEffectiveTime
 	)
+	if ttl <= 0 {
+		ttl = time.Minute * 30
+	}
 
-	val, err := sonic.MarshalString(info)
+	val, err := sonic.Marshal(info)
 	if err != nil {
 		l.logger.Error("Failed to marshal string",
 			zap.Error(err),
@@ -902,28 +1110,48 @@ func (l *VersionLogic) GetDistributeURL(info *DistributeInfo) (string, error) {
 		return "", err
 	}
 
-	key := strings.Join([]string{misc.DispensePrefix, rk}, ":")
+	token := downloadSigner.Load().Sign(val, time.Now().Add(ttl))
 
-	_, err = l.rdb.Set(ctx, key, val, time.Minute*30).Result()
-	if err != nil {
-		l.logger.Error("failed to set distribute info",
-			zap.Error(err),
-		)
-		return "", err
+	url := strings.Join([]string{prefix, token}, "/")
+	return url, nil
+}
+
+func (l *VersionLogic) loadDistributeInfo(ctx context.Context, rk string) (*DistributeInfo, error) {
+	var (
+		info = &DistributeInfo{}
+		val  []byte
+	)
+	if dltoken.IsToken(rk) {
+		var err error
+		val, err = downloadSigner.Load().Verify(rk, time.Now())
+		if err != nil {
+			l.logger.Debug("invalid download token",
+				zap.String("distribute key", rk),
+				zap.Error(err),
+			)
+			return nil, misc.DistributeKeyNotFoundError
+		}
+	} else {
+		// keys stored in redis before signed tokens, remove after they are all expired
+		key := strings.Join([]string{misc.DispensePrefix, rk}, ":")
+		str, err := l.rdb.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			return nil, misc.DistributeKeyNotFoundError
+		}
+		if err != nil {
+			return nil, err
+		}
+		val = []byte(str)
 	}
 
-	url := strings.Join([]string{prefix, rk}, "/")
-	return url, nil
+	if err := sonic.Unmarshal(val, info); err != nil {
+		return nil, err
+	}
+	return info, nil
 }
 
 func (l *VersionLogic) GetDistributeLocation(ctx context.Context, rk string) (string, error) {
-	key := strings.Join([]string{misc.DispensePrefix, rk}, ":")
-	val, err := l.rdb.Get(ctx, key).Result()
-	if err != nil {
-		return "", err
-	}
-	info := &DistributeInfo{}
-	err = sonic.UnmarshalString(val, info)
+	info, err := l.loadDistributeInfo(ctx, rk)
 	if err != nil {
 		return "", err
 	}
@@ -986,7 +1214,16 @@ func (l *VersionLogic) GetDistributeLocation(ctx context.Context, rk string) (st
 		return "", errors.New("unknown error")
 	}
 
-	url, err := l.distributeLogic.Distribute(info)
+	policy, err := l.resourceLogic.FindDistributionPolicyById(ctx, info.Resource)
+	if err != nil {
+		l.logger.Error("Failed to find resource distribution policy",
+			zap.String("resource id", info.Resource),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	url, err := l.distributeLogic.Distribute(info, policy)
 	if err != nil {
 		return "", err
 	}
@@ -995,13 +1232,7 @@ func (l *VersionLogic) GetDistributeLocation(ctx context.Context, rk string) (st
 }
 
 func (l *VersionLogic) GetDownloadInfo(ctx context.Context, rk string) (map[string]string, error) {
-	key := strings.Join([]string{misc.DispensePrefix, rk}, ":")
-	val, err := l.rdb.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-	info := &DistributeInfo{}
-	err = sonic.UnmarshalString(val, info)
+	info, err := l.loadDistributeInfo(ctx, rk)
 	if err != nil {
 		return nil, err
 	}
@@ -1052,15 +1283,16 @@ func (l *VersionLogic) getFullUpdateStorageByCache(ctx context.Context, versionI
 	return *val, err
 }
 
-func (l *VersionLogic) getIncrementalInfoOrEmpty(ctx context.Context, target, current int, os, arch string) (*IncrementalUpdateInfo, error) {
+func (l *VersionLogic) getIncrementalInfoOrEmpty(ctx context.Context, target, current int, os, arch string, format int) (*IncrementalUpdateInfo, error) {
 	cacheKey := l.cacheGroup.GetCacheKey(
 		strconv.Itoa(target),
 		strconv.Itoa(current),
 		os,
 		arch,
+		strconv.Itoa(format),
 	)
 	val, err := l.cacheGroup.IncrementalUpdateInfoCache.ComputeIfAbsent(cacheKey, func() (*IncrementalUpdateInfo, error) {
-		s, err := l.storageLogic.GetIncrementalUpdateStorage(ctx, target, current, os, arch)
+		s, err := l.storageLogic.GetIncrementalUpdateStorage(ctx, target, current, os, arch, format)
 		switch {
 		case err != nil && ent.IsNotFound(err):
 			return &IncrementalUpdateInfo{}, nil
@@ -1093,10 +1325,6 @@ func renewMutex(ctx context.Context, mutex *redsync.Mutex) {
 
 }
 
-func (l *VersionLogic) UpdateReleaseNote(ctx context.Context, param UpdateReleaseNoteDetailParam) error {
-	return l.versionRepo.UpdateVersionReleaseNote(ctx, param.VersionID, param.ReleaseNoteDetail)
-}
-
 func (l *VersionLogic) UpdateCustomData(ctx context.Context, param UpdateReleaseNoteSummaryParam) error {
 	return l.versionRepo.UpdateVersionCustomData(ctx, param.VersionID, param.ReleaseNoteSummary)
 }
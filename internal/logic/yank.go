@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/cache"
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/errs"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+	"go.uber.org/zap"
+)
+
+// YankVersion stops serving the version, /latest falls back to the newest release left
+func (l *VersionLogic) YankVersion(ctx context.Context, param YankVersionParam) error {
+	now := time.Now()
+	return l.doYankVersion(ctx, param, &now)
+}
+
+// UnyankVersion serves a yanked version again
+func (l *VersionLogic) UnyankVersion(ctx context.Context, param YankVersionParam) error {
+	return l.doYankVersion(ctx, param, nil)
+}
+
+func (l *VersionLogic) doYankVersion(ctx context.Context, param YankVersionParam, at *time.Time) error {
+	ver, err := l.versionRepo.GetVersionByName(ctx, param.ResourceId, param.VersionName)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errs.ErrResourceVersionNotFound
+		}
+		return err
+	}
+
+	n, err := l.storageLogic.storageRepo.YankStorages(ctx, ver.ID, param.OS, param.Arch, at, param.Reason, param.Downgrade)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.ErrStorageNotFound
+	}
+
+	l.logger.Info("version yank changed",
+		zap.String("resource id", param.ResourceId),
+		zap.String("version name", param.VersionName),
+		zap.Stringp("os", param.OS),
+		zap.Stringp("arch", param.Arch),
+		zap.Bool("yanked", at != nil),
+		zap.String("reason", param.Reason),
+		zap.Bool("downgrade", param.Downgrade),
+		zap.Int("storages", n),
+	)
+
+	l.doEvictVersionInfo(ctx, param.ResourceId, "yank")
+	return nil
+}
+
+// doEvictVersionInfo patch chains through a version are cached as well, every instance drops its caches
+func (l *VersionLogic) doEvictVersionInfo(ctx context.Context, resourceId, reason string) {
+	l.EvictLatestVersionInfo(resourceId)
+	if err := cache.PublishEvict(ctx, l.rdb, reason); err != nil {
+		l.logger.Warn("failed to publish cache evict",
+			zap.Error(err),
+		)
+	}
+}
+
+// GetYankState whether the version is yanked on the platform and clients on it are offered the previous release
+func (l *VersionLogic) GetYankState(resourceId, os, arch, channel, versionName string) (yanked, downgrade bool, err error) {
+	if versionName == "" {
+		return false, false, nil
+	}
+	val, err := l.loadMultiVersionInfo(resourceId, os, arch, channel)
+	if err != nil {
+		return false, false, err
+	}
+	downgrade, yanked = val.Yanked[versionName]
+	return yanked, downgrade, nil
+}
+
+// HoldOnYanked the yanked version a client on it stays on, nil once a release sorting after it ships
+func (l *VersionLogic) HoldOnYanked(ctx context.Context, resourceId, versionName string, latest *LatestVersionInfo) (*ent.Version, error) {
+	c, _, err := l.resourceLogic.ComparatorOf(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	ver, err := l.versionRepo.GetVersionByName(ctx, resourceId, versionName)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	if sortsAfter(c, latest, ver.Name, ver.Number) {
+		return nil, nil
+	}
+	return ver, nil
+}
+
+// sortsAfter whether latest is newer than the named version, by number when the names can't be compared
+func sortsAfter(c *vercomp.VersionComparator, latest *LatestVersionInfo, name string, number uint64) bool {
+	if r := c.Compare(latest.VersionName, name); r.Comparable {
+		return r.Result == vercomp.Greater
+	}
+	return number > 0 && latest.VersionNumber > number
+}
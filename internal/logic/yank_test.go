@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"testing"
+
+	. "github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/MirrorChyan/resource-backend/internal/pkg/vercomp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortsAfter(t *testing.T) {
+	c := vercomp.NewComparator()
+	tests := []struct {
+		name   string
+		latest LatestVersionInfo
+		yanked string
+		number uint64
+		want   bool
+	}{
+		{"newer release exists", LatestVersionInfo{VersionName: "1.3.0", VersionNumber: 5}, "1.2.0", 4, true},
+		{"fallback to older release", LatestVersionInfo{VersionName: "1.1.0", VersionNumber: 3}, "1.2.0", 4, false},
+		{"same version", LatestVersionInfo{VersionName: "1.2.0", VersionNumber: 4}, "1.2.0", 4, false},
+		{"newer by number", LatestVersionInfo{VersionName: "nightly-b", VersionNumber: 5}, "nightly-a", 4, true},
+		{"older by number", LatestVersionInfo{VersionName: "nightly-b", VersionNumber: 3}, "nightly-a", 4, false},
+		{"unknown version", LatestVersionInfo{VersionName: "nightly-b", VersionNumber: 5}, "nightly-a", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sortsAfter(c, &tt.latest, tt.yanked, tt.number))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/MirrorChyan/resource-backend/internal/ent"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
@@ -10,6 +12,8 @@ type CreateResourceParam struct {
 	Name        string
 	Description string
 	UpdateType  string
+	// empty tries the default parsers
+	VersionScheme string
 }
 
 type CreateVersionParam struct {
@@ -19,6 +23,95 @@ type CreateVersionParam struct {
 	Arch       string
 	Channel    string
 	Filename   string
+	// nil is RolloutFull
+	RolloutPercent *int
+	// nil publishes once processed
+	PublishAt *time.Time
+}
+
+// UploadSession resumable upload state kept in redis, Key is relative to OSSDir
+type UploadSession struct {
+	Id         string `json:"session_id"`
+	ResourceId string `json:"resource_id"`
+	Name       string `json:"name"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	Channel    string `json:"channel"`
+	Key        string `json:"key"`
+	// nil is RolloutFull
+	RolloutPercent *int      `json:"rollout_percent,omitempty"`
+	Size           int64     `json:"size"`
+	Offset         int64     `json:"offset"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	// Parts chunks stored so far, Pushed the package is at Key and only the callback is left
+	Parts  int  `json:"parts"`
+	Pushed bool `json:"pushed"`
+}
+
+// PurgeReport outcome of a purge run, real runs are kept in redis
+type PurgeReport struct {
+	Id         string                `json:"id"`
+	DryRun     bool                  `json:"dry_run"`
+	StartedAt  time.Time             `json:"started_at"`
+	FinishedAt time.Time             `json:"finished_at"`
+	FreedBytes int64                 `json:"freed_bytes"`
+	Purged     int                   `json:"purged"`
+	Failures   int                   `json:"failures"`
+	Resources  []PurgeResourceReport `json:"resources,omitempty"`
+}
+
+type PurgeResourceReport struct {
+	ResourceId string            `json:"resource_id"`
+	FreedBytes int64             `json:"freed_bytes"`
+	Items      []PurgeReportItem `json:"items"`
+	Errors     []string          `json:"errors,omitempty"`
+}
+
+type PurgeReportItem struct {
+	StorageId   int    `json:"storage_id"`
+	VersionId   int    `json:"version_id"`
+	VersionName string `json:"version_name"`
+	Channel     string `json:"channel"`
+	OS          string `json:"os"`
+	Arch        string `json:"arch"`
+	// oss prefix and local working directory
+	Paths []string `json:"paths"`
+	Bytes int64    `json:"bytes"`
+	Error string   `json:"error,omitempty"`
+}
+
+// ReconcileReport package files and storage rows which no longer match
+type ReconcileReport struct {
+	DryRun     bool      `json:"dry_run"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Grace      string    `json:"grace"`
+	FreedBytes int64     `json:"freed_bytes"`
+	// files no storage points to
+	OrphanFiles []OrphanFile `json:"orphan_files"`
+	// storages whose package is gone, excluded from the latest version
+	BrokenStorages []BrokenStorage `json:"broken_storages"`
+	// previously broken storages whose package is back
+	RestoredStorages []int `json:"restored_storages"`
+}
+
+type OrphanFile struct {
+	// oss or local
+	Tree    string    `json:"tree"`
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	// false within the grace period or on a dry run
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BrokenStorage struct {
+	StorageId   int    `json:"storage_id"`
+	PackagePath string `json:"package_path"`
+	// oss object or local file missing
+	Missing string `json:"missing"`
 }
 
 type CreateVersionCallBackParam struct {
@@ -28,6 +121,8 @@ type CreateVersionCallBackParam struct {
 	Arch       string `json:"arch"`
 	Channel    string `json:"channel"`
 	Key        string `json:"key"`
+	// nil is RolloutFull
+	RolloutPercent *int `json:"rollout_percent,omitempty"`
 }
 
 type GetVersionByNameParam struct {
@@ -43,7 +138,9 @@ type ExistVersionNameWithOSAndArchParam struct {
 }
 
 type UpdateReleaseNoteDetailParam struct {
-	VersionID         int
+	VersionID int
+	// empty for the default locale
+	Locale            string
 	ReleaseNoteDetail string
 }
 
@@ -56,6 +153,10 @@ type UpdateRequestParam struct {
 	ResourceId         string
 	CurrentVersionName string
 	TargetVersionInfo  *LatestVersionInfo
+	// highest patch format the client understands
+	PatchFormat int
+	// client accepts a chain of incremental packages
+	PatchChain bool
 }
 
 type UpdateInfoTuple struct {
@@ -63,13 +164,20 @@ type UpdateInfoTuple struct {
 	PackagePath string
 	UpdateType  string
 	Filesize    int64
+	Chain       []PatchEdge
 }
+type PublishTaskPayload struct {
+	ResourceId string
+	VersionId  int
+}
+
 type PatchTaskPayload struct {
 	ResourceId       string
 	CurrentVersionId int
 	TargetVersionId  int
 	OS               string
 	Arch             string
+	PatchFormat      int
 }
 
 type StorageInfoCreatePayload struct {
@@ -81,6 +189,8 @@ type StorageInfoCreatePayload struct {
 	Arch        string
 	Channel     string
 	StatusKey   string
+	// nil is RolloutFull
+	RolloutPercent *int
 
 	IncrementalType types.FileType
 }
@@ -88,8 +198,71 @@ type StorageInfoCreatePayload struct {
 type IncrementalUpdateInfo struct {
 	Storage *ent.Storage
 }
+
+// PatchChain the smallest chain of incremental packages, empty when there is none
+type PatchChain struct {
+	Edges []PatchEdge
+	Size  int64
+}
 type MultiVersionInfo struct {
 	LatestVersionInfo *LatestVersionInfo
+	// newer versions offered to a share of the clients, newest first
+	Rollouts []*LatestVersionInfo
+	// yanked version name -> downgrade offered
+	Yanked map[string]bool
+	// critical versions of the channel and the channels it includes
+	Critical []CriticalVersion
+}
+
+// ChangelogVersion a published version of a channel chain
+type ChangelogVersion struct {
+	Name         string
+	Number       uint64
+	Channel      string
+	ReleaseNote  string
+	ReleaseNotes types.ReleaseNotes
+	CreatedAt    time.Time
+}
+
+// ChangelogIndex published versions of a channel chain in release order
+type ChangelogIndex struct {
+	Versions []ChangelogVersion
+	// Positions version name -> versions released up to and including it
+	Positions map[string]int
+	// Comparable every name can be compared, the versions are in comparator order then
+	Comparable bool
+}
+
+type CriticalVersion struct {
+	Name   string
+	Reason string
+}
+
+type RolloutVersionParam struct {
+	ResourceId  string
+	VersionName string
+	// nil for every platform
+	OS      *string
+	Arch    *string
+	Percent int
+}
+
+type PromoteVersionParam struct {
+	ResourceId  string
+	VersionName string
+	Channel     string
+	// empty moves the version, otherwise it is cloned under this name
+	CloneName string
+}
+
+type YankVersionParam struct {
+	ResourceId  string
+	VersionName string
+	// nil for every platform
+	OS        *string
+	Arch      *string
+	Reason    string
+	Downgrade bool
 }
 
 type PatchTaskExecuteParam struct {
@@ -101,6 +274,8 @@ type PatchTaskExecuteParam struct {
 	CurrentFileType      string
 	TargetStorageHashes  map[string]string
 	CurrentStorageHashes map[string]string
+	CurrentOriginPackage string
 	OS                   string
 	Arch                 string
+	PatchFormat          int
 }
@@ -39,6 +39,16 @@ type UpdateInfo struct {
 	SHA256     string
 	UpdateType string
 	Filesize   int64
+	// only for chain update
+	Chain []PatchHop
+}
+
+type PatchHop struct {
+	FromVersion string
+	ToVersion   string
+	RelPath     string
+	SHA256      string
+	Filesize    int64
 }
 
 type DistributeInfo struct {
@@ -60,4 +70,11 @@ type PatchInfoTuple struct {
 	SrcPackage  string
 	DestPackage string
 	FileType    string
+
+	// patch format, deltas against BasePackage are only generated from format 2
+	FormatVersion int
+	BasePackage   string
+	BaseFileType  string
+	SrcHashes     map[string]string
+	BaseHashes    map[string]string
 }
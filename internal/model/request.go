@@ -1,10 +1,61 @@
 package model
 
+import (
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+)
+
 type CreateResourceRequest struct {
-	ID          string `json:"id" validate:"required,min=3,max=64,slug"`
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description" validate:"max=255"`
-	UpdateType  string `json:"update_type" validate:"omitempty,oneof=full incremental"`
+	ID            string `json:"id" validate:"required,min=3,max=64,slug"`
+	Name          string `json:"name" validate:"required"`
+	Description   string `json:"description" validate:"max=255"`
+	UpdateType    string `json:"update_type" validate:"omitempty,oneof=full incremental"`
+	VersionScheme string `json:"version_scheme" validate:"omitempty,oneof=semver loose_semver calver pep440 datetime integer"`
+}
+
+type UpdateVersionSchemeRequest struct {
+	// empty tries the default parsers
+	VersionScheme string `json:"version_scheme" validate:"omitempty,oneof=semver loose_semver calver pep440 datetime integer"`
+}
+
+type UpdateDistributionPolicyRequest struct {
+	Distributors []string `json:"distributors" validate:"max=8,dive,required"`
+}
+
+type UpdatePatchPolicyRequest struct {
+	MaxSizeRatio     float64 `json:"max_size_ratio" validate:"gte=0,lte=1"`
+	PregenerateCount int     `json:"pregenerate_count" validate:"gte=0,lte=32"`
+}
+
+type UpdateRetentionPolicyRequest struct {
+	KeepLatest        int      `json:"keep_latest" validate:"gte=0,lte=100"`
+	KeepDays          int      `json:"keep_days" validate:"gte=0,lte=3650"`
+	PinnedVersions    []string `json:"pinned_versions" validate:"max=64,dive,required"`
+	MinActiveRequests int64    `json:"min_active_requests" validate:"gte=0"`
+}
+
+type UpdateSupportPolicyRequest struct {
+	// channel -> minimum supported version
+	MinVersions map[string]types.MinVersion `json:"min_versions" validate:"max=32"`
+}
+
+type UpsertChannelRequest struct {
+	Includes []string `json:"includes" validate:"max=16,dive,required"`
+}
+
+type PurgeRequest struct {
+	DryRun bool `query:"dry_run"`
+}
+
+type ReconcileRequest struct {
+	DryRun bool `query:"dry_run"`
+	// e.g. 72h, empty uses extra.orphan_grace_period
+	Grace string `query:"grace"`
+}
+
+type ListPurgeReportsRequest struct {
+	Limit int `query:"limit" validate:"gte=0,lte=100"`
 }
 
 type CreateVersionRequest struct {
@@ -13,6 +64,8 @@ type CreateVersionRequest struct {
 	Arch     string `json:"arch" form:"arch"`
 	Channel  string `json:"channel" form:"channel"`
 	Filename string `json:"filename" form:"filename" validate:"required"`
+	// omitted publishes the version once processed
+	PublishAt *time.Time `json:"publish_at" form:"-"`
 }
 
 type CreateVersionCallBackRequest struct {
@@ -21,6 +74,29 @@ type CreateVersionCallBackRequest struct {
 	Arch    string `json:"arch" form:"arch"`
 	Channel string `json:"channel" form:"channel"`
 	Key     string `json:"key" form:"key" validate:"required"`
+	// omitted offers the version to every client
+	RolloutPercent *int `json:"rollout_percent" form:"rollout_percent" validate:"omitempty,gte=0,lte=100"`
+}
+
+type CreateUploadRequest struct {
+	Name     string `json:"name" validate:"required"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Channel  string `json:"channel"`
+	Filename string `json:"filename" validate:"required"`
+	Size     int64  `json:"size" validate:"gt=0"`
+	// omitted offers the version to every client
+	RolloutPercent *int `json:"rollout_percent" validate:"omitempty,gte=0,lte=100"`
+	// omitted publishes the version once processed
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+type UploadChunkRequest struct {
+	Offset int64 `query:"offset" validate:"gte=0"`
+}
+
+type CompleteUploadRequest struct {
+	SHA256 string `json:"sha256" validate:"required,len=64,hexadecimal"`
 }
 
 type GetLatestVersionRequest struct {
@@ -31,12 +107,26 @@ type GetLatestVersionRequest struct {
 	Channel        string `query:"channel"`
 	CDK            string `query:"cdk"`
 	UserAgent      string `query:"user_agent"`
+	PatchFormat    int    `query:"patch_format"`
+	PatchChain     bool   `query:"patch_chain"`
+	WithChangelog  bool   `query:"with_changelog"`
+	// preferred release note languages, overrides Accept-Language
+	Lang string `query:"lang"`
+}
+
+type ChangelogRequest struct {
+	From    string `query:"from"`
+	To      string `query:"to"`
+	Channel string `query:"channel"`
+	Lang    string `query:"lang"`
 }
 
 type UpdateReleaseNoteRequest struct {
 	VersionName string `json:"version_name"`
 	Channel     string `json:"channel"`
 	Content     string `json:"content"`
+	// empty for the default locale
+	Locale string `json:"locale"`
 }
 
 type UpdateCustomDataRequest struct {
@@ -45,6 +135,42 @@ type UpdateCustomDataRequest struct {
 	Content     string `json:"content"`
 }
 
+type SchedulePublishRequest struct {
+	VersionName string `json:"version_name" validate:"required"`
+	// null publishes the version now
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+type RolloutVersionRequest struct {
+	VersionName string `json:"version_name" validate:"required"`
+	// omitted for every platform
+	OS      *string `json:"os"`
+	Arch    *string `json:"arch"`
+	Percent int     `json:"percent" validate:"gte=0,lte=100"`
+}
+
+type PromoteVersionRequest struct {
+	VersionName string `json:"version_name" validate:"required"`
+	Channel     string `json:"channel" validate:"required"`
+	// omitted moves the version, otherwise it is cloned into the channel under this name
+	CloneName string `json:"clone_name" validate:"max=255"`
+}
+
+type MarkCriticalRequest struct {
+	VersionName string `json:"version_name" validate:"required"`
+	Critical    bool   `json:"critical"`
+	Reason      string `json:"reason" validate:"max=255"`
+}
+
+type YankVersionRequest struct {
+	VersionName string `json:"version_name" validate:"required"`
+	// omitted for every platform
+	OS        *string `json:"os"`
+	Arch      *string `json:"arch"`
+	Reason    string  `json:"reason" validate:"max=255"`
+	Downgrade bool    `json:"downgrade"`
+}
+
 type GetVersionStatusRequest struct {
 	Key string `query:"key" validate:"required"`
 }
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
+)
 
 type CreateResourceResponseData struct {
 	ID          string `json:"id"`
@@ -15,6 +19,12 @@ type CreateVersionResponseData struct {
 	Arch   string `json:"arch,omitempty"`
 }
 
+type PromoteVersionResponseData struct {
+	Name    string `json:"name"`
+	Number  uint64 `json:"number"`
+	Channel string `json:"channel"`
+}
+
 type QueryLatestResponseData struct {
 	VersionName   string `json:"version_name"`
 	VersionNumber uint64 `json:"version_number"`
@@ -24,11 +34,36 @@ type QueryLatestResponseData struct {
 	OS            string `json:"os"`
 	Arch          string `json:"arch"`
 	// UpdateType is the type of the update, it can be "full" or "incremental"
-	UpdateType     string `json:"update_type,omitempty"`
-	CustomData     string `json:"custom_data,omitempty"`
-	ReleaseNote    string `json:"release_note"`
-	Filesize       int64  `json:"filesize,omitempty"`
-	CDKExpiredTime int64  `json:"cdk_expired_time,omitempty"`
+	UpdateType  string `json:"update_type,omitempty"`
+	CustomData  string `json:"custom_data,omitempty"`
+	ReleaseNote string `json:"release_note"`
+	// locale of release_note
+	ReleaseNoteLocale string `json:"release_note_locale,omitempty"`
+	Filesize          int64  `json:"filesize,omitempty"`
+	CDKExpiredTime    int64  `json:"cdk_expired_time,omitempty"`
+	// the client is below the minimum supported version or a critical version
+	ForceUpdate       bool   `json:"force_update"`
+	ForceUpdateReason string `json:"force_update_reason,omitempty"`
+	// only for chain update, apply in order
+	Patches []PatchChainItem `json:"patches,omitempty"`
+	// release notes between the current version and the latest one, newest first
+	Changelog []ChangelogItem `json:"changelog,omitempty"`
+}
+
+type ChangelogItem struct {
+	VersionName   string    `json:"version_name"`
+	VersionNumber uint64    `json:"version_number"`
+	Channel       string    `json:"channel"`
+	ReleaseNote   string    `json:"release_note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type PatchChainItem struct {
+	FromVersion string `json:"from_version"`
+	ToVersion   string `json:"to_version"`
+	Url         string `json:"url"`
+	SHA256      string `json:"sha256"`
+	Filesize    int64  `json:"filesize"`
 }
 
 type GetVersionStatusResponseData struct {
@@ -49,24 +84,89 @@ type PageData struct {
 
 // ResourceItem is a resource row in the admin resource list.
 type ResourceItem struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	UpdateType  string    `json:"update_type"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	UpdateType    string    `json:"update_type"`
+	VersionScheme string    `json:"version_scheme"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // ResourceDetailData is the admin resource detail payload.
 type ResourceDetailData struct {
 	ResourceItem
-	VersionCount int `json:"version_count"`
+	VersionCount       int                   `json:"version_count"`
+	DistributionPolicy []string              `json:"distribution_policy"`
+	PatchPolicy        types.PatchPolicy     `json:"patch_policy"`
+	RetentionPolicy    types.RetentionPolicy `json:"retention_policy"`
+	SupportPolicy      types.SupportPolicy   `json:"support_policy"`
+}
+
+// PurgePreviewData full packages the next purge job would remove.
+type PurgePreviewData struct {
+	Policy types.RetentionPolicy `json:"policy"`
+	List   []PurgePreviewItem    `json:"list"`
+}
+
+type PurgePreviewItem struct {
+	VersionId   int       `json:"version_id"`
+	VersionName string    `json:"version_name"`
+	Channel     string    `json:"channel"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	StorageId   int       `json:"storage_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ChannelItem is a release channel of a resource.
+type ChannelItem struct {
+	Name     string   `json:"name"`
+	Builtin  bool     `json:"builtin"`
+	Includes []string `json:"includes,omitempty"`
+	// channels whose versions subscribers see, the channel itself last
+	Sees []string `json:"sees"`
 }
 
 // VersionItem is a version row in the admin version list.
 type VersionItem struct {
-	ID        int       `json:"id"`
-	Channel   string    `json:"channel"`
-	Name      string    `json:"name"`
-	Number    uint64    `json:"number"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int        `json:"id"`
+	Channel   string     `json:"channel"`
+	Name      string     `json:"name"`
+	Number    uint64     `json:"number"`
+	CreatedAt time.Time  `json:"created_at"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	Critical  bool       `json:"critical"`
+	// locales the version has a release note in, the default locale first
+	Locales   []string              `json:"locales"`
+	Platforms []VersionPlatformItem `json:"platforms"`
+}
+
+// ScheduledReleaseItem is a version waiting for its publish time.
+type ScheduledReleaseItem struct {
+	ResourceId   string    `json:"resource_id"`
+	ResourceName string    `json:"resource_name"`
+	VersionId    int       `json:"version_id"`
+	VersionName  string    `json:"version_name"`
+	Channel      string    `json:"channel"`
+	PublishAt    time.Time `json:"publish_at"`
+}
+
+// VersionPlatformItem is the rollout and yank state of a version on one platform.
+type VersionPlatformItem struct {
+	OS             string     `json:"os"`
+	Arch           string     `json:"arch"`
+	RolloutPercent int        `json:"rollout_percent"`
+	YankedAt       *time.Time `json:"yanked_at,omitempty"`
+	YankReason     string     `json:"yank_reason,omitempty"`
+}
+
+// MirrorStatus is the probe state of a download mirror in the admin mirror list.
+type MirrorStatus struct {
+	Region               string    `json:"region"`
+	Url                  string    `json:"url"`
+	Healthy              bool      `json:"healthy"`
+	ConsecutiveFailures  int       `json:"consecutive_failures"`
+	ConsecutiveSuccesses int       `json:"consecutive_successes"`
+	LastCheckedAt        time.Time `json:"last_checked_at"`
+	LastError            string    `json:"last_error,omitempty"`
 }
@@ -10,10 +10,12 @@ import (
 type LatestVersionInfo struct {
 	// by logic injection
 	ResourceUpdateType types.Update
-	VersionId          int            `db:"version_id"`
-	VersionName        string         `db:"version_name"`
-	VersionNumber      uint64         `db:"version_number"`
-	ReleaseNote        string         `db:"release_note"`
+	VersionId          int    `db:"version_id"`
+	VersionName        string `db:"version_name"`
+	VersionNumber      uint64 `db:"version_number"`
+	ReleaseNote        string `db:"release_note"`
+	RawReleaseNotes    []byte `db:"release_notes"`
+	ReleaseNotes       types.ReleaseNotes
 	CustomData         string         `db:"custom_data"`
 	OS                 string         `db:"os"`
 	Arch               string         `db:"arch"`
@@ -21,16 +23,35 @@ type LatestVersionInfo struct {
 	PackageHash        sql.NullString `db:"package_hash_sha256"`
 	PackagePath        sql.NullString `db:"package_path"`
 	FileSize           int64          `db:"file_size"`
+	RolloutPercent     int            `db:"rollout_percent"`
 	CreatedAt          time.Time      `db:"created_at"`
 	VersionSerial      int            `db:"version_serial"`
 }
+
+// ResourcePurgeInfo VersionSerial ranks the storages served to every client and is 0 for the others,
+// Pending a rollout newer than the served storage
 type ResourcePurgeInfo struct {
-	VersionName   string `db:"version_name"`
-	Channel       string `db:"channel"`
-	ResourceId    string `db:"resource_id"`
-	VersionId     int    `db:"version_id"`
-	StorageId     int    `db:"storage_id"`
-	OS            string `db:"os"`
-	Arch          string `db:"arch"`
-	VersionSerial int    `db:"version_serial"`
+	VersionName   string    `db:"version_name"`
+	Channel       string    `db:"channel"`
+	ResourceId    string    `db:"resource_id"`
+	VersionId     int       `db:"version_id"`
+	StorageId     int       `db:"storage_id"`
+	PackagePath   string    `db:"package_path"`
+	OS            string    `db:"os"`
+	Arch          string    `db:"arch"`
+	CreatedAt     time.Time `db:"created_at"`
+	VersionSerial int       `db:"version_serial"`
+	Pending       bool      `db:"pending"`
+}
+
+// PatchEdge an incremental storage from current to target version
+type PatchEdge struct {
+	TargetVersionId    int    `db:"target_version_id"`
+	TargetVersionName  string `db:"target_version_name"`
+	CurrentVersionId   int    `db:"current_version_id"`
+	CurrentVersionName string `db:"current_version_name"`
+	PackagePath        string `db:"package_path"`
+	PackageHash        string `db:"package_hash_sha256"`
+	FileSize           int64  `db:"file_size"`
+	PatchFormat        int    `db:"patch_format"`
 }
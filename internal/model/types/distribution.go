@@ -0,0 +1,8 @@
+package types
+
+// DistributionPolicy distributors tried in order for the downloads of a resource,
+// the first one able to serve wins and the rest are fallbacks.
+// An empty policy uses the weighted cdn/mirror split of the instance region
+type DistributionPolicy struct {
+	Distributors []string `json:"distributors"`
+}
@@ -0,0 +1,19 @@
+package types
+
+// DefaultPatchMaxSizeRatio the full package is served once a patch reaches 90% of its size
+const DefaultPatchMaxSizeRatio = 0.9
+
+// PatchPolicy how the incremental packages of a resource are served
+type PatchPolicy struct {
+	// patch size / full package size above which the full package is served, 0 uses the default
+	MaxSizeRatio float64 `json:"max_size_ratio"`
+	// patches generated on release from the most requested versions, 0 disables it
+	PregenerateCount int `json:"pregenerate_count"`
+}
+
+func (p PatchPolicy) SizeRatio() float64 {
+	if p.MaxSizeRatio <= 0 {
+		return DefaultPatchMaxSizeRatio
+	}
+	return p.MaxSizeRatio
+}
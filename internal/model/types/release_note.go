@@ -0,0 +1,4 @@
+package types
+
+// ReleaseNotes locale -> release note, the note of the default locale is kept in release_note
+type ReleaseNotes map[string]string
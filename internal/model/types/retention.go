@@ -0,0 +1,24 @@
+package types
+
+// DefaultRetentionKeepLatest full packages kept per channel/os/arch when no policy is set
+const DefaultRetentionKeepLatest = 2
+
+// RetentionPolicy which full packages of a resource survive the purge job,
+// a package is kept as soon as one rule matches
+type RetentionPolicy struct {
+	// newest packages kept per channel/os/arch, 0 uses the default
+	KeepLatest int `json:"keep_latest"`
+	// packages of versions created within the last days, 0 disables it
+	KeepDays int `json:"keep_days"`
+	// version names never purged
+	PinnedVersions []string `json:"pinned_versions"`
+	// update checks from a version over the telemetry window which keep it alive, 0 disables it
+	MinActiveRequests int64 `json:"min_active_requests"`
+}
+
+func (p RetentionPolicy) Latest() int {
+	if p.KeepLatest <= 0 {
+		return DefaultRetentionKeepLatest
+	}
+	return p.KeepLatest
+}
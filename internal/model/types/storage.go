@@ -7,12 +7,17 @@ type Update string
 const (
 	UpdateFull        Update = "full"
 	UpdateIncremental Update = "incremental"
+	// UpdateChain response only, a list of incremental packages applied in order
+	UpdateChain Update = "chain"
 )
 
 func (u Update) String() string {
 	return string(u)
 }
 
+// RolloutFull every client is offered the version
+const RolloutFull = 100
+
 type FileType string
 
 const (
@@ -0,0 +1,12 @@
+package types
+
+// SupportPolicy the oldest versions of a resource still supported
+type SupportPolicy struct {
+	// channel -> minimum supported version, clients below it are forced to update
+	MinVersions map[string]MinVersion `json:"min_versions"`
+}
+
+type MinVersion struct {
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
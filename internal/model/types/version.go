@@ -11,3 +11,24 @@ const (
 func (c Channel) String() string {
 	return string(c)
 }
+
+// BuiltinChannels every resource has them, a built-in channel also sees the versions of those before it
+var BuiltinChannels = []Channel{ChannelStable, ChannelBeta, ChannelAlpha}
+
+// VersionScheme how the version names of a resource are parsed and ordered
+type VersionScheme string
+
+const (
+	// VersionSchemeAuto tries the default parsers in order of precedence, names which can't be parsed fall back to the upload order
+	VersionSchemeAuto        VersionScheme = ""
+	VersionSchemeSemVer      VersionScheme = "semver"
+	VersionSchemeLooseSemVer VersionScheme = "loose_semver"
+	VersionSchemeCalVer      VersionScheme = "calver"
+	VersionSchemePEP440      VersionScheme = "pep440"
+	VersionSchemeDateTime    VersionScheme = "datetime"
+	VersionSchemeInteger     VersionScheme = "integer"
+)
+
+func (s VersionScheme) String() string {
+	return string(s)
+}
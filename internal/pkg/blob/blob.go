@@ -0,0 +1,65 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound           = errors.New("blob not found")
+	ErrInvalidKey         = errors.New("invalid blob key")
+	ErrPresignUnsupported = errors.New("presign not supported")
+)
+
+// Store object storage holding the published packages, keys are slash separated
+// paths relative to the root of the store, e.g. <resource>/<version>/<platform>/<file>
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (Info, error)
+	// Delete a missing key is not an error
+	Delete(ctx context.Context, key string) error
+	// List every object whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]Info, error)
+	// Presign a time limited download url
+	Presign(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+type Info struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// CleanKey rejects keys escaping the root of the store
+func CleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(strings.ReplaceAll(key, "\\", "/"), "/")
+	if key == "" {
+		return "", ErrInvalidKey
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	return path.Clean(key), nil
+}
+
+// DeletePrefix removes every object below prefix
+func DeletePrefix(ctx context.Context, s Store, prefix string) error {
+	list, err := s.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	var el []error
+	for _, o := range list {
+		if err := s.Delete(ctx, o.Key); err != nil {
+			el = append(el, err)
+		}
+	}
+	return errors.Join(el...)
+}
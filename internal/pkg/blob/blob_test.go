@@ -0,0 +1,273 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 an in memory stand-in for MinIO which verifies the signatures
+type fakeS3 struct {
+	signer *S3
+	mu     sync.Mutex
+	data   map[string][]byte
+	// uploads parts of the multipart uploads in progress by upload id
+	uploads map[string]map[int][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.URL.Host = r.Host
+	if !f.verify(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != "bucket" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		var result listBucketResult
+		prefix := r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range f.data {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			}{Key: k, Size: int64(len(f.data[k])), LastModified: time.Now().UTC()})
+		}
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPost && r.URL.Query().Has("uploads"):
+		id := strconv.Itoa(len(f.uploads) + 1)
+		f.uploads[id] = make(map[int][]byte)
+		_ = xml.NewEncoder(w).Encode(initiateMultipartUploadResult{UploadID: id})
+	case r.Method == http.MethodPut && r.URL.Query().Has("uploadId"):
+		parts, ok := f.uploads[r.URL.Query().Get("uploadId")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n, _ := strconv.Atoi(r.URL.Query().Get("partNumber"))
+		buf, _ := io.ReadAll(r.Body)
+		parts[n] = buf
+		w.Header().Set("ETag", strconv.Quote(strconv.Itoa(n)))
+	case r.Method == http.MethodPost && r.URL.Query().Has("uploadId"):
+		id := r.URL.Query().Get("uploadId")
+		var req completeMultipartUpload
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var buf []byte
+		for _, p := range req.Parts {
+			buf = append(buf, f.uploads[id][p.PartNumber]...)
+		}
+		delete(f.uploads, id)
+		f.data[key] = buf
+	case r.Method == http.MethodDelete && r.URL.Query().Has("uploadId"):
+		delete(f.uploads, r.URL.Query().Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		buf, _ := io.ReadAll(r.Body)
+		f.data[key] = buf
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		buf, ok := f.data[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(buf)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.data, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request) bool {
+	q := r.URL.Query()
+	if sig := q.Get("X-Amz-Signature"); sig != "" {
+		date, err := time.Parse(amzDateFormat, q.Get("X-Amz-Date"))
+		if err != nil {
+			return false
+		}
+		q.Del("X-Amz-Signature")
+		canonical := strings.Join([]string{
+			r.Method, canonicalURI(r.URL), canonicalQuery(q),
+			"host:" + r.Host + "\n", "host", unsignedPayload,
+		}, "\n")
+		return f.signer.signature(date, canonical) == sig
+	}
+
+	auth := r.Header.Get("Authorization")
+	date, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil || auth == "" {
+		return false
+	}
+	canonical, _ := canonicalRequest(r, r.Header.Get("X-Amz-Content-Sha256"))
+	return strings.HasSuffix(auth, "Signature="+f.signer.signature(date, canonical))
+}
+
+func newTestS3(t *testing.T) (*S3, *httptest.Server) {
+	s, _, srv := newTestS3Fake(t, 0)
+	return s, srv
+}
+
+func newTestS3Fake(t *testing.T, partSize int64) (*S3, *fakeS3, *httptest.Server) {
+	opts := S3Options{
+		PartSize:  partSize,
+		Region:    "us-east-1",
+		Bucket:    "bucket",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}
+	fake := &fakeS3{data: make(map[string][]byte), uploads: make(map[string]map[int][]byte)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	opts.Endpoint = srv.URL
+	s, err := NewS3(opts)
+	require.NoError(t, err)
+	fake.signer = s
+	return s, fake, srv
+}
+
+func TestStores(t *testing.T) {
+	local, err := NewLocal(t.TempDir(), "")
+	require.NoError(t, err)
+	s3, _ := newTestS3(t)
+
+	for name, s := range map[string]Store{"local": local, "s3": s3} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctx     = context.Background()
+				payload = []byte("package content")
+				key     = "res/1/windows-x86_64/pkg v1.zip"
+			)
+			_, err := s.Stat(ctx, key)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			require.NoError(t, s.Put(ctx, key, bytes.NewReader(payload), int64(len(payload))))
+			require.NoError(t, s.Put(ctx, "res/1/windows-x86_64/patch/0.zip", strings.NewReader("p"), 1))
+			require.NoError(t, s.Put(ctx, "res/10/any/pkg.zip", strings.NewReader("x"), 1))
+
+			info, err := s.Stat(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(payload)), info.Size)
+
+			r, err := s.Get(ctx, key)
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			assert.Equal(t, payload, got)
+
+			list, err := s.List(ctx, "res/1/")
+			require.NoError(t, err)
+			assert.Len(t, list, 2)
+
+			require.NoError(t, DeletePrefix(ctx, s, "res/1/"))
+			list, err = s.List(ctx, "res/")
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			assert.Equal(t, "res/10/any/pkg.zip", list[0].Key)
+
+			// deleting twice is fine
+			require.NoError(t, s.Delete(ctx, key))
+			_, err = s.Get(ctx, key)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			require.ErrorIs(t, s.Put(ctx, "../escape", strings.NewReader(""), 0), ErrInvalidKey)
+		})
+	}
+}
+
+func TestS3PutMultipart(t *testing.T) {
+	s, fake, _ := newTestS3Fake(t, minPartSize)
+	ctx := context.Background()
+
+	payload := bytes.Repeat([]byte("0123456789abcdef"), (2*minPartSize+1024)/16)
+	require.NoError(t, s.Put(ctx, "res/1/any/big.zip", bytes.NewReader(payload), int64(len(payload))))
+
+	r, err := s.Get(ctx, "res/1/any/big.zip")
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, payload, got)
+	assert.Empty(t, fake.uploads)
+
+	// a short body fails the part upload and aborts the upload
+	err = s.Put(ctx, "res/1/any/short.zip", bytes.NewReader(payload[:minPartSize]), int64(len(payload)))
+	require.Error(t, err)
+	assert.Empty(t, fake.uploads)
+	_, err = s.Stat(ctx, "res/1/any/short.zip")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3Presign(t *testing.T) {
+	s, _ := newTestS3(t)
+	ctx := context.Background()
+	require.NoError(t, s.Put(ctx, "a/b c.zip", strings.NewReader("data"), 4))
+
+	link, err := s.Presign(ctx, "a/b c.zip", time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "60", u.Query().Get("X-Amz-Expires"))
+
+	resp, err := http.Get(link)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "data", string(body))
+
+	// tampered links are rejected
+	resp, err = http.Get(strings.Replace(link, "X-Amz-Expires=60", "X-Amz-Expires=600", 1))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestLocalPresign(t *testing.T) {
+	s, err := NewLocal(t.TempDir(), "https://cdn.example.com/")
+	require.NoError(t, err)
+	link, err := s.Presign(context.Background(), "res/1/any/pkg.zip", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/res/1/any/pkg.zip", link)
+
+	s, err = NewLocal(t.TempDir(), "")
+	require.NoError(t, err)
+	_, err = s.Presign(context.Background(), "res/1/any/pkg.zip", time.Minute)
+	assert.ErrorIs(t, err, ErrPresignUnsupported)
+}
@@ -0,0 +1,171 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+)
+
+// Local a directory, usually a mounted bucket served by the cdn
+type Local struct {
+	root string
+	// public url the root is served under, presign is unsupported when empty
+	baseURL string
+}
+
+func NewLocal(root, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &Local{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *Local) Root() string {
+	return s.root
+}
+
+func (s *Local) path(key string) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *Local) Put(_ context.Context, key string, r io.Reader, size int64) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		return err
+	}
+
+	// readers never observe a partially written object
+	tmp := strings.Join([]string{p, ksuid.New().String(), "tmp"}, ".")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, r)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err == nil && size >= 0 && n != size {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+func (s *Local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *Local) Stat(_ context.Context, key string) (Info, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return Info{}, err
+	}
+	st, err := os.Stat(p)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return Info{}, ErrNotFound
+	case err != nil:
+		return Info{}, err
+	case st.IsDir():
+		return Info{}, ErrNotFound
+	}
+	key, _ = CleanKey(key)
+	return Info{Key: key, Size: st.Size(), ModTime: st.ModTime()}, nil
+}
+
+func (s *Local) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Local) List(_ context.Context, prefix string) ([]Info, error) {
+	prefix = strings.TrimPrefix(strings.ReplaceAll(prefix, "\\", "/"), "/")
+	// walk the deepest directory covering the prefix
+	dir := prefix
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir)
+	}
+	start := s.root
+	if dir != "." && dir != "" {
+		d, err := s.path(dir)
+		if err != nil {
+			return nil, err
+		}
+		start = d
+	}
+
+	var list []Info
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		st, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		list = append(list, Info{Key: key, Size: st.Size(), ModTime: st.ModTime()})
+		return nil
+	})
+	return list, err
+}
+
+// Presign the local store is served as is, the url does not expire
+func (s *Local) Presign(_ context.Context, key string, _ time.Duration) (string, error) {
+	if s.baseURL == "" {
+		return "", ErrPresignUnsupported
+	}
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{s.baseURL, key}, "/"), nil
+}
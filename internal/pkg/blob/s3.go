@@ -0,0 +1,505 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat   = "20060102T150405Z"
+	amzShortFormat  = "20060102"
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	maxPresignTTL   = 7 * 24 * time.Hour
+
+	defaultPartSize = 64 << 20
+	minPartSize     = 5 << 20
+	maxParts        = 10000
+)
+
+type S3Options struct {
+	// Endpoint e.g. https://s3.us-east-1.amazonaws.com or http://127.0.0.1:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle https://endpoint/bucket/key instead of https://bucket.endpoint/key, MinIO needs it
+	PathStyle bool
+	// PartSize objects larger than it are uploaded in parts, a single PUT is limited to 5 GiB
+	PartSize int64
+	Client   *http.Client
+}
+
+// S3 any S3 compatible service, requests are signed with Signature Version 4
+type S3 struct {
+	endpoint *url.URL
+	opts     S3Options
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3(opts S3Options) (*S3, error) {
+	u, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", opts.Endpoint)
+	}
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.PartSize <= 0 {
+		opts.PartSize = defaultPartSize
+	}
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &S3{
+		endpoint: u,
+		opts:     opts,
+		client:   client,
+		now:      time.Now,
+	}, nil
+}
+
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	base := strings.TrimSuffix(u.Path, "/")
+	if s.opts.PathStyle {
+		u.Path = strings.Join([]string{base, s.opts.Bucket, key}, "/")
+	} else {
+		u.Host = strings.Join([]string{s.opts.Bucket, u.Host}, ".")
+		u.Path = strings.Join([]string{base, key}, "/")
+	}
+	u.RawPath = ""
+	u.RawQuery = ""
+	return &u
+}
+
+func (s *S3) bucketURL() *url.URL {
+	u := s.objectURL("")
+	if s.opts.PathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/")
+	}
+	return u
+}
+
+func (s *S3) do(ctx context.Context, method string, u *url.URL, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	s.sign(req, unsignedPayload, s.now().UTC())
+	return s.client.Do(req)
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	if size < 0 {
+		return fmt.Errorf("s3 put %s: size is required", key)
+	}
+	if size > s.opts.PartSize {
+		return s.putMultipart(ctx, key, r, size)
+	}
+	resp, err := s.do(ctx, http.MethodPut, s.objectURL(key), r, size)
+	if err != nil {
+		return err
+	}
+	defer drain(resp)
+	return checkResponse(resp, "put", key)
+}
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// putMultipart upload in parts of PartSize, grown when needed to stay within the part limit,
+// the upload is aborted on any failure so no parts are left behind
+func (s *S3) putMultipart(ctx context.Context, key string, r io.Reader, size int64) error {
+	partSize := max(s.opts.PartSize, (size+maxParts-1)/maxParts, minPartSize)
+
+	u := s.objectURL(key)
+	u.RawQuery = "uploads="
+	resp, err := s.do(ctx, http.MethodPost, u, nil, 0)
+	if err != nil {
+		return err
+	}
+	if err := checkResponse(resp, "put", key); err != nil {
+		drain(resp)
+		return err
+	}
+	var initiated initiateMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	drain(resp)
+	if err != nil {
+		return fmt.Errorf("s3 put %s: initiate multipart upload: %w", key, err)
+	}
+	if initiated.UploadID == "" {
+		return fmt.Errorf("s3 put %s: initiate multipart upload: empty upload id", key)
+	}
+
+	if err := s.uploadParts(ctx, key, initiated.UploadID, r, size, partSize); err != nil {
+		s.abortMultipart(key, initiated.UploadID)
+		return err
+	}
+	return nil
+}
+
+func (s *S3) uploadParts(ctx context.Context, key, uploadID string, r io.Reader, size, partSize int64) error {
+	var parts []completedPart
+	for n, offset := 1, int64(0); offset < size; n, offset = n+1, offset+partSize {
+		length := min(partSize, size-offset)
+		u := s.objectURL(key)
+		u.RawQuery = url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadID}}.Encode()
+		resp, err := s.do(ctx, http.MethodPut, u, io.LimitReader(r, length), length)
+		if err != nil {
+			return err
+		}
+		err = checkResponse(resp, "put", key)
+		drain(resp)
+		if err != nil {
+			return err
+		}
+		etag := resp.Header.Get("ETag")
+		if etag == "" {
+			return fmt.Errorf("s3 put %s: part %d: missing etag", key, n)
+		}
+		parts = append(parts, completedPart{PartNumber: n, ETag: etag})
+	}
+
+	body, err := xml.Marshal(completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return err
+	}
+	u := s.objectURL(key)
+	u.RawQuery = url.Values{"uploadId": {uploadID}}.Encode()
+	resp, err := s.do(ctx, http.MethodPost, u, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer drain(resp)
+	if err := checkResponse(resp, "put", key); err != nil {
+		return err
+	}
+	// the completion can fail after a 200 status, the error is in the body then
+	msg, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	var e s3Error
+	if xml.Unmarshal(msg, &e) == nil && e.Code != "" {
+		return fmt.Errorf("s3 put %s: complete multipart upload: %s %s", key, e.Code, e.Message)
+	}
+	return nil
+}
+
+func (s *S3) abortMultipart(key, uploadID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	u := s.objectURL(key)
+	u.RawQuery = url.Values{"uploadId": {uploadID}}.Encode()
+	resp, err := s.do(ctx, http.MethodDelete, u, nil, 0)
+	if err != nil {
+		return
+	}
+	drain(resp)
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, s.objectURL(key), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, "get", key); err != nil {
+		drain(resp)
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (Info, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := s.do(ctx, http.MethodHead, s.objectURL(key), nil, 0)
+	if err != nil {
+		return Info{}, err
+	}
+	defer drain(resp)
+	if err := checkResponse(resp, "stat", key); err != nil {
+		return Info{}, err
+	}
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return Info{Key: key, Size: resp.ContentLength, ModTime: modTime}, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(key), nil, 0)
+	if err != nil {
+		return err
+	}
+	defer drain(resp)
+	if err := checkResponse(resp, "delete", key); err != nil && err != ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3) List(ctx context.Context, prefix string) ([]Info, error) {
+	prefix = strings.TrimPrefix(strings.ReplaceAll(prefix, "\\", "/"), "/")
+	var (
+		list  []Info
+		token string
+	)
+	for {
+		q := url.Values{}
+		q.Set("list-type", "2")
+		q.Set("prefix", prefix)
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		u := s.bucketURL()
+		u.RawQuery = q.Encode()
+
+		resp, err := s.do(ctx, http.MethodGet, u, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkResponse(resp, "list", prefix); err != nil {
+			drain(resp)
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		drain(resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range result.Contents {
+			list = append(list, Info{Key: c.Key, Size: c.Size, ModTime: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return list, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3) Presign(_ context.Context, key string, ttl time.Duration) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	ttl = min(max(ttl, time.Second), maxPresignTTL)
+
+	var (
+		now   = s.now().UTC()
+		scope = s.scope(now)
+		u     = s.objectURL(key)
+		q     = url.Values{}
+	)
+	q.Set("X-Amz-Algorithm", signAlgorithm)
+	q.Set("X-Amz-Credential", strings.Join([]string{s.opts.AccessKey, scope}, "/"))
+	q.Set("X-Amz-Date", now.Format(amzDateFormat))
+	q.Set("X-Amz-Expires", strconv.Itoa(int(ttl/time.Second)))
+	q.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = canonicalQuery(q)
+
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		canonicalURI(u),
+		u.RawQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	u.RawQuery += "&X-Amz-Signature=" + s.signature(now, canonical)
+	return u.String(), nil
+}
+
+func (s *S3) scope(t time.Time) string {
+	return strings.Join([]string{t.Format(amzShortFormat), s.opts.Region, "s3", "aws4_request"}, "/")
+}
+
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonical, signed := canonicalRequest(req, payloadHash)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signAlgorithm, s.opts.AccessKey, s.scope(now), signed, s.signature(now, canonical),
+	))
+}
+
+func (s *S3) signature(now time.Time, canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	toSign := strings.Join([]string{
+		signAlgorithm,
+		now.Format(amzDateFormat),
+		s.scope(now),
+		hex.EncodeToString(sum[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretKey), now.Format(amzShortFormat))
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, toSign))
+}
+
+// canonicalRequest signs host and the x-amz-* headers
+func canonicalRequest(req *http.Request, payloadHash string) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "x-amz-") {
+			headers[lk] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(headers[k])
+		b.WriteByte('\n')
+	}
+	signed := strings.Join(names, ";")
+	return strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		b.String(),
+		signed,
+		payloadHash,
+	}, "\n"), signed
+}
+
+func canonicalURI(u *url.URL) string {
+	p := u.Path
+	if p == "" {
+		return "/"
+	}
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		segs[i] = uriEncode(seg)
+	}
+	return strings.Join(segs, "/")
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), q[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode RFC 3986 with the unreserved characters left alone
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func checkResponse(resp *http.Response, op, key string) error {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 %s %s: %s %s", op, key, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,133 @@
+package dltoken
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// token layout: <kid>.<base64url(expiry unix seconds | nonce | aes-gcm(payload))>.<base64url(hmac-sha256)>,
+// the payload carries the cdk of the user and must not be readable from a shared download link
+
+const separator = "."
+
+var (
+	ErrMalformed  = errors.New("malformed download token")
+	ErrUnknownKey = errors.New("unknown download token key")
+	ErrSignature  = errors.New("invalid download token signature")
+	ErrExpired    = errors.New("download token expired")
+)
+
+const expirySize = 8
+
+type Signer struct {
+	// kid -> secret
+	keys map[string][]byte
+	// kid -> cipher keyed by a key derived from the secret
+	ciphers map[string]cipher.AEAD
+	current string
+}
+
+// NewSigner tokens are signed with the current key and verified with any of the keys,
+// keep retired keys around until the tokens signed with them are expired
+func NewSigner(keys map[string]string, current string) (*Signer, error) {
+	if strings.Contains(current, separator) {
+		return nil, errors.New("key id must not contain " + separator)
+	}
+	if _, ok := keys[current]; !ok {
+		return nil, errors.New("current key id " + current + " not found")
+	}
+	s := &Signer{
+		keys:    make(map[string][]byte, len(keys)),
+		ciphers: make(map[string]cipher.AEAD, len(keys)),
+		current: current,
+	}
+	for kid, secret := range keys {
+		if secret == "" {
+			return nil, errors.New("secret of key id " + kid + " is empty")
+		}
+		s.keys[kid] = []byte(secret)
+		aead, err := newCipher(s.keys[kid])
+		if err != nil {
+			return nil, err
+		}
+		s.ciphers[kid] = aead
+	}
+	return s, nil
+}
+
+func newCipher(secret []byte) (cipher.AEAD, error) {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte("download token encryption"))
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func IsToken(s string) bool {
+	return strings.Count(s, separator) == 2
+}
+
+func (s *Signer) Sign(payload []byte, expireAt time.Time) string {
+	aead := s.ciphers[s.current]
+	body := make([]byte, expirySize+aead.NonceSize(), expirySize+aead.NonceSize()+len(payload)+aead.Overhead())
+	binary.BigEndian.PutUint64(body, uint64(expireAt.Unix()))
+	nonce := body[expirySize:]
+	_, _ = rand.Read(nonce)
+	body = aead.Seal(body, nonce, payload, body[:expirySize])
+
+	head := s.current + separator + base64.RawURLEncoding.EncodeToString(body)
+	return head + separator + base64.RawURLEncoding.EncodeToString(s.mac(s.keys[s.current], head))
+}
+
+// Verify returns the payload of a valid and unexpired token
+func (s *Signer) Verify(token string, now time.Time) ([]byte, error) {
+	i := strings.LastIndex(token, separator)
+	if i < 0 || !IsToken(token) {
+		return nil, ErrMalformed
+	}
+	head, sig := token[:i], token[i+1:]
+	kid, enc, _ := strings.Cut(head, separator)
+
+	secret, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !hmac.Equal(mac, s.mac(secret, head)) {
+		return nil, ErrSignature
+	}
+
+	aead := s.ciphers[kid]
+	body, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil || len(body) < expirySize+aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	if now.Unix() > int64(binary.BigEndian.Uint64(body)) {
+		return nil, ErrExpired
+	}
+	nonce := body[expirySize : expirySize+aead.NonceSize()]
+	payload, err := aead.Open(nil, nonce, body[expirySize+aead.NonceSize():], body[:expirySize])
+	if err != nil {
+		return nil, ErrSignature
+	}
+	return payload, nil
+}
+
+func (s *Signer) mac(secret []byte, head string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(head))
+	return h.Sum(nil)
+}
@@ -0,0 +1,112 @@
+package dltoken
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	var (
+		now     = time.Unix(1700000000, 0)
+		payload = []byte(`{"cdk":"abc","rel_path":"res/1/any/resource.zip"}`)
+	)
+
+	old, err := NewSigner(map[string]string{"k1": "s1"}, "k1")
+	require.NoError(t, err)
+	rotated, err := NewSigner(map[string]string{"k1": "s1", "k2": "s2"}, "k2")
+	require.NoError(t, err)
+
+	token := old.Sign(payload, now.Add(time.Minute))
+	require.True(t, IsToken(token))
+	require.True(t, strings.HasPrefix(token, "k1."))
+
+	testCases := []struct {
+		Name     string
+		Signer   *Signer
+		Token    string
+		Now      time.Time
+		Expected error
+	}{
+		{
+			Name:   "valid",
+			Signer: old,
+			Token:  token,
+			Now:    now,
+		},
+		{
+			Name:   "retired key still verifies",
+			Signer: rotated,
+			Token:  token,
+			Now:    now,
+		},
+		{
+			Name:     "expired",
+			Signer:   old,
+			Token:    token,
+			Now:      now.Add(2 * time.Minute),
+			Expected: ErrExpired,
+		},
+		{
+			Name:     "unknown key",
+			Signer:   old,
+			Token:    rotated.Sign(payload, now.Add(time.Minute)),
+			Now:      now,
+			Expected: ErrUnknownKey,
+		},
+		{
+			Name:     "tampered payload",
+			Signer:   old,
+			Token:    "k1." + strings.Split(rotated.Sign([]byte("other"), now.Add(time.Minute)), ".")[1] + "." + strings.Split(token, ".")[2],
+			Now:      now,
+			Expected: ErrSignature,
+		},
+		{
+			Name:     "legacy key",
+			Signer:   old,
+			Token:    "2NtQHkC3RBI0Dz0ZmqNVgFuwBOn",
+			Now:      now,
+			Expected: ErrMalformed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := tc.Signer.Verify(tc.Token, tc.Now)
+			if tc.Expected != nil {
+				require.ErrorIs(t, err, tc.Expected)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, payload, got)
+		})
+	}
+}
+
+func TestSignHidesPayload(t *testing.T) {
+	signer, err := NewSigner(map[string]string{"k1": "s1"}, "k1")
+	require.NoError(t, err)
+
+	payload := []byte(`{"cdk":"secret-cdk"}`)
+	token := signer.Sign(payload, time.Now().Add(time.Minute))
+	body, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(body, []byte("secret-cdk")))
+
+	got, err := signer.Verify(token, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, payload, got)
+}
+
+func TestNewSignerRejectsInvalidKeyId(t *testing.T) {
+	_, err := NewSigner(map[string]string{"a.b": "s"}, "a.b")
+	require.Error(t, err)
+	_, err = NewSigner(map[string]string{"a": "s"}, "b")
+	require.Error(t, err)
+	_, err = NewSigner(map[string]string{"a": ""}, "a")
+	require.Error(t, err)
+}
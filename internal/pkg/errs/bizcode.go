@@ -11,4 +11,16 @@ const (
 	BizCodeResourceVersionNameConflict      = 8006
 	BizCodeResourceVersionStorageProcessing = 8007
 	BizResourceVersionNameUnparsable        = 8008
+	BizCodeResourceVersionNotFound          = 8009
+	BizCodeResourceChannelInUse             = 8010
+	BizCodeResourceVersionNotNewer          = 8011
+
+	BizCodeUploadSessionNotFound = 8101
+	BizCodeUploadOffsetMismatch  = 8102
+	BizCodeUploadIncomplete      = 8103
+	BizCodeUploadChecksumInvalid = 8104
+	BizCodeUploadBusy            = 8105
+
+	BizCodePurgeReportNotFound = 8201
+	BizCodeStorageNotFound     = 8202
 )
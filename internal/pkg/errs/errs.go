@@ -16,7 +16,19 @@ var (
 	ErrResourceIDAlreadyExists          = New(BizCodeResourceIDAlreadyExists, http.StatusBadRequest, "resource id already exists", nil)
 	ErrResourceVersionNameConflict      = New(BizCodeResourceVersionNameConflict, http.StatusConflict, "version name under the current platform architecture already exists", nil)
 	ErrResourceVersionStorageProcessing = New(BizCodeResourceVersionStorageProcessing, http.StatusConflict, "current version storage in process", nil)
-	ErrResourceVersionNameUnparsable    = New(BizResourceVersionNameUnparsable, http.StatusBadRequest, "version name is not supported for parsing, please use the stable channel", nil)
+	ErrResourceVersionNameUnparsable    = New(BizResourceVersionNameUnparsable, http.StatusBadRequest, "version name can't be parsed under the version scheme of the resource", nil)
+	ErrResourceVersionNotFound          = New(BizCodeResourceVersionNotFound, http.StatusNotFound, "version not found", nil)
+	ErrResourceChannelInUse             = New(BizCodeResourceChannelInUse, http.StatusConflict, "channel still has versions or is included by another channel", nil)
+	ErrResourceVersionNotNewer          = New(BizCodeResourceVersionNotNewer, http.StatusConflict, "version name does not sort after the latest version of the channel", nil)
+
+	ErrUploadSessionNotFound = New(BizCodeUploadSessionNotFound, http.StatusNotFound, "upload session not found or expired", nil)
+	ErrUploadOffsetMismatch  = New(BizCodeUploadOffsetMismatch, http.StatusConflict, "chunk offset does not match the uploaded size", nil)
+	ErrUploadIncomplete      = New(BizCodeUploadIncomplete, http.StatusBadRequest, "upload is not complete", nil)
+	ErrUploadChecksumInvalid = New(BizCodeUploadChecksumInvalid, http.StatusBadRequest, "sha256 mismatch, the upload has been reset", nil)
+	ErrUploadBusy            = New(BizCodeUploadBusy, http.StatusLocked, "upload session is busy with another request, retry later", nil)
+
+	ErrPurgeReportNotFound = New(BizCodePurgeReportNotFound, http.StatusNotFound, "purge report not found or expired", nil)
+	ErrStorageNotFound     = New(BizCodeStorageNotFound, http.StatusNotFound, "storage not found", nil)
 )
 
 type Error struct {
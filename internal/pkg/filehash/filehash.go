@@ -22,10 +22,14 @@ func Calculate(filePath string) (string, error) {
 		_ = f.Close()
 	}(file)
 
+	return Sum(file)
+}
+
+func Sum(r io.Reader) (string, error) {
 	h := sha256.New()
 	buf := bufpool.GetBuffer()
 	defer bufpool.PutBuffer(buf)
-	if _, err := io.CopyBuffer(h, file, *buf); err != nil {
+	if _, err := io.CopyBuffer(h, r, *buf); err != nil {
 		return "", err
 	}
 	return hex.EncodeToString(h.Sum(nil)), nil
@@ -0,0 +1,132 @@
+package locale
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Normalize canonical form of a language tag like zh-CN, zh-Hant-TW or en,
+// false for anything which isn't a language tag
+func Normalize(tag string) (string, bool) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 || !isAlpha(parts[0]) {
+		return "", false
+	}
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		p := parts[i]
+		if p == "" || len(p) > 8 || !isAlphaNum(p) {
+			return "", false
+		}
+		switch {
+		case len(p) == 4 && isAlpha(p):
+			// script
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		case len(p) == 2 && isAlpha(p):
+			// region
+			parts[i] = strings.ToUpper(p)
+		default:
+			parts[i] = strings.ToLower(p)
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+// ParsePreferences languages of an Accept-Language header or a comma separated list, most preferred first,
+// invalid tags, wildcards and q=0 are dropped
+func ParsePreferences(header string) []string {
+	type pref struct {
+		tag string
+		q   float64
+	}
+	var list []pref
+	for _, item := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(item, ";")
+		t, ok := Normalize(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		if q <= 0 {
+			continue
+		}
+		list = append(list, pref{tag: t, q: q})
+	}
+	slices.SortStableFunc(list, func(a, b pref) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	result := make([]string, 0, len(list))
+	for _, p := range list {
+		result = append(result, p.tag)
+	}
+	return result
+}
+
+// Match the available locale closest to the most preferred language,
+// zh-Hant-TW matches zh-Hant-TW, then zh-Hant, then zh and at last any other zh locale
+func Match(prefs, available []string) (string, bool) {
+	for _, p := range prefs {
+		for tag := p; tag != ""; tag = parent(tag) {
+			if slices.Contains(available, tag) {
+				return tag, true
+			}
+		}
+		base := language(p)
+		for _, a := range available {
+			if language(a) == base {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
+
+func parent(tag string) string {
+	i := strings.LastIndexByte(tag, '-')
+	if i < 0 {
+		return ""
+	}
+	return tag[:i]
+}
+
+func language(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !isLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNum(s string) bool {
+	for _, r := range s {
+		if !isLetter(r) && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
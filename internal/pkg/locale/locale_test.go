@@ -0,0 +1,67 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		Tag  string
+		Want string
+		Ok   bool
+	}{
+		{"zh-cn", "zh-CN", true},
+		{"zh_CN", "zh-CN", true},
+		{"EN", "en", true},
+		{"zh-hant-tw", "zh-Hant-TW", true},
+		{"es-419", "es-419", true},
+		{"", "", false},
+		{"*", "", false},
+		{"e", "", false},
+		{"zh--CN", "", false},
+		{"zh-CN!", "", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Tag, func(t *testing.T) {
+			got, ok := Normalize(tc.Tag)
+			assert.Equal(t, tc.Ok, ok)
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
+
+func TestParsePreferences(t *testing.T) {
+	assert.Equal(t,
+		[]string{"ja-JP", "ja", "en-US", "en"},
+		ParsePreferences("en-US;q=0.8, ja-JP, en;q=0.5, ja;q=0.9, *;q=0.1, fr;q=0"),
+	)
+	assert.Equal(t, []string{"ko"}, ParsePreferences("ko"))
+	assert.Empty(t, ParsePreferences(""))
+}
+
+func TestMatch(t *testing.T) {
+	available := []string{"zh-CN", "zh-Hant", "en", "ja"}
+	testCases := []struct {
+		Name  string
+		Prefs []string
+		Want  string
+		Ok    bool
+	}{
+		{"exact", []string{"ja"}, "ja", true},
+		{"parent", []string{"en-US"}, "en", true},
+		{"parent script", []string{"zh-Hant-TW"}, "zh-Hant", true},
+		{"same language", []string{"zh-SG"}, "zh-CN", true},
+		{"first match wins", []string{"fr", "ja", "en"}, "ja", true},
+		{"none", []string{"ko", "fr"}, "", false},
+		{"empty", nil, "", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, ok := Match(tc.Prefs, available)
+			assert.Equal(t, tc.Ok, ok)
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
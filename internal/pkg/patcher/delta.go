@@ -0,0 +1,168 @@
+package patcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// A pure-Go copy/insert delta in the spirit of bsdiff/xdelta: blocks of the
+// old file are indexed by a rolling hash, the new file is scanned for matching
+// blocks which are extended in both directions and emitted as copies, anything
+// else is emitted as literal inserts. The patch archive compresses the literals.
+//
+// layout: magic | uvarint(new size) | ops...
+//
+//	copy:   'C' | uvarint(old offset) | uvarint(length)
+//	insert: 'I' | uvarint(length) | bytes
+
+const DeltaAlgorithm = "copy-insert"
+
+var deltaMagic = []byte("RBD1")
+
+const (
+	opCopy   = 'C'
+	opInsert = 'I'
+
+	minDeltaBlock = 32
+	// keeps the block index of the old file at a few million entries
+	maxDeltaBlocks = 1 << 22
+
+	rollingBase uint64 = 257
+)
+
+var ErrCorruptDelta = errors.New("corrupt delta")
+
+// Delta both files are held in memory, callers cap the file size
+func Delta(old, new []byte) []byte {
+	var (
+		out = bytes.NewBuffer(make([]byte, 0, len(new)/8+16))
+		tmp = make([]byte, binary.MaxVarintLen64)
+	)
+	out.Write(deltaMagic)
+	putUvarint(out, tmp, uint64(len(new)))
+
+	bs := minDeltaBlock
+	for len(old)/bs > maxDeltaBlocks {
+		bs *= 2
+	}
+	if len(old) < bs || len(new) < bs {
+		emitInsert(out, tmp, new)
+		return out.Bytes()
+	}
+
+	// first occurrence wins
+	index := make(map[uint64]int, len(old)/bs)
+	for off := 0; off+bs <= len(old); off += bs {
+		h := blockHash(old[off : off+bs])
+		if _, ok := index[h]; !ok {
+			index[h] = off
+		}
+	}
+
+	var pow uint64 = 1
+	for range bs - 1 {
+		pow *= rollingBase
+	}
+
+	var (
+		i        = 0
+		literal  = 0
+		h        = blockHash(new[:bs])
+		matchEnd = len(new) - bs
+	)
+	for i <= matchEnd {
+		if off, ok := index[h]; ok && bytes.Equal(old[off:off+bs], new[i:i+bs]) {
+			s, o := i, off
+			for s > literal && o > 0 && new[s-1] == old[o-1] {
+				s--
+				o--
+			}
+			e, oe := i+bs, off+bs
+			for e < len(new) && oe < len(old) && new[e] == old[oe] {
+				e++
+				oe++
+			}
+			emitInsert(out, tmp, new[literal:s])
+			out.WriteByte(opCopy)
+			putUvarint(out, tmp, uint64(o))
+			putUvarint(out, tmp, uint64(e-s))
+
+			i, literal = e, e
+			if i <= matchEnd {
+				h = blockHash(new[i : i+bs])
+			}
+			continue
+		}
+		if i < matchEnd {
+			h = (h-uint64(new[i])*pow)*rollingBase + uint64(new[i+bs])
+		}
+		i++
+	}
+	emitInsert(out, tmp, new[literal:])
+	return out.Bytes()
+}
+
+func ApplyDelta(old, delta []byte) ([]byte, error) {
+	if !bytes.HasPrefix(delta, deltaMagic) {
+		return nil, ErrCorruptDelta
+	}
+	r := bytes.NewReader(delta[len(deltaMagic):])
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, ErrCorruptDelta
+	}
+	out := make([]byte, 0, size)
+	for {
+		op, err := r.ReadByte()
+		if err != nil {
+			break
+		}
+		switch op {
+		case opCopy:
+			off, e1 := binary.ReadUvarint(r)
+			n, e2 := binary.ReadUvarint(r)
+			if e1 != nil || e2 != nil || off+n > uint64(len(old)) || off+n < off {
+				return nil, ErrCorruptDelta
+			}
+			out = append(out, old[off:off+n]...)
+		case opInsert:
+			n, err := binary.ReadUvarint(r)
+			if err != nil || n > uint64(r.Len()) {
+				return nil, ErrCorruptDelta
+			}
+			start := len(out)
+			out = append(out, make([]byte, n)...)
+			_, _ = r.Read(out[start:])
+		default:
+			return nil, fmt.Errorf("%w: unknown op %q", ErrCorruptDelta, op)
+		}
+	}
+	if uint64(len(out)) != size {
+		return nil, ErrCorruptDelta
+	}
+	return out, nil
+}
+
+func blockHash(b []byte) uint64 {
+	var h uint64
+	for _, c := range b {
+		h = h*rollingBase + uint64(c)
+	}
+	return h
+}
+
+func emitInsert(out *bytes.Buffer, tmp []byte, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	out.WriteByte(opInsert)
+	putUvarint(out, tmp, uint64(len(b)))
+	out.Write(b)
+}
+
+func putUvarint(out *bytes.Buffer, tmp []byte, v uint64) {
+	n := binary.PutUvarint(tmp, v)
+	out.Write(tmp[:n])
+}
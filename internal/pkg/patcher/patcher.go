@@ -31,6 +31,35 @@ const (
 	Added
 )
 
+const (
+	// FormatFull every modified file is shipped whole, the only format old clients understand
+	FormatFull = 1
+	// FormatDelta modified files may be shipped as deltas listed under "delta" in changes.json
+	FormatDelta = 2
+
+	LatestFormat = FormatDelta
+)
+
+const (
+	// smaller files are always shipped whole
+	minDeltaFileSize = 4 << 10
+	// both versions of a file are held in memory while diffing
+	maxDeltaFileSize = 512 << 20
+	// a delta must save at least half of the file to be worth applying
+	maxDeltaRatio = 0.5
+)
+
+type DeltaRecord struct {
+	Algorithm    string `json:"algorithm"`
+	SourceSHA256 string `json:"source_sha256"`
+	TargetSHA256 string `json:"target_sha256"`
+}
+
+// NormalizeFormat clamps the patch format requested by a client to a supported one
+func NormalizeFormat(format int) int {
+	return max(FormatFull, min(format, LatestFormat))
+}
+
 type Change struct {
 	Filename   string     `json:"filename"`
 	ChangeType ChangeType `json:"change_type"`
@@ -249,9 +278,8 @@ func extractZipFile(origin string, pending map[string]string) error {
 	return wg.Wait()
 }
 
-func appendChangesRecord(root string, changes []Change, addedDirs, deletedDirs []string) error {
+func appendChangesRecord(root string, data any) error {
 	path := filepath.Join(root, "changes.json")
-	data := getChangesInfo(changes, addedDirs, deletedDirs)
 
 	f, err := os.Create(path)
 	if err != nil {
@@ -343,7 +371,25 @@ func GenerateV2(info model.PatchInfoTuple, changes []Change, addedDirs, deletedD
 		}
 	}
 
-	err = appendChangesRecord(root, changes, addedDirs, deletedDirs)
+	var record any = getChangesInfo(changes, addedDirs, deletedDirs)
+	if info.FormatVersion >= FormatDelta {
+		deltas, err := replaceWithDeltas(info, root, pending, changes)
+		if err != nil {
+			return err
+		}
+		v2 := map[string]any{
+			"format_version": FormatDelta,
+		}
+		for k, v := range record.(map[string][]string) {
+			v2[k] = v
+		}
+		if len(deltas) > 0 {
+			v2["delta"] = deltas
+		}
+		record = v2
+	}
+
+	err = appendChangesRecord(root, record)
 	if err != nil {
 		return err
 	}
@@ -361,3 +407,85 @@ func GenerateV2(info model.PatchInfoTuple, changes []Change, addedDirs, deletedD
 
 	return nil
 }
+
+// replaceWithDeltas overwrites the extracted modified files with a delta against the
+// base package wherever the delta is meaningfully smaller than the file itself
+func replaceWithDeltas(info model.PatchInfoTuple, root string, pending map[string]string, changes []Change) (map[string]DeltaRecord, error) {
+	deltas := make(map[string]DeltaRecord)
+	if info.BasePackage == "" {
+		return deltas, nil
+	}
+
+	base, err := os.MkdirTemp(os.TempDir(), "process-base")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp base directory: %w", err)
+	}
+	defer func(p string) {
+		go func(p string) {
+			_ = os.RemoveAll(p)
+		}(p)
+	}(base)
+
+	var candidates = make(map[string]string)
+	for _, change := range changes {
+		if change.ChangeType != Modified {
+			continue
+		}
+		stat, err := os.Stat(pending[change.Filename])
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat modified file: %w", err)
+		}
+		if stat.Size() < minDeltaFileSize || stat.Size() > maxDeltaFileSize {
+			continue
+		}
+		tmp := filepath.Join(base, change.Filename)
+		if err := os.MkdirAll(filepath.Dir(tmp), os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to create temp base directory: %w", err)
+		}
+		candidates[change.Filename] = tmp
+	}
+	if len(candidates) == 0 {
+		return deltas, nil
+	}
+
+	switch info.BaseFileType {
+	case string(types.Zip):
+		err = extractZipFile(info.BasePackage, candidates)
+	case string(types.Tgz):
+		err = extractTgzFile(info.BasePackage, candidates)
+	default:
+		return deltas, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract base package: %w", err)
+	}
+
+	for name, oldPath := range candidates {
+		old, err := os.ReadFile(oldPath)
+		if err != nil {
+			// not present in the base package, ship it whole
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		newPath := pending[name]
+		buf, err := os.ReadFile(newPath)
+		if err != nil {
+			return nil, err
+		}
+		d := Delta(old, buf)
+		if float64(len(d)) >= float64(len(buf))*maxDeltaRatio {
+			continue
+		}
+		if err := os.WriteFile(newPath, d, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write delta: %w", err)
+		}
+		deltas[name] = DeltaRecord{
+			Algorithm:    DeltaAlgorithm,
+			SourceSHA256: info.BaseHashes[name],
+			TargetSHA256: info.SrcHashes[name],
+		}
+	}
+	return deltas, nil
+}
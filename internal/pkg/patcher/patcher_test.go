@@ -2,8 +2,11 @@ package patcher
 
 import (
 	"archive/zip"
+	"bytes"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"testing"
 
@@ -664,6 +667,148 @@ func TestFullPipeline_RootOnlyFiles(t *testing.T) {
 	assert.False(t, hasDelDir)
 }
 
+// ---------- delta ----------
+
+func TestDeltaRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	random := func(n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(rnd.Uint32())
+		}
+		return b
+	}
+	base := random(64 << 10)
+
+	testCases := []struct {
+		Name string
+		Old  []byte
+		New  []byte
+	}{
+		{Name: "empty", Old: nil, New: nil},
+		{Name: "identical", Old: base, New: base},
+		{Name: "from empty", Old: nil, New: base[:1000]},
+		{Name: "to empty", Old: base, New: []byte{}},
+		{Name: "shorter than a block", Old: []byte("abc"), New: []byte("abd")},
+		{Name: "byte flipped", Old: base, New: func() []byte {
+			b := bytes.Clone(base)
+			b[12345] ^= 0xff
+			return b
+		}()},
+		{Name: "inserted and removed", Old: base, New: slices.Concat(base[:1000], random(300), base[5000:])},
+		{Name: "reordered", Old: base, New: slices.Concat(base[32<<10:], base[:32<<10])},
+		{Name: "unrelated", Old: base, New: random(10 << 10)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := Delta(tc.Old, tc.New)
+			got, err := ApplyDelta(tc.Old, d)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.New), len(got))
+			require.True(t, bytes.Equal(tc.New, got))
+		})
+	}
+
+	flipped := bytes.Clone(base)
+	flipped[100] ^= 1
+	assert.Less(t, len(Delta(base, flipped)), 1<<10, "a single changed byte should produce a tiny delta")
+}
+
+func TestApplyDeltaRejectsCorrupt(t *testing.T) {
+	old := bytes.Repeat([]byte("0123456789abcdef"), 64)
+	d := Delta(old, append(bytes.Clone(old), 'x'))
+
+	_, err := ApplyDelta(old, d[:len(d)-1])
+	require.ErrorIs(t, err, ErrCorruptDelta)
+	_, err = ApplyDelta(old, []byte("nope"))
+	require.ErrorIs(t, err, ErrCorruptDelta)
+	_, err = ApplyDelta(old[:10], d)
+	require.ErrorIs(t, err, ErrCorruptDelta)
+}
+
+func TestFullPipeline_Delta(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(3, 4))
+	big := make([]byte, 256<<10)
+	for i := range big {
+		big[i] = byte(rnd.Uint32())
+	}
+	patched := bytes.Clone(big)
+	copy(patched[4096:], "patched")
+
+	for _, fileType := range []types.FileType{types.Zip, types.Tgz} {
+		t.Run(string(fileType), func(t *testing.T) {
+			v1Dir := t.TempDir()
+			writeFile(t, v1Dir, "bin/app.dat", string(big))
+			writeFile(t, v1Dir, "small.txt", "small_v1")
+
+			v2Dir := t.TempDir()
+			writeFile(t, v2Dir, "bin/app.dat", string(patched))
+			writeFile(t, v2Dir, "small.txt", "small_v2")
+
+			build := buildZip
+			if fileType == types.Tgz {
+				build = buildTgz
+			}
+			v1Pkg := build(t, v1Dir, "v1"+types.GetFileSuffix(fileType))
+			v2Pkg := build(t, v2Dir, "v2"+types.GetFileSuffix(fileType))
+
+			v1Hashes := must(filehash.GetAll(v1Dir))
+			v2Hashes := must(filehash.GetAll(v2Dir))
+			changes := must(CalculateDiff(v2Hashes, v1Hashes))
+
+			generate := func(format int) string {
+				dest := filepath.Join(t.TempDir(), "patch"+types.GetFileSuffix(fileType))
+				require.NoError(t, GenerateV2(model.PatchInfoTuple{
+					SrcPackage:    v2Pkg,
+					DestPackage:   dest,
+					FileType:      string(fileType),
+					FormatVersion: format,
+					BasePackage:   v1Pkg,
+					BaseFileType:  string(fileType),
+					SrcHashes:     v2Hashes,
+					BaseHashes:    v1Hashes,
+				}, changes, nil, nil))
+				unpack := t.TempDir()
+				if fileType == types.Tgz {
+					require.NoError(t, archiver.UnpackTarGz(dest, unpack))
+				} else {
+					require.NoError(t, archiver.UnpackZip(dest, unpack))
+				}
+				return unpack
+			}
+
+			// old clients get whole files and the v1 changes.json
+			v1Patch := generate(FormatFull)
+			cj := readChangesJSON(t, v1Patch)
+			assert.Equal(t, []string{"bin/app.dat", "small.txt"}, sortedSlice(cj["modified"]))
+			require.Equal(t, patched, must(os.ReadFile(filepath.Join(v1Patch, "bin/app.dat"))))
+
+			v2Patch := generate(FormatDelta)
+			var record struct {
+				FormatVersion int                    `json:"format_version"`
+				Modified      []string               `json:"modified"`
+				Delta         map[string]DeltaRecord `json:"delta"`
+			}
+			require.NoError(t, sonic.Unmarshal(must(os.ReadFile(filepath.Join(v2Patch, "changes.json"))), &record))
+			assert.Equal(t, FormatDelta, record.FormatVersion)
+			assert.Len(t, record.Modified, 2)
+
+			name := filepath.FromSlash("bin/app.dat")
+			require.Len(t, record.Delta, 1, "small files are shipped whole")
+			assert.Equal(t, DeltaRecord{
+				Algorithm:    DeltaAlgorithm,
+				SourceSHA256: v1Hashes[name],
+				TargetSHA256: v2Hashes[name],
+			}, record.Delta[name])
+
+			d := must(os.ReadFile(filepath.Join(v2Patch, name)))
+			assert.Less(t, len(d), len(patched)/2)
+			require.Equal(t, patched, must(ApplyDelta(big, d)))
+			assert.Equal(t, "small_v2", string(must(os.ReadFile(filepath.Join(v2Patch, "small.txt")))))
+		})
+	}
+}
+
 // ---------- assertion helpers ----------
 
 func must[T any](v T, err error) T {
@@ -0,0 +1,70 @@
+package vercomp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CalVerParser calendar versions like 2024.10.1 or 2024.10.1-beta.2, a four digit year and a month
+// followed by up to two numbers (day or micro).
+// Precedence: year, month, the following numbers, a modifier sorts before the version without one.
+type CalVerParser struct{}
+
+type calVersion struct {
+	release  []uint64
+	modifier []string
+}
+
+func (p *CalVerParser) Name() string {
+	return "CalVerParser"
+}
+
+func (p *CalVerParser) CanParse(v string) bool {
+	_, err := p.Parse(v)
+	return err == nil
+}
+
+func (p *CalVerParser) Parse(v string) (interface{}, error) {
+	core, modifier, hasModifier := strings.Cut(v, "-")
+	release, err := parseNumbers(core, 2, 4)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported calver format")
+	}
+	if len(strings.SplitN(core, ".", 2)[0]) != 4 || release[1] < 1 || release[1] > 12 {
+		return nil, fmt.Errorf("unsupported calver format")
+	}
+	result := calVersion{release: release}
+	if hasModifier {
+		result.modifier, err = parsePrerelease(modifier)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported calver format")
+		}
+	}
+	return result, nil
+}
+
+func (p *CalVerParser) Compare(a, b interface{}) int {
+	verA := a.(calVersion)
+	verB := b.(calVersion)
+	if r := compareNumbers(verA.release, verB.release); r != Equal {
+		return r
+	}
+	return comparePrerelease(verA.modifier, verB.modifier)
+}
+
+// compareNumbers missing trailing components count as 0
+func compareNumbers(a, b []uint64) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if r := compareUint(x, y); r != Equal {
+			return r
+		}
+	}
+	return Equal
+}
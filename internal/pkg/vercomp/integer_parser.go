@@ -0,0 +1,37 @@
+package vercomp
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// IntegerParser monotonic build numbers like 1024
+type IntegerParser struct{}
+
+func (p *IntegerParser) Name() string {
+	return "IntegerParser"
+}
+
+func (p *IntegerParser) CanParse(v string) bool {
+	_, err := strconv.ParseUint(v, 10, 64)
+	return err == nil
+}
+
+func (p *IntegerParser) Parse(v string) (interface{}, error) {
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported integer version: %w", err)
+	}
+	return n, nil
+}
+
+func (p *IntegerParser) Compare(a, b interface{}) int {
+	numA := a.(uint64)
+	numB := b.(uint64)
+	if numA < numB {
+		return Less
+	} else if numA > numB {
+		return Greater
+	}
+	return Equal
+}
@@ -0,0 +1,152 @@
+package vercomp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// git describe output like 1.2.0-3-g1a2b3c, three commits after the 1.2.0 tag
+var describeSuffixPattern = regexp.MustCompile(`-(\d+)-g[0-9a-f]{4,40}$`)
+
+var prereleasePattern = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+// LooseSemVerParser semver tolerating a missing patch part or a fourth part like 1.2 and 1.2.3.4,
+// leading zeros and commit suffixes, build metadata after + is ignored.
+// Precedence: release parts, a pre-release sorts before its release, then commits after the tag.
+type LooseSemVerParser struct{}
+
+type looseVersion struct {
+	release []uint64
+	pre     []string
+	commits uint64
+}
+
+func (p *LooseSemVerParser) Name() string {
+	return "LooseSemVerParser"
+}
+
+func (p *LooseSemVerParser) CanParse(v string) bool {
+	_, err := p.Parse(v)
+	return err == nil
+}
+
+func (p *LooseSemVerParser) Parse(v string) (interface{}, error) {
+	v, _, _ = strings.Cut(v, "+")
+
+	var result looseVersion
+	if m := describeSuffixPattern.FindStringSubmatchIndex(v); m != nil {
+		n, err := strconv.ParseUint(v[m[2]:m[3]], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported loose semver format")
+		}
+		result.commits = n
+		v = v[:m[0]]
+	}
+
+	core, pre, hasPre := strings.Cut(v, "-")
+	release, err := parseNumbers(core, 2, 4)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported loose semver format")
+	}
+	result.release = release
+	if hasPre {
+		result.pre, err = parsePrerelease(pre)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported loose semver format")
+		}
+	}
+	return result, nil
+}
+
+func (p *LooseSemVerParser) Compare(a, b interface{}) int {
+	verA := a.(looseVersion)
+	verB := b.(looseVersion)
+	if r := compareNumbers(verA.release, verB.release); r != Equal {
+		return r
+	}
+	if r := comparePrerelease(verA.pre, verB.pre); r != Equal {
+		return r
+	}
+	return compareUint(verA.commits, verB.commits)
+}
+
+// parseNumbers dot separated non-negative numbers, between least and most parts
+func parseNumbers(s string, least, most int) ([]uint64, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < least || len(parts) > most {
+		return nil, fmt.Errorf("expected %d to %d parts", least, most)
+	}
+	result := make([]uint64, len(parts))
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+func parsePrerelease(s string) ([]string, error) {
+	ids := strings.Split(s, ".")
+	for _, id := range ids {
+		if !prereleasePattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid pre-release identifier %q", id)
+		}
+	}
+	return ids, nil
+}
+
+// comparePrerelease semver rules, no pre-release sorts after any pre-release,
+// numeric identifiers compare numerically and sort before alphanumeric ones
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return Equal
+	case len(a) == 0:
+		return Greater
+	case len(b) == 0:
+		return Less
+	}
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if r := compareIdentifier(a[i], b[i], Less); r != Equal {
+			return r
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+// compareIdentifier numeric is the result when only a is numeric
+func compareIdentifier(a, b string, numeric int) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return compareUint(na, nb)
+	case errA == nil:
+		return numeric
+	case errB == nil:
+		return -numeric
+	}
+	return strings.Compare(a, b)
+}
+
+func compareUint(a, b uint64) int {
+	if a < b {
+		return Less
+	} else if a > b {
+		return Greater
+	}
+	return Equal
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return Less
+	} else if a > b {
+		return Greater
+	}
+	return Equal
+}
@@ -0,0 +1,155 @@
+package vercomp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions
+var pep440Pattern = regexp.MustCompile(`^(?:(\d+)!)?` +
+	`(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// PEP440Parser python versions like 1.0.0.post1, 1.0a1, 1.0.dev3 or 1!2.0+local.
+// Precedence: epoch, release, dev releases before pre-releases (a < b < rc) before the release
+// before post-releases, then the local version.
+// A bare number is left to the integer scheme.
+type PEP440Parser struct{}
+
+const (
+	pep440None = -1
+	// a dev release without pre- and post-release sorts before every pre-release
+	pep440DevOnly = -2
+)
+
+type pep440Version struct {
+	epoch   uint64
+	release []uint64
+	// 0 a, 1 b, 2 rc
+	preKind int
+	preNum  uint64
+	post    int64
+	dev     int64
+	local   []string
+}
+
+func (p *PEP440Parser) Name() string {
+	return "PEP440Parser"
+}
+
+func (p *PEP440Parser) CanParse(v string) bool {
+	_, err := p.Parse(v)
+	return err == nil
+}
+
+func (p *PEP440Parser) Parse(v string) (interface{}, error) {
+	m := pep440Pattern.FindStringSubmatch(strings.ToLower(v))
+	if m == nil {
+		return nil, fmt.Errorf("unsupported pep 440 format")
+	}
+	release, err := parseNumbers(m[2], 1, 16)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported pep 440 format")
+	}
+	result := pep440Version{
+		release: release,
+		preKind: pep440None,
+		post:    pep440None,
+		dev:     pep440None,
+	}
+	if len(release) < 2 && m[3] == "" && m[5] == "" && m[6] == "" && m[8] == "" {
+		return nil, fmt.Errorf("unsupported pep 440 format")
+	}
+	if m[1] != "" {
+		result.epoch, _ = strconv.ParseUint(m[1], 10, 64)
+	}
+	if m[3] != "" {
+		switch m[3] {
+		case "a", "alpha":
+			result.preKind = 0
+		case "b", "beta":
+			result.preKind = 1
+		default:
+			result.preKind = 2
+		}
+		result.preNum = parseOptionalNumber(m[4])
+	}
+	switch {
+	case m[5] != "":
+		result.post = int64(parseOptionalNumber(m[5]))
+	case m[6] != "":
+		result.post = int64(parseOptionalNumber(m[7]))
+	}
+	if m[8] != "" {
+		result.dev = int64(parseOptionalNumber(m[9]))
+	}
+	if m[10] != "" {
+		result.local = strings.FieldsFunc(m[10], func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+	return result, nil
+}
+
+func parseOptionalNumber(s string) uint64 {
+	n, _ := strconv.ParseUint(s, 10, 64)
+	return n
+}
+
+func (p *PEP440Parser) Compare(a, b interface{}) int {
+	verA := a.(pep440Version)
+	verB := b.(pep440Version)
+	if r := compareUint(verA.epoch, verB.epoch); r != Equal {
+		return r
+	}
+	if r := compareNumbers(verA.release, verB.release); r != Equal {
+		return r
+	}
+	if r := compareInt(verA.preKey(), verB.preKey()); r != Equal {
+		return r
+	}
+	if r := compareUint(verA.preNum, verB.preNum); r != Equal {
+		return r
+	}
+	if r := compareInt(int(verA.post), int(verB.post)); r != Equal {
+		return r
+	}
+	if r := compareInt(verA.devKey(), verB.devKey()); r != Equal {
+		return r
+	}
+	return compareLocal(verA.local, verB.local)
+}
+
+// preKey the release itself sorts after its pre-releases
+func (v pep440Version) preKey() int {
+	switch {
+	case v.preKind == pep440None && v.post == pep440None && v.dev != pep440None:
+		return pep440DevOnly
+	case v.preKind == pep440None:
+		return 3
+	}
+	return v.preKind
+}
+
+// devKey a version sorts after its dev releases
+func (v pep440Version) devKey() int {
+	if v.dev == pep440None {
+		return int(^uint(0) >> 1)
+	}
+	return int(v.dev)
+}
+
+// compareLocal no local version sorts first, numeric segments sort after alphanumeric ones
+func compareLocal(a, b []string) int {
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if r := compareIdentifier(a[i], b[i], Greater); r != Equal {
+			return r
+		}
+	}
+	return compareInt(len(a), len(b))
+}
@@ -33,13 +33,17 @@ type VersionComparator struct {
 func NewDefaultParsers() []Parser {
 	return []Parser{
 		&SemVerParser{},
-		&DateTimeParser{
-			Layouts: []string{
-				time.RFC3339,
-				time.DateTime,
-				"2006-01-02 15:04:05.000",
-				"20060102150405",
-			},
+		NewDateTimeParser(),
+	}
+}
+
+func NewDateTimeParser() *DateTimeParser {
+	return &DateTimeParser{
+		Layouts: []string{
+			time.RFC3339,
+			time.DateTime,
+			"2006-01-02 15:04:05.000",
+			"20060102150405",
 		},
 	}
 }
@@ -195,3 +195,128 @@ func TestVersionComparatorCompare(t *testing.T) {
 		})
 	}
 }
+
+type parserCase struct {
+	Ver1     string
+	Ver2     string
+	Expected int
+}
+
+func runParserCases(t *testing.T, p Parser, cases []parserCase) {
+	for _, tc := range cases {
+		t.Run(tc.Ver1+"_"+tc.Ver2, func(t *testing.T) {
+			require.True(t, p.CanParse(tc.Ver1), tc.Ver1)
+			require.True(t, p.CanParse(tc.Ver2), tc.Ver2)
+			a, err := p.Parse(tc.Ver1)
+			require.NoError(t, err)
+			b, err := p.Parse(tc.Ver2)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, p.Compare(a, b))
+			require.Equal(t, -tc.Expected, p.Compare(b, a))
+		})
+	}
+}
+
+func TestLooseSemVerParser(t *testing.T) {
+	p := &LooseSemVerParser{}
+	runParserCases(t, p, []parserCase{
+		{"1.2", "1.2.0", Equal},
+		{"1.2", "1.2.1", Less},
+		{"1.2.3.4", "1.2.3", Greater},
+		{"1.2.3.4", "1.2.3.10", Less},
+		{"01.02.03", "1.2.3", Equal},
+		{"5.2.0-beta.3+g1a2b3c", "5.2.0-beta.3", Equal},
+		{"5.2.0-beta.3", "5.2.0-beta.10", Less},
+		{"5.2.0-beta", "5.2.0-beta.1", Less},
+		{"5.2.0-1", "5.2.0-beta", Less},
+		{"5.2.0-rc.1", "5.2.0", Less},
+		{"5.2.0-3-g1a2b3c", "5.2.0", Greater},
+		{"5.2.0-3-g1a2b3c", "5.2.0-12-gabcdef0", Less},
+		{"5.2.0-3-g1a2b3c", "5.2.1", Less},
+		{"5.2.0-beta.1-2-g1a2b3c", "5.2.0-beta.1", Greater},
+	})
+	for _, v := range []string{"1", "1.2.3.4.5", "1.x", "1.2.3-", "1.2.3-beta..1", ""} {
+		require.False(t, p.CanParse(v), v)
+	}
+}
+
+func TestCalVerParser(t *testing.T) {
+	p := &CalVerParser{}
+	runParserCases(t, p, []parserCase{
+		{"2024.10.1", "2024.9.30", Greater},
+		{"2024.01.05", "2024.1.5", Equal},
+		{"2024.10", "2024.10.0", Equal},
+		{"2024.10.1", "2024.10.1.1", Less},
+		{"2024.10.1-beta.2", "2024.10.1", Less},
+		{"2024.10.1-beta.2", "2024.10.1-beta.10", Less},
+		{"2023.12.31", "2024.1.1", Less},
+	})
+	for _, v := range []string{"24.10.1", "2024", "2024.13.1", "2024.0.1", "2024.10.1.1.1", "1.2.3"} {
+		require.False(t, p.CanParse(v), v)
+	}
+}
+
+func TestPEP440Parser(t *testing.T) {
+	p := &PEP440Parser{}
+	runParserCases(t, p, []parserCase{
+		{"1.0.0.post1", "1.0.0", Greater},
+		{"1.0.0.post1", "1.0.0.post2", Less},
+		{"1.0-1", "1.0.post1", Equal},
+		{"1.0a1", "1.0", Less},
+		{"1.0a1", "1.0b1", Less},
+		{"1.0b2", "1.0rc1", Less},
+		{"1.0c1", "1.0rc1", Equal},
+		{"1.0alpha1", "1.0a1", Equal},
+		{"1.0.dev3", "1.0a1", Less},
+		{"1.0a1.dev1", "1.0a1", Less},
+		{"1.0.post1.dev1", "1.0.post1", Less},
+		{"1.0.post1.dev1", "1.0", Greater},
+		{"1.0", "1.0.0", Equal},
+		{"1!1.0", "2.0", Greater},
+		{"1.0+abc", "1.0", Greater},
+		{"1.0+abc.5", "1.0+abc.10", Less},
+		{"1.0+abc", "1.0+5", Less},
+		{"1.0.0-beta.1", "1.0.0rc1", Less},
+	})
+	for _, v := range []string{"1", "1.0+", "1.0.gamma1", "latest", ""} {
+		require.False(t, p.CanParse(v), v)
+	}
+}
+
+func TestIntegerParser(t *testing.T) {
+	p := &IntegerParser{}
+	runParserCases(t, p, []parserCase{
+		{"99", "100", Less},
+		{"0100", "100", Equal},
+	})
+	require.False(t, p.CanParse("1.0"))
+	require.False(t, p.CanParse("-1"))
+}
+
+func TestVersionComparatorMixedFormats(t *testing.T) {
+	testCases := []struct {
+		Parsers            []Parser
+		Ver1               string
+		Ver2               string
+		ExpectedComparable bool
+		ExpectedResult     int
+	}{
+		// the default parsers stay SemVer and DateTime
+		{nil, "1.2", "1.2.1", false, Invalid},
+		{nil, "1.0.0", "1.0.0.post1", false, Invalid},
+		{[]Parser{&LooseSemVerParser{}}, "1.2", "1.2.1", true, Less},
+		{[]Parser{&LooseSemVerParser{}}, "v1.2.3.4", "v1.2.3", true, Greater},
+		{[]Parser{&PEP440Parser{}}, "1.0.0", "1.0.0.post1", true, Less},
+		{[]Parser{&CalVerParser{}}, "2024.10.1", "2024.9.30", true, Greater},
+		// names of different parsers are never compared
+		{[]Parser{&SemVerParser{}, &PEP440Parser{}}, "1.0.0", "1.0.0.post1", false, Invalid},
+		{[]Parser{&SemVerParser{}, &LooseSemVerParser{}}, "1.2", "1.2.1", false, Invalid},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Ver1+"_"+tc.Ver2, func(t *testing.T) {
+			ret := NewComparator(tc.Parsers...).Compare(tc.Ver1, tc.Ver2)
+			require.Equal(t, tc.ExpectedComparable, ret.Comparable)
+			require.Equal(t, tc.ExpectedResult, ret.Result)
+		})
+	}
+}
@@ -2,9 +2,11 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/MirrorChyan/resource-backend/internal/ent"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
 )
 
@@ -49,15 +51,27 @@ func (r *Version) buildVersionQuery(resID, channel string) *ent.VersionQuery {
 	q := r.db.Version.Query().
 		Where(version.HasResourceWith(resource.ID(resID)))
 	if channel != "" {
-		q = q.Where(version.ChannelEQ(version.Channel(channel)))
+		q = q.Where(version.Channel(channel))
 	}
 	return q
 }
 
 // ListVersionsByResource returns versions of a resource, optionally filtered by
-// channel, ordered by number desc, paginated by offset/limit.
+// channel, ordered by number desc, paginated by offset/limit. Full storages are
+// loaded for the rollout and yank state per platform.
 func (r *Version) ListVersionsByResource(ctx context.Context, resID string, offset, limit int, channel string) ([]*ent.Version, error) {
 	return r.buildVersionQuery(resID, channel).
+		WithStorages(func(q *ent.StorageQuery) {
+			q.Where(storage.UpdateTypeEQ(storage.UpdateTypeFull)).
+				Select(
+					storage.FieldOs,
+					storage.FieldArch,
+					storage.FieldRolloutPercent,
+					storage.FieldYankedAt,
+					storage.FieldYankReason,
+					storage.FieldVersionStorages,
+				)
+		}).
 		Order(ent.Desc(version.FieldNumber)).
 		Offset(offset).
 		Limit(limit).
@@ -67,3 +81,14 @@ func (r *Version) ListVersionsByResource(ctx context.Context, resID string, offs
 func (r *Version) CountVersionsByResource(ctx context.Context, resID, channel string) (int, error) {
 	return r.buildVersionQuery(resID, channel).Count(ctx)
 }
+
+// ListScheduledVersions versions with a publish_at after now, the next to go live first.
+func (r *Version) ListScheduledVersions(ctx context.Context, now time.Time) ([]*ent.Version, error) {
+	return r.db.Version.Query().
+		Where(version.PublishAtGT(now)).
+		WithResource(func(q *ent.ResourceQuery) {
+			q.Select(resource.FieldID, resource.FieldName)
+		}).
+		Order(ent.Asc(version.FieldPublishAt)).
+		All(ctx)
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/ent/channel"
+	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+)
+
+type Channel struct {
+	*Repo
+}
+
+func NewChannel(db *Repo) *Channel {
+	return &Channel{
+		Repo: db,
+	}
+}
+
+func (r *Channel) ListChannels(ctx context.Context, resID string) ([]*ent.Channel, error) {
+	return r.db.Channel.Query().
+		Where(channel.HasResourceWith(resource.ID(resID))).
+		Order(ent.Asc(channel.FieldName)).
+		All(ctx)
+}
+
+// UpsertChannel creates the channel or replaces its includes
+func (r *Channel) UpsertChannel(ctx context.Context, resID, name string, includes []string) error {
+	n, err := r.db.Channel.Update().
+		Where(
+			channel.HasResourceWith(resource.ID(resID)),
+			channel.Name(name),
+		).
+		SetIncludes(includes).
+		Save(ctx)
+	if err != nil || n > 0 {
+		return err
+	}
+	return r.db.Channel.Create().
+		SetResourceID(resID).
+		SetName(name).
+		SetIncludes(includes).
+		Exec(ctx)
+}
+
+func (r *Channel) DeleteChannel(ctx context.Context, resID, name string) (int, error) {
+	return r.db.Channel.Delete().
+		Where(
+			channel.HasResourceWith(resource.ID(resID)),
+			channel.Name(name),
+		).
+		Exec(ctx)
+}
+
+func (r *Channel) CountChannelVersions(ctx context.Context, resID, name string) (int, error) {
+	return r.db.Version.Query().
+		Where(
+			version.HasResourceWith(resource.ID(resID)),
+			version.Channel(name),
+		).
+		Count(ctx)
+}
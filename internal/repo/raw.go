@@ -1,8 +1,11 @@
 package repo
 
 import (
+	"time"
+
 	"github.com/MirrorChyan/resource-backend/internal/config"
 	"github.com/MirrorChyan/resource-backend/internal/model"
+	"github.com/bytedance/sonic"
 	"go.uber.org/zap"
 )
 
@@ -17,11 +20,13 @@ func NewRawQuery(db *Repo) *RawQuery {
 }
 
 const (
+	// newest storages per channel down to the first one rolled out to every client
 	sql1 = `
-with latest as (select v.id                                                                         as version_id,
+with ranked as (select v.id                                                                         as version_id,
                        v.name                                                                       as version_name,
                        v.number                                                                     as version_number,
                        v.release_note                                                               as release_note,
+                       v.release_notes                                                              as release_notes,
                        v.custom_data                                                                as custom_data,
                        v.channel                                                                    as channel,
                        s.os                                                                         as os,
@@ -29,36 +34,110 @@ with latest as (select v.id
                        s.package_hash_sha256                                                        as package_hash_sha256,
                        s.package_path                                                               as package_path,
                        s.file_size                                                                  as file_size,
+                       s.rollout_percent                                                            as rollout_percent,
                        v.created_at                                                                 as created_at,
                        row_number() over (partition by channel,os,arch order by s.created_at desc ) as version_serial
                 from versions v
                          left join storages s on v.id = s.version_storages
                 where s.package_path is not null
+                  and s.broken_at is null
+                  and s.quarantined_at is null
+                  and s.yanked_at is null
                   and v.resource_versions = ?
                   and s.os = ?
                   and s.arch = ?
-                  and s.update_type = 'full')
-select *
+                  and s.update_type = 'full'
+                  and (v.publish_at is null or v.publish_at <= ?)),
+     latest as (select ranked.*,
+                       min(case when rollout_percent >= 100 then version_serial end) over (partition by channel) as full_serial
+                from ranked)
+select version_id,
+       version_name,
+       version_number,
+       release_note,
+       release_notes,
+       custom_data,
+       channel,
+       os,
+       arch,
+       package_hash_sha256,
+       package_path,
+       file_size,
+       rollout_percent,
+       created_at,
+       version_serial
 from latest
-where latest.version_serial = 1
+where latest.full_serial is null
+   or latest.version_serial <= latest.full_serial
+order by channel, version_serial
 `
+	// only storages served to every client are ranked, newer rollouts in progress and scheduled releases are pending
 	sql2 = `
-with latest as (select v.name                                                                       as version_name,
-                       v.channel                                                                    as channel,
-                       v.resource_versions                                                          as resource_id,
-                       v.id                                                                         as version_id,
-                       s.os                                                                         as os,
-                       s.arch                                                                       as arch,
-                       s.id                                                                         as storage_id,
-                       row_number() over (partition by channel,os,arch order by s.created_at desc ) as version_serial
-                from versions v
-                         left join storages s on v.id = s.version_storages
-                where s.package_path is not null
-                  and v.resource_versions = ?
-                  and s.update_type = 'full')
-select *
-from latest
-where latest.version_serial not in (1, 2)
+with candidates as (select v.name                    as version_name,
+                           v.channel                 as channel,
+                           v.resource_versions       as resource_id,
+                           v.id                      as version_id,
+                           s.os                      as os,
+                           s.arch                    as arch,
+                           s.id                      as storage_id,
+                           s.package_path            as package_path,
+                           v.created_at              as created_at,
+                           s.created_at              as storage_created_at,
+                           s.rollout_percent         as rollout_percent,
+                           (s.broken_at is null
+                               and s.quarantined_at is null
+                               and s.yanked_at is null
+                               and (v.publish_at is null or v.publish_at <= ?)) as servable,
+                           (v.publish_at is not null and v.publish_at > ?)        as scheduled
+                    from versions v
+                             left join storages s on v.id = s.version_storages
+                    where s.package_path is not null
+                      and v.resource_versions = ?
+                      and s.update_type = 'full'),
+     ranked as (select candidates.*,
+                       servable and rollout_percent >= 100                                             as served,
+                       max(case when servable and rollout_percent >= 100 then storage_created_at end)
+                           over (partition by channel,os,arch)                                         as served_at
+                from candidates)
+select version_name,
+       channel,
+       resource_id,
+       version_id,
+       os,
+       arch,
+       storage_id,
+       package_path,
+       created_at,
+       case
+           when served then row_number() over (partition by channel,os,arch,served order by storage_created_at desc)
+           else 0 end                                                                     as version_serial,
+       scheduled or (servable and rollout_percent < 100 and
+                     (served_at is null or storage_created_at > served_at))              as pending
+from ranked
+order by channel, os, arch, version_serial
+`
+	sql3 = `
+select s.version_storages    as target_version_id,
+       tv.name               as target_version_name,
+       s.storage_old_version as current_version_id,
+       cv.name               as current_version_name,
+       s.package_path        as package_path,
+       s.package_hash_sha256 as package_hash_sha256,
+       s.file_size           as file_size,
+       s.patch_format        as patch_format
+from storages s
+         join versions tv on tv.id = s.version_storages
+         join versions cv on cv.id = s.storage_old_version
+where s.package_path is not null
+  and s.broken_at is null
+  and s.quarantined_at is null
+  and s.yanked_at is null
+  and tv.resource_versions = ?
+  and s.os = ?
+  and s.arch = ?
+  and s.update_type = 'incremental'
+  and s.patch_format <= ?
+  and (tv.publish_at is null or tv.publish_at <= ?)
 `
 )
 
@@ -71,7 +150,7 @@ func (r *RawQuery) GetSpecifiedLatestVersion(resourceId, os, arch string) ([]mod
 			zap.String("arch", arch),
 		)
 	}
-	err := r.dx.Select(&result, sql1, resourceId, os, arch)
+	err := r.dx.Select(&result, sql1, resourceId, os, arch, time.Now())
 	if err != nil {
 		zap.L().Error("GetSpecifiedLatestVersion",
 			zap.String("resource id", resourceId),
@@ -81,22 +160,34 @@ func (r *RawQuery) GetSpecifiedLatestVersion(resourceId, os, arch string) ([]mod
 		)
 		return nil, err
 	}
+	for i := range result {
+		if len(result[i].RawReleaseNotes) == 0 {
+			continue
+		}
+		if err := sonic.Unmarshal(result[i].RawReleaseNotes, &result[i].ReleaseNotes); err != nil {
+			return nil, err
+		}
+		result[i].RawReleaseNotes = nil
+	}
 	return result, err
 }
 
-func (r *RawQuery) GetReadyToPurgeInfo(resourceId string) ([]model.ResourcePurgeInfo, error) {
+// GetPurgeCandidates every full storage of the resource, the ones served to every client ranked per channel/os/arch
+// newest first, the retention policy decides which of them are purged
+func (r *RawQuery) GetPurgeCandidates(resourceId string) ([]model.ResourcePurgeInfo, error) {
 	if len(resourceId) == 0 {
 		return nil, nil
 	}
 	var result []model.ResourcePurgeInfo
 	if config.GConfig.Extra.SqlDebugMode {
-		zap.L().Info("GetReadyToPurgeInfo",
+		zap.L().Info("GetPurgeCandidates",
 			zap.String("resourceId", resourceId),
 		)
 	}
-	err := r.dx.Select(&result, sql2, resourceId)
+	now := time.Now()
+	err := r.dx.Select(&result, sql2, now, now, resourceId)
 	if err != nil {
-		zap.L().Error("GetReadyToPurgeInfo",
+		zap.L().Error("GetPurgeCandidates",
 			zap.String("resourceId", resourceId),
 			zap.Error(err),
 		)
@@ -105,3 +196,26 @@ func (r *RawQuery) GetReadyToPurgeInfo(resourceId string) ([]model.ResourcePurge
 
 	return result, err
 }
+
+func (r *RawQuery) GetPatchEdges(resourceId, os, arch string, patchFormat int) ([]model.PatchEdge, error) {
+	var result []model.PatchEdge
+	if config.GConfig.Extra.SqlDebugMode {
+		zap.L().Info("GetPatchEdges",
+			zap.String("resource id", resourceId),
+			zap.String("os", os),
+			zap.String("arch", arch),
+			zap.Int("patch format", patchFormat),
+		)
+	}
+	err := r.dx.Select(&result, sql3, resourceId, os, arch, patchFormat, time.Now())
+	if err != nil {
+		zap.L().Error("GetPatchEdges",
+			zap.String("resource id", resourceId),
+			zap.String("os", os),
+			zap.String("arch", arch),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return result, err
+}
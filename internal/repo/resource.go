@@ -5,6 +5,8 @@ import (
 
 	"github.com/MirrorChyan/resource-backend/internal/ent"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+	"github.com/MirrorChyan/resource-backend/internal/ent/version"
+	"github.com/MirrorChyan/resource-backend/internal/model/types"
 )
 
 type Resource struct {
@@ -17,18 +19,27 @@ func NewResource(db *Repo) *Resource {
 	}
 }
 
-func (r *Resource) FindUpdateTypeById(ctx context.Context, id string) (*ent.Resource, error) {
+func (r *Resource) FindResourceInfoById(ctx context.Context, id string) (*ent.Resource, error) {
 	return r.db.Resource.Query().
-		Select(resource.FieldUpdateType).
+		Select(
+			resource.FieldUpdateType,
+			resource.FieldVersionScheme,
+			resource.FieldDistributionPolicy,
+			resource.FieldPatchPolicy,
+			resource.FieldRetentionPolicy,
+			resource.FieldSupportPolicy,
+		).
 		Where(resource.ID(id)).
+		WithChannels().
 		First(ctx)
 }
 
-func (r *Resource) CreateResource(ctx context.Context, resID, name, description, updateType string) (*ent.Resource, error) {
+func (r *Resource) CreateResource(ctx context.Context, resID, name, description, updateType, versionScheme string) (*ent.Resource, error) {
 	return r.db.Resource.Create().
 		SetID(resID).
 		SetName(name).
 		SetUpdateType(updateType).
+		SetVersionScheme(versionScheme).
 		SetDescription(description).
 		Save(ctx)
 }
@@ -39,6 +50,43 @@ func (r *Resource) CheckResourceExistsByID(ctx context.Context, id string) (bool
 		Exist(ctx)
 }
 
+func (r *Resource) UpdateDistributionPolicy(ctx context.Context, id string, policy types.DistributionPolicy) error {
+	return r.db.Resource.UpdateOneID(id).
+		SetDistributionPolicy(policy).
+		Exec(ctx)
+}
+
+func (r *Resource) UpdatePatchPolicy(ctx context.Context, id string, policy types.PatchPolicy) error {
+	return r.db.Resource.UpdateOneID(id).
+		SetPatchPolicy(policy).
+		Exec(ctx)
+}
+
+func (r *Resource) UpdateRetentionPolicy(ctx context.Context, id string, policy types.RetentionPolicy) error {
+	return r.db.Resource.UpdateOneID(id).
+		SetRetentionPolicy(policy).
+		Exec(ctx)
+}
+
+func (r *Resource) UpdateSupportPolicy(ctx context.Context, id string, policy types.SupportPolicy) error {
+	return r.db.Resource.UpdateOneID(id).
+		SetSupportPolicy(policy).
+		Exec(ctx)
+}
+
+func (r *Resource) UpdateVersionScheme(ctx context.Context, id string, scheme string) error {
+	return r.db.Resource.UpdateOneID(id).
+		SetVersionScheme(scheme).
+		Exec(ctx)
+}
+
+func (r *Resource) ListVersionNames(ctx context.Context, id string) ([]string, error) {
+	return r.db.Version.Query().
+		Where(version.HasResourceWith(resource.ID(id))).
+		Select(version.FieldName).
+		Strings(ctx)
+}
+
 func (r *Resource) GetFullResource(ctx context.Context) ([]*ent.Resource, error) {
 	return r.db.Resource.Query().All(ctx)
 }
@@ -2,8 +2,11 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/MirrorChyan/resource-backend/internal/ent"
+	"github.com/MirrorChyan/resource-backend/internal/ent/predicate"
+	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
 	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
 	"github.com/MirrorChyan/resource-backend/internal/model/types"
@@ -23,6 +26,7 @@ func (r *Storage) CreateFullUpdateStorage(ctx context.Context, verID int,
 	os, arch, path, hash string, fileType types.FileType,
 	size int64,
 	fileHashes map[string]string,
+	rolloutPercent int,
 ) (*ent.Storage, error) {
 
 	return r.db.Storage.Create().
@@ -34,16 +38,18 @@ func (r *Storage) CreateFullUpdateStorage(ctx context.Context, verID int,
 		SetFileType(string(fileType)).
 		SetFileSize(size).
 		SetFileHashes(fileHashes).
+		SetRolloutPercent(rolloutPercent).
 		SetVersionID(verID).
 		Save(ctx)
 }
 
 func (r *Storage) CreateIncrementalUpdateStorage(ctx context.Context, tx *ent.Tx,
 	verID, oldVerID int, filetype string, filesize int64,
-	os, arch, updatePath, hashes string,
+	os, arch, updatePath, hashes string, patchFormat int,
 ) (*ent.Storage, error) {
 	return tx.Storage.Create().
 		SetUpdateType(storage.UpdateTypeIncremental).
+		SetPatchFormat(patchFormat).
 		SetOs(os).
 		SetArch(arch).
 		SetPackagePath(updatePath).
@@ -80,7 +86,8 @@ func (r *Storage) UpdateStoragePackageHash(ctx context.Context, id int, hash str
 	return r.db.Storage.UpdateOneID(id).SetPackageHashSha256(hash).Exec(ctx)
 }
 
-func (r *Storage) GetIncrementalUpdateStorage(ctx context.Context, verID, oldVerID int, os, arch string) (*ent.Storage, error) {
+// GetIncrementalUpdateStorage the newest patch format the client understands
+func (r *Storage) GetIncrementalUpdateStorage(ctx context.Context, verID, oldVerID int, os, arch string, patchFormat int) (*ent.Storage, error) {
 	return r.db.Storage.Query().
 		Where(
 			storage.HasVersionWith(version.ID(verID)),
@@ -88,8 +95,13 @@ func (r *Storage) GetIncrementalUpdateStorage(ctx context.Context, verID, oldVer
 			storage.UpdateTypeEQ(storage.UpdateTypeIncremental),
 			storage.Os(os),
 			storage.Arch(arch),
+			storage.PatchFormatLTE(patchFormat),
+			storage.BrokenAtIsNil(),
+			storage.QuarantinedAtIsNil(),
+			storage.YankedAtIsNil(),
 		).
-		Only(ctx)
+		Order(ent.Desc(storage.FieldPatchFormat)).
+		First(ctx)
 }
 
 func (r *Storage) PurgeStorageInfo(ctx context.Context, storageId int) error {
@@ -108,3 +120,206 @@ func (r *Storage) PurgeStorageInfo(ctx context.Context, storageId int) error {
 	}
 	return nil
 }
+
+// ListPackageStorages every storage still pointing at a package file
+func (r *Storage) ListPackageStorages(ctx context.Context) ([]*ent.Storage, error) {
+	return r.db.Storage.Query().
+		Select(
+			storage.FieldUpdateType,
+			storage.FieldOs,
+			storage.FieldArch,
+			storage.FieldVersionStorages,
+			storage.FieldPackagePath,
+			storage.FieldBrokenAt,
+		).
+		Where(storage.PackagePathNotNil()).
+		All(ctx)
+}
+
+// MarkStoragesBroken a nil time clears the mark
+func (r *Storage) MarkStoragesBroken(ctx context.Context, ids []int, at *time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	u := r.db.Storage.Update().Where(storage.IDIn(ids...))
+	if at == nil {
+		u.ClearBrokenAt()
+	} else {
+		u.SetBrokenAt(*at)
+	}
+	return u.Exec(ctx)
+}
+
+// ListStoragesToVerify servable storages, never verified first then the longest ago
+func (r *Storage) ListStoragesToVerify(ctx context.Context, limit int) ([]*ent.Storage, error) {
+	return r.db.Storage.Query().
+		Select(
+			storage.FieldPackagePath,
+			storage.FieldPackageHashSha256,
+			storage.FieldFileSize,
+			storage.FieldLastVerifiedAt,
+		).
+		Where(
+			storage.PackagePathNotNil(),
+			storage.BrokenAtIsNil(),
+			storage.QuarantinedAtIsNil(),
+		).
+		Order(ent.Asc(storage.FieldLastVerifiedAt), ent.Asc(storage.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+func (r *Storage) MarkStorageVerified(ctx context.Context, id int, at time.Time) error {
+	return r.db.Storage.UpdateOneID(id).SetLastVerifiedAt(at).Exec(ctx)
+}
+
+// QuarantineStorage a nil time releases the storage
+func (r *Storage) QuarantineStorage(ctx context.Context, id int, at *time.Time) error {
+	u := r.db.Storage.UpdateOneID(id)
+	if at == nil {
+		u.ClearQuarantinedAt()
+	} else {
+		u.SetQuarantinedAt(*at)
+	}
+	return u.Exec(ctx)
+}
+
+// CountPackageReferences storages pointing at the package, storages of excludeVersion are not counted when it is set
+func (r *Storage) CountPackageReferences(ctx context.Context, packagePath string, excludeVersion int) (int, error) {
+	q := r.db.Storage.Query().Where(storage.PackagePath(packagePath))
+	if excludeVersion > 0 {
+		q = q.Where(storage.VersionStoragesNEQ(excludeVersion))
+	}
+	return q.Count(ctx)
+}
+
+// HasTaintedPackageReferences whether a storage pointing at the package is quarantined or broken
+func (r *Storage) HasTaintedPackageReferences(ctx context.Context, packagePath string) (bool, error) {
+	return r.db.Storage.Query().
+		Where(
+			storage.PackagePath(packagePath),
+			storage.Or(storage.QuarantinedAtNotNil(), storage.BrokenAtNotNil()),
+		).
+		Exist(ctx)
+}
+
+// ListServedStorages storages of the version clients can currently be served
+func (r *Storage) ListServedStorages(ctx context.Context, verID int) ([]*ent.Storage, error) {
+	return r.db.Storage.Query().
+		Where(
+			storage.VersionStorages(verID),
+			storage.PackagePathNotNil(),
+			storage.BrokenAtIsNil(),
+			storage.QuarantinedAtIsNil(),
+			storage.YankedAtIsNil(),
+		).
+		WithOldVersion(func(q *ent.VersionQuery) {
+			q.Select(version.FieldID)
+		}).
+		All(ctx)
+}
+
+// CloneStorages the same packages attached to another version
+func (r *Storage) CloneStorages(ctx context.Context, tx *ent.Tx, list []*ent.Storage, verID int) error {
+	builders := make([]*ent.StorageCreate, 0, len(list))
+	for _, s := range list {
+		c := tx.Storage.Create().
+			SetUpdateType(s.UpdateType).
+			SetOs(s.Os).
+			SetArch(s.Arch).
+			SetPackagePath(s.PackagePath).
+			SetPackageHashSha256(s.PackageHashSha256).
+			SetFileType(s.FileType).
+			SetFileSize(s.FileSize).
+			SetPatchFormat(s.PatchFormat).
+			SetRolloutPercent(s.RolloutPercent).
+			SetNillableLastVerifiedAt(s.LastVerifiedAt).
+			SetVersionID(verID)
+		if s.FileHashes != nil {
+			c.SetFileHashes(s.FileHashes)
+		}
+		if s.Edges.OldVersion != nil {
+			c.SetOldVersionID(s.Edges.OldVersion.ID)
+		}
+		builders = append(builders, c)
+	}
+	return tx.Storage.CreateBulk(builders...).Exec(ctx)
+}
+
+func (r *Storage) UpdateStoragePackagePath(ctx context.Context, id int, packagePath string) error {
+	return r.db.Storage.UpdateOneID(id).
+		SetPackagePath(packagePath).
+		Exec(ctx)
+}
+
+// CountDirReferences storages of other versions whose package lies below one of the dirs
+func (r *Storage) CountDirReferences(ctx context.Context, dirs []string, excludeVersion int) (int, error) {
+	ps := make([]predicate.Storage, 0, len(dirs))
+	for _, d := range dirs {
+		ps = append(ps, storage.PackagePathHasPrefix(d))
+	}
+	return r.db.Storage.Query().
+		Where(
+			storage.Or(ps...),
+			storage.VersionStoragesNEQ(excludeVersion),
+		).
+		Count(ctx)
+}
+
+// YankStorages storages of the version, limited to a platform when os and arch are set, a nil time withdraws the yank
+func (r *Storage) YankStorages(ctx context.Context, verID int, os, arch *string, at *time.Time, reason string, downgrade bool) (int, error) {
+	u := r.db.Storage.Update().Where(storage.VersionStorages(verID))
+	if os != nil {
+		u.Where(storage.Os(*os))
+	}
+	if arch != nil {
+		u.Where(storage.Arch(*arch))
+	}
+	if at == nil {
+		u.ClearYankedAt().ClearYankReason().SetYankDowngrade(false)
+	} else {
+		u.SetYankedAt(*at).SetYankReason(reason).SetYankDowngrade(downgrade)
+	}
+	return u.Save(ctx)
+}
+
+// SetRolloutPercent full storages of the version, limited to a platform when os and arch are set
+func (r *Storage) SetRolloutPercent(ctx context.Context, verID int, os, arch *string, percent int) (int, error) {
+	u := r.db.Storage.Update().Where(
+		storage.VersionStorages(verID),
+		storage.UpdateTypeEQ(storage.UpdateTypeFull),
+	)
+	if os != nil {
+		u.Where(storage.Os(*os))
+	}
+	if arch != nil {
+		u.Where(storage.Arch(*arch))
+	}
+	return u.SetRolloutPercent(percent).Save(ctx)
+}
+
+// ListYankedVersions version name -> whether clients on it are offered the previous release
+func (r *Storage) ListYankedVersions(ctx context.Context, resourceId, os, arch string) (map[string]bool, error) {
+	list, err := r.db.Storage.Query().
+		Where(
+			storage.HasVersionWith(version.HasResourceWith(resource.ID(resourceId))),
+			storage.UpdateTypeEQ(storage.UpdateTypeFull),
+			storage.Os(os),
+			storage.Arch(arch),
+			storage.YankedAtNotNil(),
+		).
+		WithVersion(func(q *ent.VersionQuery) {
+			q.Select(version.FieldName)
+		}).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(list))
+	for _, s := range list {
+		if s.Edges.Version != nil {
+			result[s.Edges.Version.Name] = s.YankDowngrade
+		}
+	}
+	return result, nil
+}
@@ -2,9 +2,11 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/MirrorChyan/resource-backend/internal/ent"
 	"github.com/MirrorChyan/resource-backend/internal/ent/resource"
+	"github.com/MirrorChyan/resource-backend/internal/ent/storage"
 	"github.com/MirrorChyan/resource-backend/internal/ent/version"
 )
 
@@ -32,7 +34,71 @@ func (r *Version) GetMaxNumberVersion(ctx context.Context, resID string) (*ent.V
 		First(ctx)
 }
 
-func (r *Version) CreateVersion(ctx context.Context, resID string, channel version.Channel, name string, number uint64) (*ent.Version, error) {
+func (r *Version) GetMaxNumberChannelVersion(ctx context.Context, resID, channel string) (*ent.Version, error) {
+	return r.db.Version.Query().
+		Where(
+			version.HasResourceWith(resource.ID(resID)),
+			version.Channel(channel),
+		).
+		Order(ent.Desc(version.FieldNumber)).
+		First(ctx)
+}
+
+// ListPublishedVersions versions of the channels which are published by now and served on at least one platform,
+// versions still processing, yanked, broken or quarantined everywhere are left out
+func (r *Version) ListPublishedVersions(ctx context.Context, resID string, channels []string, now time.Time) ([]*ent.Version, error) {
+	return r.db.Version.Query().
+		Where(
+			version.HasResourceWith(resource.ID(resID)),
+			version.ChannelIn(channels...),
+			version.Or(version.PublishAtIsNil(), version.PublishAtLTE(now)),
+			version.HasStoragesWith(
+				storage.UpdateTypeEQ(storage.UpdateTypeFull),
+				storage.PackagePathNotNil(),
+				storage.BrokenAtIsNil(),
+				storage.QuarantinedAtIsNil(),
+				storage.YankedAtIsNil(),
+			),
+		).
+		Select(
+			version.FieldName,
+			version.FieldNumber,
+			version.FieldChannel,
+			version.FieldReleaseNote,
+			version.FieldReleaseNotes,
+			version.FieldCreatedAt,
+		).
+		Order(ent.Asc(version.FieldNumber)).
+		All(ctx)
+}
+
+// ListRecentPlatformVersions versions created before the given one with a full package for the platform, newest first
+func (r *Version) ListRecentPlatformVersions(ctx context.Context, resID, os, arch string, before, limit int) ([]*ent.Version, error) {
+	return r.db.Version.Query().
+		Where(
+			version.HasResourceWith(resource.ID(resID)),
+			version.IDLT(before),
+			version.HasStoragesWith(
+				storage.UpdateTypeEQ(storage.UpdateTypeFull),
+				storage.Os(os),
+				storage.Arch(arch),
+				storage.PackagePathNotNil(),
+			),
+		).
+		Order(ent.Desc(version.FieldNumber)).
+		Limit(limit).
+		Select(version.FieldID, version.FieldName).
+		All(ctx)
+}
+
+// HasStorages whether any package was stored for the version
+func (r *Version) HasStorages(ctx context.Context, verID int) (bool, error) {
+	return r.db.Version.Query().
+		Where(version.ID(verID), version.HasStorages()).
+		Exist(ctx)
+}
+
+func (r *Version) CreateVersion(ctx context.Context, resID string, channel, name string, number uint64) (*ent.Version, error) {
 	return r.db.Version.Create().
 		SetResourceID(resID).
 		SetChannel(channel).
@@ -41,12 +107,76 @@ func (r *Version) CreateVersion(ctx context.Context, resID string, channel versi
 		Save(ctx)
 }
 
+// CloneVersion a copy of src under another name and channel, storages are not cloned
+func (r *Version) CloneVersion(ctx context.Context, tx *ent.Tx, resID string, src *ent.Version, channel, name string, number uint64) (*ent.Version, error) {
+	return tx.Version.Create().
+		SetResourceID(resID).
+		SetChannel(channel).
+		SetName(name).
+		SetNumber(number).
+		SetReleaseNote(src.ReleaseNote).
+		SetReleaseNotes(src.ReleaseNotes).
+		SetCustomData(src.CustomData).
+		Save(ctx)
+}
+
+func (r *Version) SetVersionChannel(ctx context.Context, verID int, channel string) error {
+	return r.db.Version.UpdateOneID(verID).
+		SetChannel(channel).
+		Exec(ctx)
+}
+
 func (r *Version) UpdateVersionReleaseNote(ctx context.Context, verID int, releaseNote string) error {
 	return r.db.Version.UpdateOneID(verID).
 		SetReleaseNote(releaseNote).
 		Exec(ctx)
 }
 
+// SetLocalizedReleaseNote the note of one locale is written in place, an empty note removes the locale
+func (r *Version) SetLocalizedReleaseNote(ctx context.Context, verID int, locale, note string) error {
+	path := `$."` + locale + `"`
+	if note == "" {
+		_, err := r.dx.ExecContext(ctx,
+			"update versions set release_notes = json_remove(release_notes, ?) where id = ? and release_notes is not null",
+			path, verID)
+		return err
+	}
+	_, err := r.dx.ExecContext(ctx,
+		"update versions set release_notes = json_set(coalesce(release_notes, json_object()), ?, ?) where id = ?",
+		path, note, verID)
+	return err
+}
+
+// SetPublishAt a nil time publishes the version
+func (r *Version) SetPublishAt(ctx context.Context, verID int, at *time.Time) error {
+	u := r.db.Version.UpdateOneID(verID)
+	if at == nil {
+		u.ClearPublishAt()
+	} else {
+		u.SetPublishAt(*at)
+	}
+	return u.Exec(ctx)
+}
+
+func (r *Version) SetCritical(ctx context.Context, verID int, critical bool, reason string) error {
+	return r.db.Version.UpdateOneID(verID).
+		SetCritical(critical).
+		SetCriticalReason(reason).
+		Exec(ctx)
+}
+
+// ListCriticalVersions critical versions of the channels
+func (r *Version) ListCriticalVersions(ctx context.Context, resID string, channels []string) ([]*ent.Version, error) {
+	return r.db.Version.Query().
+		Where(
+			version.HasResourceWith(resource.ID(resID)),
+			version.ChannelIn(channels...),
+			version.Critical(true),
+		).
+		Select(version.FieldName, version.FieldCriticalReason).
+		All(ctx)
+}
+
 func (r *Version) UpdateVersionCustomData(ctx context.Context, verID int, customData string) error {
 	return r.db.Version.UpdateOneID(verID).
 		SetCustomData(customData).
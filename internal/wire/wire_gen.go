@@ -27,19 +27,20 @@ import (
 func NewHandlerSet(logger *zap.Logger, client *ent.Client, db *sqlx.DB, redisClient *redis.Client, redsyncRedsync *redsync.Redsync, taskQueue *tasks.TaskQueue, multiCacheGroup *cache.MultiCacheGroup, versionComparator *vercomp.VersionComparator) *HandlerSet {
 	repoRepo := repo.NewRepo(client, db)
 	resource := repo.NewResource(repoRepo)
-	resourceLogic := logic.NewResourceLogic(logger, resource, multiCacheGroup)
+	channel := repo.NewChannel(repoRepo)
+	distributeLogic := dispense.NewDistributeLogic(logger, redisClient)
+	resourceLogic := logic.NewResourceLogic(logger, resource, channel, distributeLogic, redisClient, multiCacheGroup, versionComparator)
 	resourceHandler := handler.NewResourceHandler(resourceLogic)
 	version := repo.NewVersion(repoRepo)
 	rawQuery := repo.NewRawQuery(repoRepo)
-	distributeLogic := dispense.NewDistributeLogic(logger, redisClient)
 	storage := repo.NewStorage(repoRepo)
-	storageLogic := logic.NewStorageLogic(logger, storage, resource, rawQuery)
+	storageLogic := logic.NewStorageLogic(logger, storage, resource, rawQuery, redisClient, redsyncRedsync)
 	versionLogic := logic.NewVersionLogic(logger, repoRepo, version, rawQuery, versionComparator, distributeLogic, resourceLogic, storageLogic, redisClient, redsyncRedsync, taskQueue, multiCacheGroup)
 	versionHandler := handler.NewVersionHandler(logger, resourceLogic, versionLogic, versionComparator)
 	storageHandler := handler.NewStorageHandler(logger, storageLogic)
 	metricsHandler := handler.NewMetricsHandler()
 	heathCheckHandler := handler.NewHeathCheckHandlerHandler()
-	adminHandler := handler.NewAdminHandler(logger, resourceLogic, versionLogic)
+	adminHandler := handler.NewAdminHandler(logger, resourceLogic, versionLogic, storageLogic, distributeLogic)
 	handlerSet := &HandlerSet{
 		ResourceHandler:   resourceHandler,
 		VersionHandler:    versionHandler,